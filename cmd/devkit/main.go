@@ -56,6 +56,7 @@ func main() {
 
 	actionChain := hooks.NewActionChain()
 	actionChain.Use(hooks.WithMetricEmission)
+	actionChain.Use(hooks.WithDryRun)
 
 	hooks.ApplyMiddleware(app.Commands, actionChain)
 
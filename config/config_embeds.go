@@ -13,3 +13,6 @@ var EnvExample string
 
 //go:embed .zeus
 var ZeusConfig string
+
+//go:embed forks.yaml
+var ForkPresetsYaml string
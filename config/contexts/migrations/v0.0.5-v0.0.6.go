@@ -0,0 +1,44 @@
+package contextMigrations
+
+import (
+	"github.com/Layr-Labs/devkit-cli/pkg/migration"
+
+	"gopkg.in/yaml.v3"
+)
+
+func Migration_0_0_5_to_0_0_6(user, old, new *yaml.Node) (*yaml.Node, error) {
+	// Extract funding section from new default
+	fundingNode := migration.ResolveNode(new, []string{"context", "funding"})
+
+	// Check if context exists in user config
+	contextNode := migration.ResolveNode(user, []string{"context"})
+	if contextNode == nil || contextNode.Kind != yaml.MappingNode {
+		// Something is wrong with user config, just return it unmodified
+		return user, nil
+	}
+
+	// Keep a funding section the user already wrote
+	hasFunding := false
+	for i := 0; i < len(contextNode.Content)-1; i += 2 {
+		if contextNode.Content[i].Value == "funding" {
+			hasFunding = true
+			break
+		}
+	}
+
+	// Add funding section to user config if missing
+	if fundingNode != nil && !hasFunding {
+		// Add the key with comment first
+		migration.EnsureKeyWithComment(user, []string{"context", "funding"}, "Wallet funding applied on `devnet start`")
+
+		// Pull users funding key node and replace it with the default mapping
+		keyNode := migration.ResolveNode(user, []string{"context", "funding"})
+		*keyNode = *migration.CloneNode(fundingNode)
+	}
+
+	// Upgrade the version
+	if v := migration.ResolveNode(user, []string{"version"}); v != nil {
+		v.Value = "0.0.6"
+	}
+	return user, nil
+}
@@ -0,0 +1,42 @@
+package contextMigrations
+
+import (
+	"github.com/Layr-Labs/devkit-cli/pkg/migration"
+
+	"gopkg.in/yaml.v3"
+)
+
+func Migration_0_0_6_to_0_0_7(user, old, new *yaml.Node) (*yaml.Node, error) {
+	// Extract devnet_backend from new default
+	backendNode := migration.ResolveNode(new, []string{"context", "devnet_backend"})
+
+	// Check if context exists in user config
+	contextNode := migration.ResolveNode(user, []string{"context"})
+	if contextNode == nil || contextNode.Kind != yaml.MappingNode {
+		// Something is wrong with user config, just return it unmodified
+		return user, nil
+	}
+
+	// Keep a backend the user already chose
+	hasBackend := false
+	for i := 0; i < len(contextNode.Content)-1; i += 2 {
+		if contextNode.Content[i].Value == "devnet_backend" {
+			hasBackend = true
+			break
+		}
+	}
+
+	// Add devnet_backend to user config if missing
+	if backendNode != nil && !hasBackend {
+		migration.EnsureKeyWithComment(user, []string{"context", "devnet_backend"}, `Backend running the devnet chains: "docker" (anvil in a container) or "anvil" (a local anvil process)`)
+
+		keyNode := migration.ResolveNode(user, []string{"context", "devnet_backend"})
+		*keyNode = *migration.CloneNode(backendNode)
+	}
+
+	// Upgrade the version
+	if v := migration.ResolveNode(user, []string{"version"}); v != nil {
+		v.Value = "0.0.7"
+	}
+	return user, nil
+}
@@ -0,0 +1,18 @@
+package contextMigrations
+
+import (
+	"github.com/Layr-Labs/devkit-cli/pkg/migration"
+
+	"gopkg.in/yaml.v3"
+)
+
+func Migration_0_0_7_to_0_0_8(user, old, new *yaml.Node) (*yaml.Node, error) {
+	// Append the slashing history at the bottom if missing
+	migration.EnsureKeyWithComment(user, []string{"context", "slashing_history"}, "Slashes sent by `devkit avs slash`")
+
+	// Upgrade the version
+	if v := migration.ResolveNode(user, []string{"version"}); v != nil {
+		v.Value = "0.0.8"
+	}
+	return user, nil
+}
@@ -16,6 +16,10 @@ var AVSCommand = &cli.Command{
 		context.Command,
 		BuildCommand,
 		DevnetCommand,
+		SetupCommand,
+		OperatorCommand,
+		RewardsCommand,
+		SlashCommand,
 		RunCommand,
 		CallCommand,
 		ReleaseCommand,
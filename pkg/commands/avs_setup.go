@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// setupContextFlag selects the context every setup subcommand reads and sends to
+var setupContextFlag = &cli.StringFlag{
+	Name:  "context",
+	Usage: "Context whose L1 chain, AVS and operators are used",
+	Value: devnet.CONTEXT,
+}
+
+// SetupCommand defines the "setup" command, exposing each AVS setup step `devnet start` runs
+var SetupCommand = &cli.Command{
+	Name:  "setup",
+	Usage: "Register the AVS and its operators with EigenLayer, step by step",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "update-avs-metadata",
+			Usage: "Update the AVS metadata URI",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "uri",
+					Usage: "Metadata URI to set; defaults to avs.metadata_url in the context",
+				},
+				setupContextFlag,
+			}, common.DryRunFlags...), common.GlobalFlags...),
+			Action: func(cCtx *cli.Context) error {
+				return UpdateAVSMetadataAction(cCtx, common.LoggerFromContext(cCtx.Context))
+			},
+		},
+		{
+			Name:  "set-avs-registrar",
+			Usage: "Set the AVS registrar the AllocationManager calls on operator registration",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "registrar",
+					Usage: "Registrar address to set; defaults to the AvsRegistrar in the context's deployed_contracts",
+				},
+				setupContextFlag,
+			}, common.DryRunFlags...), common.GlobalFlags...),
+			Action: func(cCtx *cli.Context) error {
+				return SetAVSRegistrarAction(cCtx, common.LoggerFromContext(cCtx.Context))
+			},
+		},
+		{
+			Name:  "create-avs-operator-sets",
+			Usage: "Create the AVS's operator sets that do not exist yet and add their missing strategies to those that do",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "operator-sets",
+					Usage: "YAML or JSON file listing the operator sets to create; defaults to operator_sets in the context",
+				},
+				setupContextFlag,
+			}, common.DryRunFlags...), common.GlobalFlags...),
+			Action: func(cCtx *cli.Context) error {
+				return CreateAVSOperatorSetsAction(cCtx, common.LoggerFromContext(cCtx.Context))
+			},
+		},
+		{
+			Name:  "register-operators-from-config",
+			Usage: "Register the context's operator_registrations with EigenLayer and the AVS",
+			Flags: append(append([]cli.Flag{
+				&cli.StringSliceFlag{
+					Name:  "operator",
+					Usage: "Only register this operator address; repeat for several",
+				},
+				setupContextFlag,
+			}, common.DryRunFlags...), common.GlobalFlags...),
+			Action: func(cCtx *cli.Context) error {
+				return RegisterOperatorsFromConfigAction(cCtx, common.LoggerFromContext(cCtx.Context))
+			},
+		},
+	},
+}
+
+// setupContextName returns the context selected by --context; `devnet start` runs the steps without it
+func setupContextName(cCtx *cli.Context) string {
+	if name := cCtx.String("context"); name != "" {
+		return name
+	}
+	return devnet.CONTEXT
+}
+
+// loadOperatorSetsFile reads the operator sets to create from a YAML or JSON file, laid out either like the
+// context's operator_sets list or as a mapping holding it under operator_sets
+func loadOperatorSetsFile(path string) ([]common.OperatorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator sets file: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse operator sets file %s: %w", path, err)
+	}
+	if len(node.Content) == 0 {
+		return nil, fmt.Errorf("operator sets file %s is empty", path)
+	}
+	listNode := node.Content[0]
+	if listNode.Kind == yaml.MappingNode {
+		listNode = common.GetChildByKey(listNode, "operator_sets")
+	}
+	if listNode == nil || listNode.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("operator sets file %s must hold a list of operator sets or an operator_sets list", path)
+	}
+
+	var operatorSets []common.OperatorSet
+	if err := listNode.Decode(&operatorSets); err != nil {
+		return nil, fmt.Errorf("failed to parse operator sets file %s: %w", path, err)
+	}
+	return operatorSets, nil
+}
+
+// filterOperatorRegistrations returns the registrations of the given operators, or all of them without a filter
+func filterOperatorRegistrations(registrations []common.OperatorRegistration, operators []string) ([]common.OperatorRegistration, error) {
+	if len(operators) == 0 {
+		return registrations, nil
+	}
+	filtered := []common.OperatorRegistration{}
+	for _, operator := range operators {
+		found := false
+		for _, opReg := range registrations {
+			if strings.EqualFold(opReg.Address, operator) {
+				filtered = append(filtered, opReg)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("operator %s has no operator_registrations in the context", operator)
+		}
+	}
+	return filtered, nil
+}
+
+// setupStepStatus is what an AVS setup step did
+type setupStepStatus int
+
+const (
+	setupStepChanged setupStepStatus = iota
+	setupStepSkipped
+	setupStepFailed
+)
+
+// setupStep is one entry of the setup summary
+type setupStep struct {
+	name   string
+	status setupStepStatus
+	detail string
+}
+
+// setupReport collects what each AVS setup step sent and what it found already done on chain, so reruns
+// against a devnet that already has state can show that they only changed what was missing
+type setupReport struct {
+	steps  []setupStep
+	dryRun bool
+}
+
+// changed records a step that sent a transaction
+func (r *setupReport) changed(name, format string, args ...any) {
+	r.steps = append(r.steps, setupStep{name: name, status: setupStepChanged, detail: fmt.Sprintf(format, args...)})
+}
+
+// skipped records a step the chain already satisfied
+func (r *setupReport) skipped(name, format string, args ...any) {
+	r.steps = append(r.steps, setupStep{name: name, status: setupStepSkipped, detail: fmt.Sprintf(format, args...)})
+}
+
+// failed records a step that failed without stopping the setup
+func (r *setupReport) failed(name string, err error) {
+	r.steps = append(r.steps, setupStep{name: name, status: setupStepFailed, detail: err.Error()})
+}
+
+// print logs every recorded step followed by a count of each status
+func (r *setupReport) print(logger iface.Logger) {
+	if len(r.steps) == 0 {
+		return
+	}
+
+	if r.dryRun {
+		logger.Title("AVS setup summary (dry run: changes were simulated, not sent)")
+	} else {
+		logger.Title("AVS setup summary")
+	}
+	counts := map[setupStepStatus]int{}
+	for _, step := range r.steps {
+		counts[step.status]++
+		switch step.status {
+		case setupStepChanged:
+			logger.Info("  ✅ %s: %s", step.name, step.detail)
+		case setupStepSkipped:
+			logger.Info("  ⏭️  %s: %s", step.name, step.detail)
+		case setupStepFailed:
+			logger.Error("  ❌ %s: %s", step.name, step.detail)
+		}
+	}
+	logger.Info("%d changed, %d already satisfied, %d failed", counts[setupStepChanged], counts[setupStepSkipped], counts[setupStepFailed])
+}
+
+// isRegisteredForSet reports whether sets, as returned by getRegisteredSets, contains the AVS's operator set
+func isRegisteredForSet(sets []allocationmanager.OperatorSet, avsAddress ethcommon.Address, operatorSetID uint32) bool {
+	for _, set := range sets {
+		if set.Avs == avsAddress && set.Id == operatorSetID {
+			return true
+		}
+	}
+	return false
+}
+
+// missingStrategies returns the strategies configured for an operator set that the existing set on chain lacks
+func missingStrategies(configured []common.Strategy, onChain []ethcommon.Address) []string {
+	present := make(map[ethcommon.Address]bool, len(onChain))
+	for _, strategy := range onChain {
+		present[strategy] = true
+	}
+	missing := []string{}
+	for _, strategy := range configured {
+		if !present[ethcommon.HexToAddress(strategy.StrategyAddress)] {
+			missing = append(missing, strategy.StrategyAddress)
+		}
+	}
+	return missing
+}
+
+// metadataSearchStart returns the block to search for the AVS's metadata events from. A forked chain only
+// holds the AVS's own events after the fork block, and searching the upstream chain's history is slow.
+func metadataSearchStart(chainCfg common.ChainConfig) uint64 {
+	if chainCfg.Fork != nil && chainCfg.Fork.Block > 0 {
+		return uint64(chainCfg.Fork.Block)
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSetupReportPrint(t *testing.T) {
+	log := logger.NewNoopLogger()
+
+	(&setupReport{}).print(log)
+	assert.Zero(t, log.Len(), "an empty report prints nothing")
+
+	report := &setupReport{}
+	report.skipped("AVS metadata", "already %q", "https://avs.example")
+	report.changed("Operator set 1", "created with %d strategies", 2)
+	report.failed("Operator 0x1", errors.New("execution reverted"))
+	report.print(log)
+
+	assert.True(t, log.ContainsLevel("TITLE", "AVS setup summary"))
+	assert.True(t, log.ContainsLevel("INFO", `AVS metadata: already "https://avs.example"`))
+	assert.True(t, log.ContainsLevel("INFO", "Operator set 1: created with 2 strategies"))
+	assert.True(t, log.ContainsLevel("ERROR", "Operator 0x1: execution reverted"))
+	assert.True(t, log.Contains("1 changed, 1 already satisfied, 1 failed"))
+}
+
+func TestIsRegisteredForSet(t *testing.T) {
+	avs := ethcommon.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	other := ethcommon.HexToAddress("0x0000000000000000000000000000000000000001")
+	sets := []allocationmanager.OperatorSet{{Avs: other, Id: 0}, {Avs: avs, Id: 1}}
+
+	assert.True(t, isRegisteredForSet(sets, avs, 1))
+	assert.False(t, isRegisteredForSet(sets, avs, 0), "set 0 belongs to another AVS")
+	assert.False(t, isRegisteredForSet(nil, avs, 1))
+}
+
+func TestMissingStrategies(t *testing.T) {
+	configured := []common.Strategy{
+		{StrategyAddress: "0x93c4b944D05dfe6df7645A86cd2206016c51564D"},
+		{StrategyAddress: "0x1BeE69b7dFFfA4E2d53C2a2Df135C388AD25dCD2"},
+	}
+	onChain := []ethcommon.Address{ethcommon.HexToAddress("0x93c4b944d05dfe6df7645a86cd2206016c51564d")}
+
+	assert.Equal(t, []string{"0x1BeE69b7dFFfA4E2d53C2a2Df135C388AD25dCD2"}, missingStrategies(configured, onChain))
+	assert.Empty(t, missingStrategies(configured[:1], onChain))
+}
+
+func TestMetadataSearchStart(t *testing.T) {
+	assert.Equal(t, uint64(0), metadataSearchStart(common.ChainConfig{}))
+	assert.Equal(t, uint64(22475020), metadataSearchStart(common.ChainConfig{Fork: &common.ForkConfig{Block: 22475020}}))
+}
+
+func TestLoadOperatorSetsFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	sets, err := loadOperatorSetsFile(write("sets.yaml", `
+- operator_set_id: 0
+  strategies:
+    - strategy: "0x93c4b944D05dfe6df7645A86cd2206016c51564D"
+- operator_set_id: 1
+  strategies: []
+`))
+	require.NoError(t, err)
+	require.Len(t, sets, 2)
+	assert.Equal(t, "0x93c4b944D05dfe6df7645A86cd2206016c51564D", sets[0].Strategies[0].StrategyAddress)
+	assert.Equal(t, uint64(1), sets[1].OperatorSetID)
+
+	sets, err = loadOperatorSetsFile(write("sets.json", `{"operator_sets": [{"operator_set_id": 3, "strategies": [{"strategy": "0x1BeE69b7dFFfA4E2d53C2a2Df135C388AD25dCD2"}]}]}`))
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	assert.Equal(t, uint64(3), sets[0].OperatorSetID)
+
+	_, err = loadOperatorSetsFile(write("bad.yaml", `operator_set_id: 0`))
+	assert.ErrorContains(t, err, "must hold a list of operator sets")
+
+	_, err = loadOperatorSetsFile(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestFilterOperatorRegistrations(t *testing.T) {
+	registrations := []common.OperatorRegistration{
+		{Address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906", OperatorSetID: 0},
+		{Address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65", OperatorSetID: 0},
+		{Address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906", OperatorSetID: 1},
+	}
+
+	all, err := filterOperatorRegistrations(registrations, nil)
+	require.NoError(t, err)
+	assert.Equal(t, registrations, all)
+
+	filtered, err := filterOperatorRegistrations(registrations, []string{"0x90f79bf6eb2c4f870365e785982e1f101e93b906"})
+	require.NoError(t, err)
+	assert.Equal(t, []common.OperatorRegistration{registrations[0], registrations[2]}, filtered)
+
+	_, err = filterOperatorRegistrations(registrations, []string{"0x0000000000000000000000000000000000000001"})
+	assert.ErrorContains(t, err, "has no operator_registrations")
+}
+
+func TestSetupCommands_RejectInvalidInput(t *testing.T) {
+	tmpDir, err := testutils.CreateTempAVSProject(t)
+	require.NoError(t, err)
+	oldWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWD)
+		os.RemoveAll(tmpDir)
+	})
+
+	cmdWithLogger, _ := testutils.WithTestConfigAndNoopLoggerAndAccess(SetupCommand)
+	app := &cli.App{Name: "devkit", Commands: []*cli.Command{cmdWithLogger}}
+
+	err = app.Run([]string{"devkit", "setup", "set-avs-registrar", "--registrar", "0x123"})
+	assert.ErrorContains(t, err, "invalid registrar address")
+
+	err = app.Run([]string{"devkit", "setup", "create-avs-operator-sets", "--operator-sets", "missing.yaml"})
+	assert.ErrorContains(t, err, "failed to read operator sets file")
+
+	err = app.Run([]string{"devkit", "setup", "update-avs-metadata", "--context", "holesky"})
+	assert.ErrorContains(t, err, "failed to load configurations")
+}
+
+func TestRegisterOperatorsFromConfig_FailsWhenRegistrationsFail(t *testing.T) {
+	tmpDir, err := testutils.CreateTempAVSProject(t)
+	require.NoError(t, err)
+	oldWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWD)
+		os.RemoveAll(tmpDir)
+	})
+
+	// Point the chains at a closed port so every registration fails to reach the chain
+	contextPath := filepath.Join(tmpDir, "config", "contexts", "devnet.yaml")
+	data, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+	contextYAML := strings.ReplaceAll(string(data), "http://localhost:8545", "http://127.0.0.1:1")
+	contextYAML = strings.Replace(contextYAML, "operator_registrations: []", `operator_registrations:
+    - address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+      operator_set_id: 0
+      payload: ""
+    - address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+      operator_set_id: 0
+      payload: ""`, 1)
+	require.NoError(t, os.WriteFile(contextPath, []byte(contextYAML), 0o644))
+
+	cmdWithLogger, _ := testutils.WithTestConfigAndNoopLoggerAndAccess(SetupCommand)
+	app := &cli.App{Name: "devkit", Commands: []*cli.Command{cmdWithLogger}}
+
+	err = app.Run([]string{"devkit", "setup", "register-operators-from-config"})
+	require.ErrorIs(t, err, errOperatorRegistrationsFailed)
+	assert.EqualError(t, err, "2 of 2 operator registrations failed")
+}
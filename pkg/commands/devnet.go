@@ -2,17 +2,18 @@ package commands
 
 import (
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
 	"github.com/urfave/cli/v2"
 )
 
 // DevnetCommand defines the "devnet" command
 var DevnetCommand = &cli.Command{
 	Name:  "devnet",
-	Usage: "Manage local AVS development network (Docker-based)",
+	Usage: "Manage local AVS development network (Docker or native anvil)",
 	Subcommands: []*cli.Command{
 		{
 			Name:  "start",
-			Usage: "Starts Docker containers and deploys local contracts",
+			Usage: "Starts the devnet chains and deploys local contracts",
 			Flags: append([]cli.Flag{
 				&cli.BoolFlag{
 					Name:  "reset",
@@ -20,22 +21,34 @@ var DevnetCommand = &cli.Command{
 				},
 				&cli.StringFlag{
 					Name:  "fork",
-					Usage: "Fork from a specific chain (e.g. Base, OP)",
+					Usage: "Fork named network presets, comma separated (mainnet, holesky, sepolia, hoodi, base, optimism or one from config/forks.yaml)",
+				},
+				&cli.BoolFlag{
+					Name:  "no-fork",
+					Usage: "Start fresh chains and deploy the EigenLayer core contracts locally instead of forking",
 				},
 				&cli.BoolFlag{
 					Name:  "headless",
-					Usage: "Run without showing logs or interactive TUI",
+					Usage: "Print plain log output instead of opening the interactive dashboard",
 				},
 				&cli.IntFlag{
 					Name:  "port",
-					Usage: "Specify a custom port for local devnet",
+					Usage: "Port for the devnet's L1 RPC, further chains take the ports after it; when omitted the first free ports from the default are used",
 					Value: 8545,
 				},
+				&cli.StringFlag{
+					Name:  "backend",
+					Usage: "Run the chains with docker (containers) or anvil (local anvil processes); overrides devnet_backend in the devnet context",
+				},
 				&cli.BoolFlag{
 					Name:  "skip-avs-run",
 					Usage: "Skip starting offchain AVS components",
 					Value: false,
 				},
+				&cli.BoolFlag{
+					Name:  "capture-logs",
+					Usage: "Capture the run script's output in .devkit/logs: run.log for all of it and <component>.log for lines tagged `[component]` or `component |`, readable with `devnet logs <component>`",
+				},
 				&cli.BoolFlag{
 					Name:  "skip-deploy-contracts",
 					Usage: "Skip deploying contracts and only start local devnet",
@@ -46,14 +59,71 @@ var DevnetCommand = &cli.Command{
 					Usage: "Skip AVS setup steps (metadata update, registrar setup, etc.) after contract deployment",
 					Value: false,
 				},
+				&cli.StringFlag{
+					Name:  "from-snapshot",
+					Usage: "Restore chain state and context from a saved snapshot instead of forking and deploying",
+				},
+				&cli.DurationFlag{
+					Name:  "ready-timeout",
+					Usage: "How long to wait for the devnet RPC and deployed contracts to become available",
+					Value: devnet.DEVNET_READY_TIMEOUT,
+				},
 				&cli.BoolFlag{
 					Name:  "use-zeus",
 					Usage: "Use Zeus CLI to fetch mainnet core addresses",
 					Value: false,
 				},
+				&cli.BoolFlag{
+					Name:  "keep-state",
+					Usage: "Keep the chain state when the devnet stops on exit so `devnet resume` can continue from it",
+				},
 			}, common.GlobalFlags...),
 			Action: StartDevnetAction,
 		},
+		{
+			Name:  "resume",
+			Usage: "Starts the devnet again from the chain state kept by `devnet stop --keep-state`",
+			Description: "Each chain is started on top of its saved state. Deploy and setup are skipped when every contract in the " +
+				"devnet context still has code; otherwise they run as in `devnet start`.",
+			Flags: append([]cli.Flag{
+				&cli.BoolFlag{
+					Name:  "headless",
+					Usage: "Print plain log output instead of opening the interactive dashboard",
+				},
+				&cli.IntFlag{
+					Name:  "port",
+					Usage: "Port for the devnet's L1 RPC, further chains take the ports after it; when omitted the first free ports from the default are used",
+					Value: 8545,
+				},
+				&cli.StringFlag{
+					Name:  "backend",
+					Usage: "Run the chains with docker (containers) or anvil (local anvil processes); overrides devnet_backend in the devnet context",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-avs-run",
+					Usage: "Skip starting offchain AVS components",
+				},
+				&cli.BoolFlag{
+					Name:  "capture-logs",
+					Usage: "Capture the run script's output in .devkit/logs: run.log for all of it and <component>.log for lines tagged `[component]` or `component |`, readable with `devnet logs <component>`",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-setup",
+					Usage: "Skip AVS setup steps if deploy has to run again",
+				},
+				&cli.DurationFlag{
+					Name:  "ready-timeout",
+					Usage: "How long to wait for the devnet RPC and deployed contracts to become available",
+					Value: devnet.DEVNET_READY_TIMEOUT,
+				},
+				&cli.BoolFlag{
+					Name:  "keep-state",
+					Usage: "Keep the chain state again when the devnet stops on exit",
+					Value: true,
+				},
+			}, common.GlobalFlags...),
+			Action: ResumeDevnetAction,
+		},
 		{
 			Name:   "deploy-contracts",
 			Usage:  "Deploy all L1/L2 and AVS contracts to devnet",
@@ -76,6 +146,10 @@ var DevnetCommand = &cli.Command{
 					Name:  "port",
 					Usage: "Stop container running on the specified port",
 				},
+				&cli.BoolFlag{
+					Name:  "keep-state",
+					Usage: "Keep the chain state anvil dumps on exit so `devnet resume` can continue from it",
+				},
 			},
 			Action: StopDevnetAction,
 		},
@@ -84,6 +158,12 @@ var DevnetCommand = &cli.Command{
 			Usage:  "Lists all running devkit devnet containers with their ports",
 			Action: ListDevnetContainersAction,
 		},
+		DevnetStatusCommand,
+		DevnetLogsCommand,
+		DevnetSnapshotCommand,
+		DevnetMineCommand,
+		DevnetWarpCommand,
+		DevnetAutomineCommand,
 		{
 			Name:   "fetch-addresses",
 			Usage:  "Fetches current EigenLayer core addresses from mainnet using Zeus CLI",
@@ -96,10 +176,5 @@ var DevnetCommand = &cli.Command{
 				},
 			},
 		},
-		// TODO: Surface the following actions as separate commands:
-		// - update-avs-metadata: Updates the AVS metadata URI on the devnet
-		// - set-avs-registrar: Sets the AVS registrar address on the devnet
-		// - create-avs-operator-sets: Creates AVS operator sets on the devnet
-		// - register-operators-from-config: Registers operators defined in config to Eigenlayer and the AVS on the devnet
 	},
 }
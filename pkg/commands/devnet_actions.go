@@ -9,7 +9,6 @@ import (
 	"io/fs"
 	"math/big"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -20,12 +19,13 @@ import (
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
 	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
 	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/progress"
 	"github.com/Layr-Labs/devkit-cli/pkg/migration"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 
 	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
 )
@@ -43,15 +43,17 @@ type DeployContractJson struct {
 }
 
 func StartDevnetAction(cCtx *cli.Context) error {
-	// Check if docker is running, else try to start it
-	if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+	return startDevnet(cCtx, false)
+}
 
-		if errors.Is(err, context.Canceled) {
-			return err // propagate the cancellation directly
-		}
-		return cli.Exit(err.Error(), 1)
-	}
+// ResumeDevnetAction starts the project's devnet again on top of the chain state kept by `devnet stop --keep-state`
+func ResumeDevnetAction(cCtx *cli.Context) error {
+	return startDevnet(cCtx, true)
+}
 
+// startDevnet starts one anvil per chain in the devnet context, then funds, deploys and sets up the AVS.
+// A resumed devnet loads each chain's kept state and skips deploy and setup when its contracts survived.
+func startDevnet(cCtx *cli.Context, resume bool) error {
 	// Get logger
 	logger := common.LoggerFromContext(cCtx.Context)
 
@@ -59,6 +61,10 @@ func StartDevnetAction(cCtx *cli.Context) error {
 	skipAvsRun := cCtx.Bool("skip-avs-run")
 	skipDeployContracts := cCtx.Bool("skip-deploy-contracts")
 	useZeus := cCtx.Bool("use-zeus")
+	noFork := cCtx.Bool("no-fork")
+	if noFork && useZeus {
+		return fmt.Errorf("--no-fork deploys EigenLayer locally and cannot be combined with --use-zeus")
+	}
 
 	// Migrate config
 	configMigrated, err := migrateConfig(logger)
@@ -88,6 +94,26 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return err
 	}
 
+	// Pick the backend that runs the chains; only the docker backend needs a Docker daemon
+	backendName, err := devnet.GetDevnetBackendName(config, cCtx.String("backend"))
+	if err != nil {
+		return err
+	}
+	if backendName == devnet.BACKEND_DOCKER {
+		// Check if docker is running, else try to start it
+		if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err // propagate the cancellation directly
+			}
+			return cli.Exit(err.Error(), 1)
+		}
+	}
+	backend, err := devnet.NewBackend(backendName)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
 	// Set path for context yamls
 	contextDir := filepath.Join("config", "contexts")
 	yamlPath := path.Join(contextDir, "devnet.yaml")
@@ -111,6 +137,83 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return fmt.Errorf("missing 'context' key in ./config/contexts/devnet.yaml")
 	}
 
+	// Resuming needs the chain state kept by the last stop
+	var saved *devnet.SavedDevnetState
+	if resume {
+		saved, err = devnet.ReadSavedDevnetState()
+		if err != nil {
+			return err
+		}
+		if saved.Project != config.Config.Project.Name {
+			logger.Warn("Saved devnet state belongs to project %s, not %s", saved.Project, config.Config.Project.Name)
+		}
+	}
+
+	// Wipe containers, context entries and outputs left by earlier runs before anything reads them
+	if cCtx.Bool("reset") {
+		config, err = resetDevnet(cCtx, logger, backend, config, rootNode, contextNode, yamlPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve the snapshot up front so a bad name fails before any container starts
+	var snapshot *devnet.SnapshotMetadata
+	if name := cCtx.String("from-snapshot"); name != "" {
+		snapshot, err = devnet.ReadSnapshotMetadata(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve --fork presets up front so an unknown name or missing RPC url fails before any container starts
+	forkPresets := make(map[string]devnet.ForkPreset)
+	if selection := cCtx.String("fork"); selection != "" {
+		if noFork {
+			return fmt.Errorf("--fork and --no-fork cannot be combined")
+		}
+		available, err := devnet.LoadForkPresets()
+		if err != nil {
+			return err
+		}
+		selected, err := devnet.SelectForkPresets(available, selection)
+		if err != nil {
+			return err
+		}
+		for _, preset := range selected {
+			if _, ok := config.Context[devnet.CONTEXT].Chains[preset.Chain]; !ok {
+				return fmt.Errorf("fork preset %s targets chain %s, which is not defined in ./config/contexts/devnet.yaml", preset.Name, preset.Chain)
+			}
+			forkPresets[preset.Chain] = preset
+		}
+	}
+
+	containerManager, err := devnet.NewContainerManager()
+	if err != nil {
+		return err
+	}
+	defer containerManager.Close()
+	registry, err := devnet.ReconcileDevnetRegistry(cCtx.Context, containerManager)
+	if err != nil {
+		return err
+	}
+	// A devnet of this project that is still running would be replaced under its feet; --reset has already
+	// removed it
+	if !cCtx.Bool("reset") {
+		if err := registry.CheckProjectStopped(config.Config.Project.Name); err != nil {
+			return err
+		}
+	}
+
+	// Values an earlier run set for itself only (e.g. --no-fork EigenLayer addresses) are put back before this
+	// run decides its own; a resumed devnet continues on the chains they describe, so it keeps them
+	if !resume {
+		config, err = restoreContextOverrides(logger, config, rootNode, contextNode, yamlPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Fetch EigenLayer addresses using Zeus if requested
 	if useZeus {
 		logger.Info("Fetching EigenLayer core addresses from Zeus...")
@@ -127,79 +230,92 @@ func StartDevnetAction(cCtx *cli.Context) error {
 			}
 		}
 	}
-	port := cCtx.Int("port")
-	if !devnet.IsPortAvailable(port) {
-		return fmt.Errorf("❌ Port %d is already in use. Please choose a different port using --port", port)
-	}
-	chainImage := devnet.GetDevnetChainImageOrDefault(config)
-	chainArgs := devnet.GetDevnetChainArgsOrDefault(config)
 
-	// Start timer
-	startTime := time.Now()
-
-	logger.Info("Starting devnet...\n")
-
-	if cCtx.Bool("reset") {
-		logger.Debug("Resetting devnet...")
-	}
-	if fork := cCtx.String("fork"); fork != "" {
-		logger.Debug("Forking from chain: %s", fork)
-	}
-	if cCtx.Bool("headless") {
-		logger.Debug("Running in headless mode")
+	// An explicit --port is used as given; otherwise take the first free range from the default,
+	// skipping ports other devnets in the registry have claimed
+	basePort := cCtx.Int("port")
+	if !cCtx.IsSet("port") {
+		basePort, err = devnet.FindFreePortRange(basePort, len(devnet.GetDevnetChainNames(config)), registry.Ports(), devnet.IsPortAvailable)
+		if err != nil {
+			return err
+		}
+		if basePort != cCtx.Int("port") {
+			logger.Info("Port %d is in use; starting devnet on port %d", cCtx.Int("port"), basePort)
+		}
 	}
 
-	// Docker-compose for anvil devnet
-	composePath := devnet.WriteEmbeddedArtifacts()
-	forkUrl, err := devnet.GetDevnetForkUrlDefault(config, devnet.L1)
+	// Resolve one anvil per chain in the context, L1 on the base port and the rest on the ports after it
+	chains, err := devnet.ResolveDevnetChains(config, basePort)
 	if err != nil {
-		return fmt.Errorf("%w", err)
+		return err
 	}
+	resumedChains := make(map[string]bool, len(chains))
+	for i := range chains {
+		chain := &chains[i]
+		if !devnet.IsPortAvailable(chain.Port) {
+			return fmt.Errorf("❌ Port %d (chain %s) is already in use. Please choose a different port using --port, or omit --port to pick a free one", chain.Port, chain.Name)
+		}
 
-	// Error if the forkUrl has not been modified
-	if forkUrl == "" {
-		return fmt.Errorf("fork-url not set; set fork-url in ./config/context/devnet.yaml or .env and consult README for guidance")
+		// A snapshot carries its own state so those chains start empty instead of forking
+		var snapshotChain devnet.SnapshotChain
+		inSnapshot := false
+		if snapshot != nil {
+			snapshotChain, inSnapshot = snapshot.Chains[chain.Name]
+		}
+		// A resumed chain is started the way it was before so its state loads on top of the same fork
+		var savedChain devnet.SavedDevnetChain
+		if saved != nil {
+			savedChain, resumedChains[chain.Name] = saved.Chains[chain.Name]
+			if !resumedChains[chain.Name] {
+				logger.Warn("No saved state for chain %s; starting it fresh", chain.Name)
+			}
+		}
+		if resumedChains[chain.Name] {
+			chain.ChainID = savedChain.ChainID
+			chain.ForkBlock = savedChain.ForkBlock
+			if !savedChain.Forked {
+				chain.ForkURL = ""
+			} else if chain.ForkURL == "" {
+				return fmt.Errorf("chain %s was forked when its state was saved; set its fork-url in ./config/contexts/devnet.yaml or .env to resume", chain.Name)
+			}
+		} else if inSnapshot {
+			chain.ChainID = int(snapshotChain.ChainID)
+			chain.ForkURL = ""
+			chain.ForkBlock = 0
+		} else if noFork {
+			chain.ForkURL = ""
+			chain.ForkBlock = 0
+		} else if preset, ok := forkPresets[chain.Name]; ok {
+			if err := applyForkPreset(cCtx, logger, config, contextNode, chain, preset); err != nil {
+				return err
+			}
+		} else if chain.ForkURL == "" && chain.Name == devnet.L1 {
+			return fmt.Errorf("fork-url not set; set fork-url in ./config/context/devnet.yaml or .env, or pass --no-fork to deploy EigenLayer locally, and consult README for guidance")
+		} else if chain.ForkURL == "" {
+			logger.Warn("fork-url not set for %s; starting it as a fresh chain", chain.Name)
+			chain.ForkBlock = 0
+		}
 	}
+	chainImage := devnet.GetDevnetChainImageOrDefault(config)
+	baseChainArgs := devnet.GetDevnetChainArgsOrDefault(config)
 
-	// Ensure fork URL uses appropriate Docker host for container environments
-	dockerForkUrl := devnet.EnsureDockerHost(forkUrl)
+	// Start timer
+	startTime := time.Now()
 
-	// Get the block_time from env/config
-	blockTime, err := devnet.GetDevnetBlockTimeOrDefault(config, devnet.L1)
-	if err != nil {
-		blockTime = 12
-	}
+	logger.Info("Starting devnet with the %s backend...\n", backendName)
 
-	// Get the chain_id from env/config
-	chainId, err := devnet.GetDevnetChainIdOrDefault(config, devnet.L1)
-	if err != nil {
-		chainId = common.DefaultAnvilChainId
+	// The dashboard needs a terminal, so anything else falls back to plain log output
+	showDashboard := !cCtx.Bool("headless") && progress.IsTTY()
+	if !showDashboard {
+		logger.Debug("Running in headless mode")
 	}
 
-	// Append config defined details to chainArgs
-	chainArgs = fmt.Sprintf("%s --chain-id %d", chainArgs, chainId)
-	chainArgs = fmt.Sprintf("%s --block-time %d", chainArgs, blockTime)
-
-	// Run docker compose up for anvil devnet
-	cmd := exec.CommandContext(cCtx.Context, "docker", "compose", "-p", config.Config.Project.Name, "-f", composePath, "up", "-d")
-
-	containerName := fmt.Sprintf("devkit-devnet-%s", config.Config.Project.Name)
-	l1ChainConfig, found := config.Context[devnet.CONTEXT].Chains["l1"]
-	if !found {
-		return fmt.Errorf("failed to find a chain with name: l1 in devnet.yaml")
-	}
-	cmd.Env = append(os.Environ(),
-		"FOUNDRY_IMAGE="+chainImage,
-		"ANVIL_ARGS="+chainArgs,
-		fmt.Sprintf("DEVNET_PORT=%d", port),
-		"FORK_RPC_URL="+dockerForkUrl,
-		fmt.Sprintf("FORK_BLOCK_NUMBER=%d", l1ChainConfig.Fork.Block),
-		"AVS_CONTAINER_NAME="+containerName,
-	)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("❌ Failed to start devnet: %w", err)
+	if snapshot != nil {
+		logger.Info("Restoring from snapshot %s", snapshot.Name)
 	}
 
+	projectName := config.Config.Project.Name
+
 	// On cancel, always call down if skipAvsRun=false
 	if !skipDeployContracts && !skipAvsRun {
 		defer func() {
@@ -207,14 +323,49 @@ func StartDevnetAction(cCtx *cli.Context) error {
 			// clone cCtx but overwrite the context to Background
 			cloned := *cCtx
 			cloned.Context = context.Background()
-			if err := StopDevnetAction(&cloned); err != nil {
-				logger.Warn("automatic StopDevnetAction failed: %v", err)
+			if err := stopProjectDevnet(&cloned, containerManager, projectName, cCtx.Bool("keep-state")); err != nil {
+				logger.Warn("automatic devnet stop failed: %v", err)
 			}
 		}()
 	}
 
-	// Construct RPC url to pass to scripts
-	rpcUrl := devnet.GetRPCURL(port)
+	// State kept by an earlier stop is only loaded by resume, so a fresh start drops it
+	if !resume {
+		if err := devnet.DiscardDevnetState(); err != nil {
+			return err
+		}
+	}
+
+	rpcUrls := make(map[string]string, len(chains))
+	for _, chain := range chains {
+		if resumedChains[chain.Name] {
+			logger.Info("Resuming chain %s from %s", chain.Name, devnet.GetDevnetChainStatePath(chain.Name))
+		}
+		err := backend.StartChain(cCtx.Context, devnet.ChainLaunch{
+			ProjectName: projectName,
+			Chain:       chain,
+			Image:       chainImage,
+			BaseArgs:    baseChainArgs,
+			Resume:      resumedChains[chain.Name],
+		})
+		if err != nil {
+			return fmt.Errorf("❌ Failed to start devnet chain %s: %w", chain.Name, err)
+		}
+
+		// Construct RPC url to pass to scripts
+		rpcUrls[chain.Name] = devnet.GetRPCURL(chain.Port)
+
+		// Record the chain in the global registry so list and stop can find it from any directory
+		if err := registerDevnetChain(cCtx.Context, backend, projectName, chain); err != nil {
+			logger.Warn("Failed to record devnet chain %s in the devnet registry: %v", chain.Name, err)
+		}
+	}
+
+	// Describe the chains next to their dumped state so `devnet stop --keep-state` can keep it for resume
+	if err := devnet.RecordDevnetState(projectName, chains); err != nil {
+		logger.Warn("Failed to record devnet state: %v", err)
+	}
+
 	logger.Info("Waiting for devnet to be ready...")
 
 	// Get chains node
@@ -223,8 +374,12 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return fmt.Errorf("missing 'chains' key in context")
 	}
 
-	// Update RPC URLs for both L1 and L2 chains
+	// Point each chain's rpc_url at its own anvil
 	for i := 0; i < len(chainsNode.Content); i += 2 {
+		rpcUrl, ok := rpcUrls[chainsNode.Content[i].Value]
+		if !ok {
+			continue
+		}
 		chainNode := chainsNode.Content[i+1]
 
 		rpcUrlNode := common.GetChildByKey(chainNode, "rpc_url")
@@ -238,17 +393,164 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return err
 	}
 
-	// Sleep for 4 second to ensure the devnet is fully started
-	time.Sleep(4 * time.Second)
-	// Fund the wallets defined in config
-	err = devnet.FundWalletsDevnet(config, rpcUrl)
+	// Block until every chain answers RPC calls at its configured chain id and fork block
+	for _, chain := range chains {
+		err = devnet.WaitForDevnetReady(cCtx.Context, logger, rpcUrls[chain.Name], devnet.ReadinessOptions{
+			ExpectedChainID: uint64(chain.ChainID),
+			ForkBlock:       uint64(chain.ForkBlock),
+			Timeout:         cCtx.Duration("ready-timeout"),
+			LogsCommand:     "devkit avs devnet logs " + chain.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("devnet chain %s failed to become ready: %w", chain.Name, err)
+		}
+	}
+
+	// Without a fork there is no EigenLayer on L1, so deploy the core contracts and point the context at them
+	if noFork && snapshot == nil {
+		if err := deployLocalEigenLayer(cCtx, logger, config, rootNode, contextNode, yamlPath, rpcUrls[devnet.L1]); err != nil {
+			return err
+		}
+	}
+
+	// Restoring a snapshot replaces funding, deployment and setup
+	if snapshot != nil {
+		if err := restoreDevnetSnapshot(cCtx, snapshot, rpcUrls); err != nil {
+			return fmt.Errorf("restoring snapshot %s failed: %w", snapshot.Name, err)
+		}
+		elapsed := time.Since(startTime).Round(time.Second)
+		logger.Info("\nDevnet restored from snapshot %s in %s", snapshot.Name, elapsed)
+	} else if resume && resumedContractsIntact(cCtx, logger, config, rpcUrls[devnet.L1]) {
+		// The kept state already holds the funded wallets, deployed contracts and AVS setup
+		elapsed := time.Since(startTime).Round(time.Second)
+		logger.Info("\nDevnet resumed in %s; deployed contracts found, skipping deploy and setup", elapsed)
+	} else if err := setupDevnet(cCtx, logger, config, rpcUrls, startTime); err != nil {
+		return err
+	}
+
+	// Start offchain AVS components after starting devnet and deploying contracts unless skipped
+	if !skipDeployContracts && !skipAvsRun {
+		if showDashboard {
+			return runDevnetDashboard(cCtx, projectName, rpcUrls)
+		}
+		if err := AVSRun(cCtx); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("avs run failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// restoreContextOverrides puts back the devnet context values earlier runs replaced for themselves and reloads
+// the config from the restored file
+func restoreContextOverrides(logger iface.Logger, config *common.ConfigWithContextConfig, rootNode, contextNode *yaml.Node, yamlPath string) (*common.ConfigWithContextConfig, error) {
+	restored, err := devnet.RestoreContextOverrides(contextNode)
+	if err != nil {
+		return nil, err
+	}
+	if len(restored) == 0 {
+		return config, nil
+	}
+	if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+		return nil, fmt.Errorf("failed to restore devnet context: %w", err)
+	}
+	if err := devnet.ClearContextOverrides(); err != nil {
+		return nil, err
+	}
+	for _, override := range restored {
+		logger.Info("Restored %s replaced by the last %s run", override.Key(), override.Reason)
+	}
+	return common.LoadConfigWithContextConfig(devnet.CONTEXT)
+}
+
+// deployLocalEigenLayer deploys the EigenLayer core on L1 and points the devnet context at it for this run. The
+// addresses only exist on this chain, so the next start that is not a resume restores the previous eigenlayer block.
+// checkOperatorSetStrategiesDeployed fails when an operator set in the devnet context references a strategy that has
+// no code on the chain at rpcURL
+func checkOperatorSetStrategiesDeployed(cCtx *cli.Context, rpcURL string) error {
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	var strategies []common.DeployedContract
+	for _, opSet := range cfg.Context[devnet.CONTEXT].OperatorSets {
+		for _, strategy := range opSet.Strategies {
+			strategies = append(strategies, common.DeployedContract{
+				Name:    fmt.Sprintf("%s (operator set %d)", strategy.StrategyAddress, opSet.OperatorSetID),
+				Address: strategy.StrategyAddress,
+			})
+		}
+	}
+	missing, err := devnet.ContractsWithoutCode(cCtx.Context, rpcURL, strategies)
+	if err != nil {
+		return fmt.Errorf("failed to check operator set strategies: %w", err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("--no-fork deploys no strategies, but operator_sets in ./config/contexts/devnet.yaml reference strategies with no code on the devnet: %s; "+
+			"deploy them with your AVS contracts, remove them from operator_sets, or start with a fork url", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func deployLocalEigenLayer(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, rootNode, contextNode *yaml.Node, yamlPath string, rpcUrl string) error {
+	logger.Title("Deploying EigenLayer core contracts locally...")
+	signer, err := common.DeployerSigner(config.Context[devnet.CONTEXT])
 	if err != nil {
 		return err
 	}
-	elapsed := time.Since(startTime).Round(time.Second)
+	if signer == nil {
+		return fmt.Errorf("--no-fork deploys EigenLayer from the deployer: set deployer_signer or deployer_private_key in ./config/contexts/devnet.yaml")
+	}
+	addresses, err := devnet.DeployEigenLayerCore(cCtx.Context, logger, rpcUrl, signer)
+	if err != nil {
+		return fmt.Errorf("failed to deploy EigenLayer core contracts: %w", err)
+	}
+
+	eigenLayer := addresses.EigenLayerConfig()
+	if err := devnet.SetContextEigenLayer(contextNode, eigenLayer, "--no-fork"); err != nil {
+		return err
+	}
+	if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+		return fmt.Errorf("failed to save EigenLayer addresses to context: %w", err)
+	}
+
+	// Keep the in-memory config in step with the file for the setup that follows
+	envCtx := config.Context[devnet.CONTEXT]
+	envCtx.EigenLayer = eigenLayer
+	config.Context[devnet.CONTEXT] = envCtx
+	return nil
+}
 
-	// Sleep for 1 second to make sure wallets are funded
-	time.Sleep(1 * time.Second)
+// resumedContractsIntact reports whether every contract in the devnet context still has code on the resumed L1
+func resumedContractsIntact(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, rpcUrl string) bool {
+	contracts := config.Context[devnet.CONTEXT].DeployedContracts
+	if len(contracts) == 0 {
+		logger.Info("No deployed contracts recorded in the devnet context; running deploy and setup")
+		return false
+	}
+	missing, err := devnet.ContractsWithoutCode(cCtx.Context, rpcUrl, contracts)
+	if err != nil {
+		logger.Warn("Failed to check deployed contracts: %v; running deploy and setup", err)
+		return false
+	}
+	if len(missing) > 0 {
+		logger.Warn("No code found for %s on the resumed devnet; running deploy and setup", strings.Join(missing, ", "))
+		return false
+	}
+	return true
+}
+
+// setupDevnet funds wallets on every chain, then deploys contracts and registers the AVS and its operators on L1
+func setupDevnet(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, rpcUrls map[string]string, startTime time.Time) error {
+	skipDeployContracts := cCtx.Bool("skip-deploy-contracts")
+
+	// Fund the wallets defined in config on each chain
+	for _, chainName := range devnet.GetDevnetChainNames(config) {
+		if err := devnet.FundWalletsDevnet(cCtx.Context, logger, config, rpcUrls[chainName]); err != nil {
+			return fmt.Errorf("funding wallets on %s failed: %w", chainName, err)
+		}
+	}
+	elapsed := time.Since(startTime).Round(time.Second)
 	logger.Info("\nDevnet started successfully in %s", elapsed)
 
 	// Deploy the contracts after starting devnet unless skipped
@@ -257,24 +559,40 @@ func StartDevnetAction(cCtx *cli.Context) error {
 			return fmt.Errorf("deploy-contracts failed: %w", err)
 		}
 
-		// Sleep for 1 second to make sure new context values have been written
-		time.Sleep(1 * time.Second)
+		// Confirm every contract recorded in the context has code before interacting with it
+		if err := waitForDeployedContracts(cCtx, rpcUrls[devnet.L1]); err != nil {
+			return fmt.Errorf("deployed contracts not found on devnet: %w", err)
+		}
 
 		logger.Title("Registering AVS with EigenLayer...")
 
+		// A fresh chain has no strategies, so operator sets pointing at fork strategies could never be staked
+		if cCtx.Bool("no-fork") && !cCtx.Bool("skip-setup") {
+			if err := checkOperatorSetStrategiesDeployed(cCtx, rpcUrls[devnet.L1]); err != nil {
+				return err
+			}
+		}
+
 		if !cCtx.Bool("skip-setup") {
-			if err := UpdateAVSMetadataAction(cCtx, logger); err != nil {
+			// Each step checks the chain first, so rerunning the setup only sends what is missing
+			report := &setupReport{}
+			defer report.print(logger)
+
+			if err := updateAVSMetadata(cCtx, logger, report); err != nil {
 				return fmt.Errorf("updating AVS metadata failed: %w", err)
 			}
-			if err := SetAVSRegistrarAction(cCtx, logger); err != nil {
+			if err := setAVSRegistrar(cCtx, logger, report); err != nil {
 				return fmt.Errorf("setting AVS registrar failed: %w", err)
 			}
-			if err := CreateAVSOperatorSetsAction(cCtx, logger); err != nil {
+			if err := createAVSOperatorSets(cCtx, logger, report); err != nil {
 				return fmt.Errorf("creating AVS operator sets failed: %w", err)
 			}
 			logger.Info("AVS registered with EigenLayer successfully.")
 
-			if err := RegisterOperatorsFromConfigAction(cCtx, logger); err != nil {
+			// Failed registrations are listed in the report; the devnet stays up so they can be retried
+			if err := registerOperatorsFromConfig(cCtx, logger, report); errors.Is(err, errOperatorRegistrationsFailed) {
+				logger.Warn("%v; rerun `devkit avs setup register-operators-from-config` to retry them", err)
+			} else if err != nil {
 				return fmt.Errorf("registering operators failed: %w", err)
 			}
 		} else {
@@ -282,23 +600,27 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		}
 	}
 
-	// Start offchain AVS components after starting devnet and deploying contracts unless skipped
-	if !skipDeployContracts && !skipAvsRun {
-		if err := AVSRun(cCtx); err != nil && !errors.Is(err, context.Canceled) {
-			return fmt.Errorf("avs run failed: %w", err)
-		}
-	}
-
 	return nil
 }
 
 func DeployContractsAction(cCtx *cli.Context) error {
 	// Get logger
 	logger := common.LoggerFromContext(cCtx.Context)
-	// Check if docker is running, else try to start it
-	err := common.EnsureDockerIsRunning(cCtx)
+
+	// Chains run by the anvil backend do not need Docker
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	backendName, err := devnet.GetDevnetBackendName(cfg, cCtx.String("backend"))
 	if err != nil {
-		return cli.Exit(err.Error(), 1)
+		return err
+	}
+	if backendName == devnet.BACKEND_DOCKER {
+		// Check if docker is running, else try to start it
+		if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
 	}
 
 	// Start timing execution runtime
@@ -413,21 +735,26 @@ func StopDevnetAction(cCtx *cli.Context) error {
 	// Get logger
 	log := common.LoggerFromContext(cCtx.Context)
 
+	containerManager, err := devnet.NewContainerManager()
+	if err != nil {
+		return err
+	}
+	defer containerManager.Close()
+
 	// Read flags
 	stopAllContainers := cCtx.Bool("all")
+	keepState := cCtx.Bool("keep-state")
+	if keepState && (stopAllContainers || cCtx.String("project.name") != "" || cCtx.Int("port") != 0) {
+		return fmt.Errorf("--keep-state keeps the state of the project in the current directory; run it there without --all, --project.name or --port")
+	}
 
 	// Should we stop all?
 	if stopAllContainers {
-		// Get all running containers
-		cmd := exec.CommandContext(cCtx.Context, "docker", devnet.GetDockerPsDevnetArgs()...)
-		output, err := cmd.Output()
+		registry, err := devnet.ReconcileDevnetRegistry(cCtx.Context, containerManager)
 		if err != nil {
-			return fmt.Errorf("failed to list devnet containers: %w", err)
+			return err
 		}
-		containerNames := strings.Split(strings.TrimSpace(string(output)), "\n")
-
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
+		if len(registry.Devnets) == 0 {
 			fmt.Printf("%s🚫 No devnet containers running.%s\n", devnet.Yellow, devnet.Reset)
 			return nil
 		}
@@ -436,15 +763,8 @@ func StopDevnetAction(cCtx *cli.Context) error {
 			log.Info("Attempting to stop devnet containers...")
 		}
 
-		for _, name := range containerNames {
-			name = strings.TrimSpace(name)
-			if name == "" {
-				continue
-			}
-			containerName := strings.Split(name, ": ")[0]
-
-			devnet.StopAndRemoveContainer(cCtx, containerName)
-
+		for _, entry := range registry.Sorted() {
+			devnet.StopAndRemoveContainer(cCtx, containerManager, entry.ContainerName)
 		}
 
 		return nil
@@ -456,44 +776,20 @@ func StopDevnetAction(cCtx *cli.Context) error {
 	// Check if any of the args are provided
 	if !(projectName == "") || !(projectPort == 0) {
 		if projectName != "" {
-			container := fmt.Sprintf("devkit-devnet-%s", projectName)
-			devnet.StopAndRemoveContainer(cCtx, container)
+			stopProjectContainers(cCtx, containerManager, projectName)
 		} else {
-			// project.name is empty, but port is provided
-			// List all running Docker containers whose names include "devkit-devnet",
-			// and format the output to show each container's name and its exposed ports.
-			cmd := exec.CommandContext(cCtx.Context, "docker", devnet.GetDockerPsDevnetArgs()...)
-
-			output, err := cmd.Output()
+			// project.name is empty, but port is provided: stop the devnet the registry has on that port
+			registry, err := devnet.ReconcileDevnetRegistry(cCtx.Context, containerManager)
 			if err != nil {
-				log.Warn("Failed to list running devnet containers: %v", err)
-			}
-
-			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-			containerFoundUsingthePort := false
-			for _, line := range lines {
-				parts := strings.Split(line, ": ")
-				if len(parts) != 2 {
-					continue
-				}
-				containerName := parts[0]
-				port := parts[1]
-				hostPort := extractHostPort(port)
-
-				if hostPort == fmt.Sprintf("%d", projectPort) {
-					// Derive project name from container name
-					projectName := strings.TrimPrefix(containerName, "devkit-devnet-")
-					devnet.StopAndRemoveContainer(cCtx, containerName)
-
-					log.Info("Stopped devnet container running on port %d, project.name %s", projectPort, projectName)
-					containerFoundUsingthePort = true
-					break
-				}
+				return err
 			}
-			if !containerFoundUsingthePort {
+			entry, ok := registry.FindPort(projectPort)
+			if !ok {
 				log.Info("No container found with port %d. Try %sdevkit avs devnet list%s to get a list of running devnet containers", projectPort, devnet.Cyan, devnet.Reset)
+				return nil
 			}
-
+			stopProjectContainers(cCtx, containerManager, entry.ProjectName)
+			log.Info("Stopped devnet running on port %d, project.name %s", projectPort, entry.ProjectName)
 		}
 		return nil
 	}
@@ -505,9 +801,7 @@ func StopDevnetAction(cCtx *cli.Context) error {
 			return err
 		}
 
-		container := fmt.Sprintf("devkit-devnet-%s", config.Config.Project.Name)
-
-		devnet.StopAndRemoveContainer(cCtx, container)
+		return stopProjectDevnet(cCtx, containerManager, config.Config.Project.Name, keepState)
 
 	} else {
 		log.Info("Run this command from the avs directory  or run %sdevkit avs devnet stop --help%s for available commands", devnet.Cyan, devnet.Reset)
@@ -516,49 +810,141 @@ func StopDevnetAction(cCtx *cli.Context) error {
 	return nil
 }
 
+// stopProjectDevnet stops the devnet of the project in the current directory. With keepState the chain state
+// anvil dumps on exit is kept for `devnet resume`, otherwise it is discarded.
+func stopProjectDevnet(cCtx *cli.Context, containerManager devnet.ContainerManager, projectName string, keepState bool) error {
+	if keepState && !devnet.IsDevnetStateRecorded() {
+		return fmt.Errorf("this devnet was started without state persistence; restart it with `devkit avs devnet start` to use --keep-state")
+	}
+
+	stopProjectContainers(cCtx, containerManager, projectName)
+	if !keepState {
+		return devnet.DiscardDevnetState()
+	}
+
+	chains, err := devnet.KeepDevnetState()
+	if err != nil {
+		return fmt.Errorf("failed to keep devnet state: %w", err)
+	}
+	fmt.Printf("%s💾 Kept state of %s in %s; run %sdevkit avs devnet resume%s%s to continue%s\n",
+		devnet.Green, strings.Join(chains, ", "), devnet.GetDevnetStateDir(), devnet.Cyan, devnet.Reset, devnet.Green, devnet.Reset)
+	return nil
+}
+
+// stopProjectContainers stops and removes the container of every chain in the project's devnet,
+// found through the project label whether or not the container is still running
+func stopProjectContainers(cCtx *cli.Context, containerManager devnet.ContainerManager, projectName string) {
+	log := common.LoggerFromContext(cCtx.Context)
+
+	containerNames := []string{}
+	containers, err := containerManager.List(cCtx.Context, devnet.ContainerFilter{Project: projectName, All: true})
+	if err != nil {
+		log.Warn("Failed to list devnet containers for project %s: %v", projectName, err)
+	}
+	for _, c := range containers {
+		containerNames = append(containerNames, c.Name)
+	}
+
+	// Containers started before chains were labelled only carry the L1 name
+	if len(containerNames) == 0 {
+		containerNames = append(containerNames, devnet.GetDevnetContainerName(projectName, devnet.L1))
+	}
+
+	for _, containerName := range containerNames {
+		devnet.StopAndRemoveContainer(cCtx, containerManager, containerName)
+	}
+}
+
+// registerDevnetChain records a chain the backend started in the global devnet registry
+func registerDevnetChain(ctx context.Context, backend devnet.Backend, projectName string, chain devnet.ChainSpec) error {
+	containerName := devnet.GetDevnetContainerName(projectName, chain.Name)
+	c, err := backend.Get(ctx, containerName)
+	if err != nil {
+		return err
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to resolve project dir: %w", err)
+	}
+
+	return devnet.UpdateDevnetRegistry(func(r *devnet.DevnetRegistry) error {
+		r.Upsert(devnet.DevnetRegistryEntry{
+			ProjectName:   projectName,
+			ProjectDir:    projectDir,
+			Chain:         chain.Name,
+			Port:          chain.Port,
+			ChainID:       chain.ChainID,
+			ContainerID:   c.ID,
+			ContainerName: containerName,
+			StartedAt:     time.Now().UTC(),
+			Backend:       backend.Name(),
+		})
+		return nil
+	})
+}
+
 func ListDevnetContainersAction(cCtx *cli.Context) error {
-	cmd := exec.CommandContext(cCtx.Context, "docker", devnet.GetDockerPsDevnetArgs()...)
-	output, err := cmd.Output()
+	containerManager, err := devnet.NewContainerManager()
+	if err != nil {
+		return err
+	}
+	defer containerManager.Close()
+
+	registry, err := devnet.ReconcileDevnetRegistry(cCtx.Context, containerManager)
 	if err != nil {
 		return fmt.Errorf("failed to list devnet containers: %w", err)
 	}
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
+	if len(registry.Devnets) == 0 {
 		fmt.Printf("%s🚫 No devnet containers running.%s\n", devnet.Yellow, devnet.Reset)
 		return nil
 	}
 	fmt.Printf("%s📦 Running Devnet Containers:%s\n\n", devnet.Blue, devnet.Reset)
-	for _, line := range lines {
-		parts := strings.Split(line, ": ")
-		if len(parts) != 2 {
-			continue
+	for _, entry := range registry.Sorted() {
+		// Containers adopted from Docker labels carry no chain id or project dir
+		details := "chain " + entry.Chain
+		if entry.ChainID != 0 {
+			details += fmt.Sprintf(" (%d)", entry.ChainID)
 		}
-		name := parts[0]
-		port := extractHostPort(parts[1])
-		fmt.Printf("%s  -  %s%-25s%s %s→%s  %shttp://localhost:%s%s\n",
+		details += fmt.Sprintf(", up %s", time.Since(entry.StartedAt).Round(time.Second))
+
+		fmt.Printf("%s  -  %s%-25s%s %s→%s  %s%s%s  %s\n",
 			devnet.Cyan, devnet.Reset,
-			name,
+			entry.ContainerName,
 			devnet.Reset,
 			devnet.Green, devnet.Reset,
-			devnet.Yellow, port, devnet.Reset,
+			devnet.Yellow, devnet.GetRPCURL(entry.Port), devnet.Reset,
+			details,
 		)
+		if entry.ProjectDir != "" {
+			fmt.Printf("     %s\n", entry.ProjectDir)
+		}
 	}
 	return nil
 }
 
 func UpdateAVSMetadataAction(cCtx *cli.Context, logger iface.Logger) error {
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	report := &setupReport{dryRun: common.IsDryRun(cCtx.Context)}
+	defer report.print(logger)
+	return updateAVSMetadata(cCtx, logger, report)
+}
+
+func updateAVSMetadata(cCtx *cli.Context, logger iface.Logger, report *setupReport) error {
+	contextName := setupContextName(cCtx)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
-	uri := cCtx.String("uri")
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	uri := cCtx.String("uri")
+	if uri == "" {
+		uri = envCtx.Avs.MetadataUri
 	}
 	l1ChainCfg, ok := envCtx.Chains[devnet.L1]
 	if !ok {
-		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, devnet.CONTEXT)
+		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, contextName)
 	}
 	client, err := ethclient.Dial(l1ChainCfg.RPCURL)
 	if err != nil {
@@ -566,11 +952,20 @@ func UpdateAVSMetadataAction(cCtx *cli.Context, logger iface.Logger) error {
 	}
 	defer client.Close()
 
-	allocationManagerAddr := ethcommon.HexToAddress(devnet.ALLOCATION_MANAGER_ADDRESS)
-	delegationManagerAddr := ethcommon.HexToAddress(devnet.DELEGATION_MANAGER_ADDRESS)
+	allocationManager, delegationManager := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	allocationManagerAddr := ethcommon.HexToAddress(allocationManager)
+	delegationManagerAddr := ethcommon.HexToAddress(delegationManager)
+
+	avsSigner, err := common.AVSSigner(envCtx.Avs)
+	if err != nil {
+		return err
+	}
+	if avsSigner == nil {
+		return fmt.Errorf("context '%s' has neither an avs_private_key nor an avs_signer", contextName)
+	}
 
 	contractCaller, err := common.NewContractCaller(
-		envCtx.Avs.AVSPrivateKey,
+		avsSigner,
 		big.NewInt(int64(l1ChainCfg.ChainID)),
 		client,
 		allocationManagerAddr,
@@ -580,24 +975,46 @@ func UpdateAVSMetadataAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
-	return contractCaller.UpdateAVSMetadata(cCtx.Context, avsAddr, uri)
+
+	// The URI is only kept in events; when they cannot be read the update is sent anyway
+	current, found, err := contractCaller.GetAVSMetadataURI(cCtx.Context, avsAddr, metadataSearchStart(l1ChainCfg))
+	if err != nil {
+		logger.Warn("Could not read the current AVS metadata URI, updating it: %v", err)
+	} else if found && current == uri {
+		report.skipped("AVS metadata", "already %q", uri)
+		return nil
+	}
+
+	if err := contractCaller.UpdateAVSMetadata(cCtx.Context, avsAddr, uri); err != nil {
+		return err
+	}
+	report.changed("AVS metadata", "set to %q", uri)
+	return nil
 }
 
 func SetAVSRegistrarAction(cCtx *cli.Context, logger iface.Logger) error {
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	report := &setupReport{dryRun: common.IsDryRun(cCtx.Context)}
+	defer report.print(logger)
+	return setAVSRegistrar(cCtx, logger, report)
+}
+
+func setAVSRegistrar(cCtx *cli.Context, logger iface.Logger, report *setupReport) error {
+	contextName := setupContextName(cCtx)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
 
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 	l1ChainCfg, ok := envCtx.Chains[devnet.L1]
 	if !ok {
-		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, devnet.CONTEXT)
+		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, contextName)
 	}
 	client, err := ethclient.Dial(l1ChainCfg.RPCURL)
 	if err != nil {
@@ -605,11 +1022,20 @@ func SetAVSRegistrarAction(cCtx *cli.Context, logger iface.Logger) error {
 	}
 	defer client.Close()
 
-	allocationManagerAddr := ethcommon.HexToAddress(devnet.ALLOCATION_MANAGER_ADDRESS)
-	delegationManagerAddr := ethcommon.HexToAddress(devnet.DELEGATION_MANAGER_ADDRESS)
+	allocationManager, delegationManager := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	allocationManagerAddr := ethcommon.HexToAddress(allocationManager)
+	delegationManagerAddr := ethcommon.HexToAddress(delegationManager)
+
+	avsSigner, err := common.AVSSigner(envCtx.Avs)
+	if err != nil {
+		return err
+	}
+	if avsSigner == nil {
+		return fmt.Errorf("context '%s' has neither an avs_private_key nor an avs_signer", contextName)
+	}
 
 	contractCaller, err := common.NewContractCaller(
-		envCtx.Avs.AVSPrivateKey,
+		avsSigner,
 		big.NewInt(int64(l1ChainCfg.ChainID)),
 		client,
 		allocationManagerAddr,
@@ -619,39 +1045,67 @@ func SetAVSRegistrarAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
 	var registrarAddr ethcommon.Address
-	logger.Info("Attempting to find AvsRegistrar in deployed contracts...")
-	foundInDeployed := false
-	for _, contract := range envCtx.DeployedContracts {
-		if strings.Contains(strings.ToLower(contract.Name), "avsregistrar") {
-			registrarAddr = ethcommon.HexToAddress(contract.Address)
-			logger.Info("Found AvsRegistrar: '%s' at address %s", contract.Name, registrarAddr.Hex())
-			foundInDeployed = true
-			break
+	if registrar := cCtx.String("registrar"); registrar != "" {
+		if !ethcommon.IsHexAddress(registrar) {
+			return fmt.Errorf("invalid registrar address %q", registrar)
+		}
+		registrarAddr = ethcommon.HexToAddress(registrar)
+	} else {
+		logger.Info("Attempting to find AvsRegistrar in deployed contracts...")
+		foundInDeployed := false
+		for _, contract := range envCtx.DeployedContracts {
+			if strings.Contains(strings.ToLower(contract.Name), "avsregistrar") {
+				registrarAddr = ethcommon.HexToAddress(contract.Address)
+				logger.Info("Found AvsRegistrar: '%s' at address %s", contract.Name, registrarAddr.Hex())
+				foundInDeployed = true
+				break
+			}
+		}
+		if !foundInDeployed {
+			return fmt.Errorf("AvsRegistrar contract not found in deployed contracts for context '%s'; pass --registrar to set one", contextName)
 		}
 	}
-	if !foundInDeployed {
-		return fmt.Errorf("AvsRegistrar contract not found in deployed contracts for context '%s'", devnet.CONTEXT)
+
+	current, err := contractCaller.GetAVSRegistrar(cCtx.Context, avsAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get current AVS registrar: %w", err)
+	}
+	if current == registrarAddr {
+		report.skipped("AVS registrar", "already %s", registrarAddr.Hex())
+		return nil
 	}
 
-	return contractCaller.SetAVSRegistrar(cCtx.Context, avsAddr, registrarAddr)
+	if err := contractCaller.SetAVSRegistrar(cCtx.Context, avsAddr, registrarAddr); err != nil {
+		return err
+	}
+	report.changed("AVS registrar", "set to %s (was %s)", registrarAddr.Hex(), current.Hex())
+	return nil
 }
 
 func CreateAVSOperatorSetsAction(cCtx *cli.Context, logger iface.Logger) error {
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	report := &setupReport{dryRun: common.IsDryRun(cCtx.Context)}
+	defer report.print(logger)
+	return createAVSOperatorSets(cCtx, logger, report)
+}
+
+func createAVSOperatorSets(cCtx *cli.Context, logger iface.Logger, report *setupReport) error {
+	contextName := setupContextName(cCtx)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
 
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 	l1ChainCfg, ok := envCtx.Chains[devnet.L1]
 	if !ok {
-		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, devnet.CONTEXT)
+		return fmt.Errorf("L1 chain configuration ('%s') not found in context '%s'", devnet.L1, contextName)
 	}
 	client, err := ethclient.Dial(l1ChainCfg.RPCURL)
 	if err != nil {
@@ -659,11 +1113,20 @@ func CreateAVSOperatorSetsAction(cCtx *cli.Context, logger iface.Logger) error {
 	}
 	defer client.Close()
 
-	allocationManagerAddr := ethcommon.HexToAddress(devnet.ALLOCATION_MANAGER_ADDRESS)
-	delegationManagerAddr := ethcommon.HexToAddress(devnet.DELEGATION_MANAGER_ADDRESS)
+	allocationManager, delegationManager := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	allocationManagerAddr := ethcommon.HexToAddress(allocationManager)
+	delegationManagerAddr := ethcommon.HexToAddress(delegationManager)
+
+	avsSigner, err := common.AVSSigner(envCtx.Avs)
+	if err != nil {
+		return err
+	}
+	if avsSigner == nil {
+		return fmt.Errorf("context '%s' has neither an avs_private_key nor an avs_signer", contextName)
+	}
 
 	contractCaller, err := common.NewContractCaller(
-		envCtx.Avs.AVSPrivateKey,
+		avsSigner,
 		big.NewInt(int64(l1ChainCfg.ChainID)),
 		client,
 		allocationManagerAddr,
@@ -673,35 +1136,90 @@ func CreateAVSOperatorSetsAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
-	if len(envCtx.OperatorSets) == 0 {
+	operatorSets := envCtx.OperatorSets
+	if path := cCtx.String("operator-sets"); path != "" {
+		if operatorSets, err = loadOperatorSetsFile(path); err != nil {
+			return err
+		}
+	}
+	if len(operatorSets) == 0 {
 		logger.Info("No operator sets to create.")
 		return nil
 	}
-	createSetParams := make([]allocationmanager.IAllocationManagerTypesCreateSetParams, len(envCtx.OperatorSets))
-	for i, opSet := range envCtx.OperatorSets {
+	createSetParams := make([]allocationmanager.IAllocationManagerTypesCreateSetParams, 0, len(operatorSets))
+	for _, opSet := range operatorSets {
+		operatorSetID := uint32(opSet.OperatorSetID)
+		stepName := fmt.Sprintf("Operator set %d", operatorSetID)
+
+		exists, err := contractCaller.IsOperatorSet(cCtx.Context, avsAddr, operatorSetID)
+		if err != nil {
+			return fmt.Errorf("failed to check operator set %d: %w", operatorSetID, err)
+		}
+		if exists {
+			onChain, err := contractCaller.GetStrategiesInOperatorSet(cCtx.Context, avsAddr, operatorSetID)
+			if err != nil {
+				return fmt.Errorf("failed to get strategies for operator set %d: %w", operatorSetID, err)
+			}
+			missing := missingStrategies(opSet.Strategies, onChain)
+			if len(missing) == 0 {
+				report.skipped(stepName, "already exists with %d strategies", len(onChain))
+				continue
+			}
+			// The set exists but does not match the config until its missing strategies are added
+			strategies := make([]ethcommon.Address, len(missing))
+			for j, strategy := range missing {
+				strategies[j] = ethcommon.HexToAddress(strategy)
+			}
+			if err := contractCaller.AddStrategiesToOperatorSet(cCtx.Context, avsAddr, operatorSetID, strategies); err != nil {
+				return fmt.Errorf("failed to add strategies %s to operator set %d: %w", strings.Join(missing, ", "), operatorSetID, err)
+			}
+			report.changed(stepName, "added strategies %s", strings.Join(missing, ", "))
+			continue
+		}
+
 		strategies := make([]ethcommon.Address, len(opSet.Strategies))
 		for j, strategy := range opSet.Strategies {
 			strategies[j] = ethcommon.HexToAddress(strategy.StrategyAddress)
 		}
-		createSetParams[i] = allocationmanager.IAllocationManagerTypesCreateSetParams{
-			OperatorSetId: uint32(opSet.OperatorSetID),
+		createSetParams = append(createSetParams, allocationmanager.IAllocationManagerTypesCreateSetParams{
+			OperatorSetId: operatorSetID,
 			Strategies:    strategies,
-		}
+		})
+	}
+	if len(createSetParams) == 0 {
+		return nil
 	}
 
-	return contractCaller.CreateOperatorSets(cCtx.Context, avsAddr, createSetParams)
+	if err := contractCaller.CreateOperatorSets(cCtx.Context, avsAddr, createSetParams); err != nil {
+		return err
+	}
+	for _, params := range createSetParams {
+		report.changed(fmt.Sprintf("Operator set %d", params.OperatorSetId), "created with %d strategies", len(params.Strategies))
+	}
+	return nil
 }
 
 func RegisterOperatorsFromConfigAction(cCtx *cli.Context, logger iface.Logger) error {
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	report := &setupReport{dryRun: common.IsDryRun(cCtx.Context)}
+	defer report.print(logger)
+	return registerOperatorsFromConfig(cCtx, logger, report)
+}
+
+// errOperatorRegistrationsFailed is returned when at least one operator registration recorded a failure
+var errOperatorRegistrationsFailed = errors.New("operator registrations failed")
+
+func registerOperatorsFromConfig(cCtx *cli.Context, logger iface.Logger, report *setupReport) error {
+	contextName := setupContextName(cCtx)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for operator registration: %w", err)
 	}
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 
 	logger.Info("Registering operators with EigenLayer...")
@@ -709,18 +1227,50 @@ func RegisterOperatorsFromConfigAction(cCtx *cli.Context, logger iface.Logger) e
 		logger.Info("No operator registrations found in context, skipping operator registration.")
 		return nil
 	}
+	registrations, err := filterOperatorRegistrations(envCtx.OperatorRegistrations, cCtx.StringSlice("operator"))
+	if err != nil {
+		return err
+	}
 
+	// Stake is split evenly across every operator set an operator registers for
+	operatorSetCounts := make(map[string]int)
 	for _, opReg := range envCtx.OperatorRegistrations {
+		operatorSetCounts[strings.ToLower(opReg.Address)]++
+	}
+
+	failures := 0
+	for _, opReg := range registrations {
 		logger.Info("Processing registration for operator at address %s", opReg.Address)
-		if err := registerOperatorEL(cCtx, opReg.Address, logger); err != nil {
+		if err := registerOperatorEL(cCtx, contextName, opReg.Address, logger, report); err != nil {
 			logger.Error("Failed to register operator %s with EigenLayer: %v. Continuing...", opReg.Address, err)
+			report.failed(fmt.Sprintf("Operator %s", opReg.Address), err)
+			failures++
 			continue
 		}
-		if err := registerOperatorAVS(cCtx, logger, opReg.Address, uint32(opReg.OperatorSetID), opReg.Payload); err != nil {
+		if err := registerOperatorAVS(cCtx, logger, report, contextName, opReg.Address, uint32(opReg.OperatorSetID), opReg.Payload); err != nil {
 			logger.Error("Failed to register operator %s for AVS: %v. Continuing...", opReg.Address, err)
+			report.failed(fmt.Sprintf("Operator %s in operator set %d", opReg.Address, opReg.OperatorSetID), err)
+			failures++
 			continue
 		}
 		logger.Info("Successfully registered operator %s for OperatorSetID %d", opReg.Address, opReg.OperatorSetID)
+		// Stake is acquired through anvil cheatcodes, which only the devnet has and a dry run must not use
+		if contextName != devnet.CONTEXT {
+			continue
+		}
+		if common.IsDryRun(cCtx.Context) {
+			logger.Info("Dry run: not staking operator %s in OperatorSetID %d", opReg.Address, opReg.OperatorSetID)
+			continue
+		}
+		if err := stakeOperatorDevnet(cCtx.Context, logger, cfg, opReg.Address, uint32(opReg.OperatorSetID), operatorSetCounts[strings.ToLower(opReg.Address)]); err != nil {
+			logger.Error("Failed to stake operator %s in OperatorSetID %d: %v. Continuing...", opReg.Address, opReg.OperatorSetID, err)
+			report.failed(fmt.Sprintf("Stake of operator %s in operator set %d", opReg.Address, opReg.OperatorSetID), err)
+			failures++
+			continue
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d %w", failures, len(registrations), errOperatorRegistrationsFailed)
 	}
 	logger.Info("Operator registration with EigenLayer completed.")
 	return nil
@@ -778,32 +1328,41 @@ func FetchZeusAddressesAction(cCtx *cli.Context) error {
 	return nil
 }
 
-func extractHostPort(portStr string) string {
-	if strings.Contains(portStr, "->") {
-		beforeArrow := strings.Split(portStr, "->")[0]
-		hostPort := strings.Split(beforeArrow, ":")
-		return hostPort[len(hostPort)-1]
+// waitForDeployedContracts reloads the devnet context and waits until every deployed contract has code
+func waitForDeployedContracts(cCtx *cli.Context, rpcUrl string) error {
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+
+	addresses := make([]ethcommon.Address, 0, len(cfg.Context[devnet.CONTEXT].DeployedContracts))
+	for _, contract := range cfg.Context[devnet.CONTEXT].DeployedContracts {
+		if !ethcommon.IsHexAddress(contract.Address) {
+			return fmt.Errorf("deployed contract %s has invalid address %q", contract.Name, contract.Address)
+		}
+		addresses = append(addresses, ethcommon.HexToAddress(contract.Address))
 	}
-	return portStr
+
+	return devnet.WaitForContractCode(cCtx.Context, rpcUrl, addresses, cCtx.Duration("ready-timeout"))
 }
 
-func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.Logger) error {
+func registerOperatorEL(cCtx *cli.Context, contextName, operatorAddress string, logger iface.Logger, report *setupReport) error {
 	if operatorAddress == "" {
 		return fmt.Errorf("operatorAddress parameter is required and cannot be empty")
 	}
 
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
 
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 	l1Cfg, ok := envCtx.Chains[devnet.L1]
 	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", devnet.CONTEXT)
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
 
 	client, err := ethclient.Dial(l1Cfg.RPCURL)
@@ -812,26 +1371,22 @@ func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.
 	}
 	defer client.Close()
 
-	var operatorPrivateKey string
-	for _, op := range envCtx.Operators {
-		key, keyErr := crypto.HexToECDSA(strings.TrimPrefix(op.ECDSAKey, "0x"))
-		if keyErr != nil {
-			continue
-		}
-		if strings.EqualFold(crypto.PubkeyToAddress(key.PublicKey).Hex(), operatorAddress) {
-			operatorPrivateKey = op.ECDSAKey
-			break
-		}
-	}
-	if operatorPrivateKey == "" {
-		return fmt.Errorf("operator with address %s not found in config", operatorAddress)
+	operator, err := common.GetOperatorSpec(envCtx, operatorAddress)
+	if err != nil {
+		return err
 	}
 
-	allocationManagerAddr := ethcommon.HexToAddress(devnet.ALLOCATION_MANAGER_ADDRESS)
-	delegationManagerAddr := ethcommon.HexToAddress(devnet.DELEGATION_MANAGER_ADDRESS)
+	allocationManager, delegationManager := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	allocationManagerAddr := ethcommon.HexToAddress(allocationManager)
+	delegationManagerAddr := ethcommon.HexToAddress(delegationManager)
+
+	operatorSigner, err := common.OperatorSigner(*operator)
+	if err != nil {
+		return err
+	}
 
 	contractCaller, err := common.NewContractCaller(
-		operatorPrivateKey,
+		operatorSigner,
 		big.NewInt(int64(l1Cfg.ChainID)),
 		client,
 		allocationManagerAddr,
@@ -841,11 +1396,30 @@ func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
+
+	stepName := fmt.Sprintf("Operator %s", operatorAddress)
+	isOperator, err := contractCaller.IsOperator(cCtx.Context, ethcommon.HexToAddress(operatorAddress))
+	if err != nil {
+		return fmt.Errorf("failed to check whether %s is an operator: %w", operatorAddress, err)
+	}
+	if isOperator {
+		report.skipped(stepName, "already registered with EigenLayer")
+		return nil
+	}
 
-	return contractCaller.RegisterAsOperator(cCtx.Context, ethcommon.HexToAddress(operatorAddress), 0, "test")
+	metadataURI := operator.MetadataURI
+	if metadataURI == "" {
+		metadataURI = "test"
+	}
+	if err := contractCaller.RegisterAsOperator(cCtx.Context, ethcommon.HexToAddress(operatorAddress), 0, metadataURI); err != nil {
+		return err
+	}
+	report.changed(stepName, "registered with EigenLayer")
+	return nil
 }
 
-func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress string, operatorSetID uint32, payloadHex string) error {
+func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, report *setupReport, contextName, operatorAddress string, operatorSetID uint32, payloadHex string) error {
 	if operatorAddress == "" {
 		return fmt.Errorf("operatorAddress parameter is required and cannot be empty")
 	}
@@ -853,18 +1427,18 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 		return fmt.Errorf("payloadHex parameter is required and cannot be empty")
 	}
 
-	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
 
-	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	envCtx, ok := cfg.Context[contextName]
 	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 	l1Cfg, ok := envCtx.Chains[devnet.L1]
 	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", devnet.CONTEXT)
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
 
 	client, err := ethclient.Dial(l1Cfg.RPCURL)
@@ -873,25 +1447,20 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 	}
 	defer client.Close()
 
-	var operatorPrivateKey string
-	for _, op := range envCtx.Operators {
-		key, keyErr := crypto.HexToECDSA(strings.TrimPrefix(op.ECDSAKey, "0x"))
-		if keyErr != nil {
-			continue
-		}
-		if strings.EqualFold(crypto.PubkeyToAddress(key.PublicKey).Hex(), operatorAddress) {
-			operatorPrivateKey = op.ECDSAKey
-			break
-		}
-	}
-	if operatorPrivateKey == "" {
-		return fmt.Errorf("operator with address %s not found in config", operatorAddress)
+	operator, err := common.GetOperatorSpec(envCtx, operatorAddress)
+	if err != nil {
+		return err
 	}
 
-	allocationManagerAddr, delegationManagerAddr := devnet.GetEigenLayerAddresses(cfg)
+	allocationManagerAddr, delegationManagerAddr := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+
+	operatorSigner, err := common.OperatorSigner(*operator)
+	if err != nil {
+		return err
+	}
 
 	contractCaller, err := common.NewContractCaller(
-		operatorPrivateKey,
+		operatorSigner,
 		big.NewInt(int64(l1Cfg.ChainID)),
 		client,
 		ethcommon.HexToAddress(allocationManagerAddr),
@@ -901,26 +1470,42 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
 
 	payloadBytes, err := hex.DecodeString(payloadHex)
 	if err != nil {
 		return fmt.Errorf("failed to decode payload hex '%s': %w", payloadHex, err)
 	}
 
-	return contractCaller.RegisterForOperatorSets(
+	stepName := fmt.Sprintf("Operator %s in operator set %d", operatorAddress, operatorSetID)
+	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
+	registeredSets, err := contractCaller.GetRegisteredSets(cCtx.Context, ethcommon.HexToAddress(operatorAddress))
+	if err != nil {
+		return fmt.Errorf("failed to get registered operator sets of %s: %w", operatorAddress, err)
+	}
+	if isRegisteredForSet(registeredSets, avsAddr, operatorSetID) {
+		report.skipped(stepName, "already registered")
+		return nil
+	}
+
+	if err := contractCaller.RegisterForOperatorSets(
 		cCtx.Context,
 		ethcommon.HexToAddress(operatorAddress),
-		ethcommon.HexToAddress(envCtx.Avs.Address),
+		avsAddr,
 		[]uint32{operatorSetID},
 		payloadBytes,
-	)
+	); err != nil {
+		return err
+	}
+	report.changed(stepName, "registered")
+	return nil
 }
 
 func extractContractOutputs(cCtx *cli.Context, context string, contractsList []DeployContractTransport) error {
 	logger := common.LoggerFromContext(cCtx.Context)
 
 	// Push contract artefacts to ./contracts/outputs
-	outDir := filepath.Join("contracts", "outputs", context)
+	outDir := getContractOutputsDir(context)
 	if err := os.MkdirAll(outDir, fs.ModePerm); err != nil {
 		return fmt.Errorf("create output dir: %w", err)
 	}
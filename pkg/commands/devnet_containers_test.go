@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// useFakeContainerManager points the devnet commands at an in-memory container manager and an empty registry
+func useFakeContainerManager(t *testing.T, containers ...devnet.DevnetContainer) *devnet.FakeContainerManager {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	fake := devnet.NewFakeContainerManager(containers...)
+	original := devnet.NewContainerManager
+	devnet.NewContainerManager = func() (devnet.ContainerManager, error) { return fake, nil }
+	t.Cleanup(func() { devnet.NewContainerManager = original })
+	return fake
+}
+
+func TestListDevnetContainersAction_FakeManager(t *testing.T) {
+	useFakeContainerManager(t,
+		devnet.DevnetContainer{ID: "1", Name: "devkit-devnet-alpha", Project: "alpha", Chain: devnet.L1, HostPorts: []int{8545}, Running: true, Created: time.Now()},
+		devnet.DevnetContainer{ID: "2", Name: "devkit-devnet-alpha-l2", Project: "alpha", Chain: "l2", HostPorts: []int{8546}, Running: true, Created: time.Now()},
+		devnet.DevnetContainer{ID: "3", Name: "devkit-devnet-stopped", Project: "stopped", Chain: devnet.L1, HostPorts: []int{8547}},
+	)
+
+	originalStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	app, _ := testutils.CreateTestAppWithNoopLoggerAndAccess("devkit", []cli.Flag{}, ListDevnetContainersAction)
+	err := app.Run([]string{"devkit"})
+
+	w.Close()
+	os.Stdout = originalStdout
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err)
+	output := buf.String()
+
+	assert.Contains(t, output, "devkit-devnet-alpha")
+	assert.Contains(t, output, "http://localhost:8545")
+	assert.Contains(t, output, "http://localhost:8546")
+	assert.NotContains(t, output, "devkit-devnet-stopped", "stopped containers are not listed")
+}
+
+func TestStopDevnetAction_PortStopsWholeDevnet(t *testing.T) {
+	fake := useFakeContainerManager(t,
+		devnet.DevnetContainer{ID: "1", Name: "devkit-devnet-alpha", Project: "alpha", Chain: devnet.L1, HostPorts: []int{8545}, Running: true},
+		devnet.DevnetContainer{ID: "2", Name: "devkit-devnet-alpha-l2", Project: "alpha", Chain: "l2", HostPorts: []int{8546}, Running: true},
+		devnet.DevnetContainer{ID: "3", Name: "devkit-devnet-beta", Project: "beta", Chain: devnet.L1, HostPorts: []int{8547}, Running: true},
+	)
+
+	app, _ := testutils.CreateTestAppWithNoopLoggerAndAccess("devkit", []cli.Flag{
+		&cli.IntFlag{Name: "port"},
+	}, StopDevnetAction)
+	require.NoError(t, app.Run([]string{"devkit", "--port", "8546"}))
+
+	assert.ElementsMatch(t, []string{"devkit-devnet-alpha", "devkit-devnet-alpha-l2"}, fake.Removed)
+
+	registry, err := devnet.LoadDevnetRegistry()
+	require.NoError(t, err)
+	require.Len(t, registry.Devnets, 1)
+	assert.Equal(t, "beta", registry.Devnets[0].ProjectName)
+}
+
+func TestStopDevnetAction_KeepStateOnlyForCurrentProject(t *testing.T) {
+	fake := useFakeContainerManager(t,
+		devnet.DevnetContainer{ID: "1", Name: "devkit-devnet-alpha", Project: "alpha", Chain: devnet.L1, HostPorts: []int{8545}, Running: true},
+	)
+
+	app, _ := testutils.CreateTestAppWithNoopLoggerAndAccess("devkit", []cli.Flag{
+		&cli.BoolFlag{Name: "all"},
+		&cli.BoolFlag{Name: "keep-state"},
+	}, StopDevnetAction)
+	err := app.Run([]string{"devkit", "--all", "--keep-state"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--keep-state")
+	assert.Empty(t, fake.Stopped, "nothing is stopped when the flags conflict")
+}
@@ -0,0 +1,491 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// dashboardRefreshInterval is how often the dashboard re-reads the chain and the devnet context
+	dashboardRefreshInterval = time.Second
+	// dashboardRecentBlocks is how many of the latest L1 blocks are scanned for transactions
+	dashboardRecentBlocks = 10
+	// dashboardMaxTransactions is how many recent transactions the dashboard lists
+	dashboardMaxTransactions = 8
+	// dashboardMaxLogLines is how many component log lines the dashboard keeps
+	dashboardMaxLogLines = 500
+	// dashboardLogBuffer is how many unread log lines are queued before further lines are dropped
+	dashboardLogBuffer = 1024
+)
+
+// dashboardBackend is what the devnet dashboard reads from and acts on
+type dashboardBackend interface {
+	Poll(ctx context.Context) (*dashboardData, error)
+	Mine(ctx context.Context) (string, error)
+	Snapshot(ctx context.Context) (string, error)
+	RestartAVS(ctx context.Context) (string, error)
+}
+
+// dashboardData is one refresh of everything the dashboard shows apart from logs
+type dashboardData struct {
+	Status       *devnet.DevnetStatus
+	Transactions []dashboardTx
+}
+
+// dashboardTx summarises a transaction mined on L1
+type dashboardTx struct {
+	Block uint64
+	Hash  string
+	From  string
+	To    string
+}
+
+type dashboardTickMsg struct{}
+
+type dashboardDataMsg struct {
+	data *dashboardData
+	err  error
+}
+
+type dashboardLogMsg struct {
+	line string
+}
+
+type dashboardActionMsg struct {
+	notice string
+	err    error
+}
+
+// dashboardModel is the bubbletea model behind `devnet start` when it runs in a terminal
+type dashboardModel struct {
+	ctx      context.Context
+	backend  dashboardBackend
+	logs     <-chan string
+	project  string
+	data     *dashboardData
+	pollErr  error
+	logLines []string
+	notice   string
+	busy     bool
+	height   int
+}
+
+func newDashboardModel(ctx context.Context, backend dashboardBackend, logs <-chan string, project string) dashboardModel {
+	return dashboardModel{ctx: ctx, backend: backend, logs: logs, project: project}
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.poll(), m.waitForLog())
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+	case dashboardTickMsg:
+		return m, m.poll()
+	case dashboardDataMsg:
+		m.pollErr = msg.err
+		if msg.data != nil {
+			m.data = msg.data
+		}
+		return m, tea.Tick(dashboardRefreshInterval, func(time.Time) tea.Msg { return dashboardTickMsg{} })
+	case dashboardLogMsg:
+		m.logLines = append(m.logLines, msg.line)
+		if len(m.logLines) > dashboardMaxLogLines {
+			m.logLines = m.logLines[len(m.logLines)-dashboardMaxLogLines:]
+		}
+		return m, m.waitForLog()
+	case dashboardActionMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.notice = "❌ " + msg.err.Error()
+		} else {
+			m.notice = "✅ " + msg.notice
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "m":
+			return m.act("Mining a block...", m.backend.Mine)
+		case "s":
+			return m.act("Saving snapshot...", m.backend.Snapshot)
+		case "r":
+			return m.act("Restarting AVS components...", m.backend.RestartAVS)
+		}
+	}
+	return m, nil
+}
+
+// act runs one key-bound action in the background; keys are ignored while an action is in flight
+func (m dashboardModel) act(pending string, action func(ctx context.Context) (string, error)) (tea.Model, tea.Cmd) {
+	if m.busy {
+		return m, nil
+	}
+	m.busy = true
+	m.notice = "⏳ " + pending
+	ctx := m.ctx
+	return m, func() tea.Msg {
+		notice, err := action(ctx)
+		return dashboardActionMsg{notice: notice, err: err}
+	}
+}
+
+func (m dashboardModel) poll() tea.Cmd {
+	ctx, backend := m.ctx, m.backend
+	return func() tea.Msg {
+		data, err := backend.Poll(ctx)
+		return dashboardDataMsg{data: data, err: err}
+	}
+}
+
+func (m dashboardModel) waitForLog() tea.Cmd {
+	ctx, logs := m.ctx, m.logs
+	return func() tea.Msg {
+		select {
+		case line := <-logs:
+			return dashboardLogMsg{line: line}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m dashboardModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sDevnet · %s%s\n", devnet.Cyan, m.project, devnet.Reset)
+	b.WriteString("m mine a block · s save snapshot · r restart AVS components · q quit\n\n")
+
+	b.WriteString("Chains\n")
+	if m.data == nil || m.data.Status == nil {
+		b.WriteString("  waiting for the first refresh...\n")
+	} else {
+		for _, chain := range m.data.Status.Chains {
+			if !chain.Reachable {
+				fmt.Fprintf(&b, "  %s %-4s %s unreachable\n", statusMark(false), chain.Name, chain.RPCURL)
+				continue
+			}
+			blockTime := time.Unix(int64(chain.BlockTimestamp), 0).UTC()
+			fmt.Fprintf(&b, "  %s %-4s block %s%d%s  %s  %s\n", statusMark(true), chain.Name, devnet.Cyan, chain.BlockNumber, devnet.Reset, blockTime.Format(time.RFC3339), chain.RPCURL)
+		}
+	}
+	if m.pollErr != nil {
+		fmt.Fprintf(&b, "  %s⚠️  %v%s\n", devnet.Yellow, m.pollErr, devnet.Reset)
+	}
+
+	b.WriteString("\nRecent transactions\n")
+	if m.data == nil || len(m.data.Transactions) == 0 {
+		b.WriteString("  none\n")
+	} else {
+		for _, tx := range m.data.Transactions {
+			to := tx.To
+			if to == "" {
+				to = "contract creation"
+			}
+			fmt.Fprintf(&b, "  #%d %s %s → %s\n", tx.Block, shortHex(tx.Hash), shortHex(tx.From), shortHex(to))
+		}
+	}
+
+	b.WriteString("\nContracts\n")
+	if m.data == nil || m.data.Status == nil || len(m.data.Status.Contracts) == 0 {
+		b.WriteString("  none deployed\n")
+	} else {
+		for _, contract := range m.data.Status.Contracts {
+			fmt.Fprintf(&b, "  %s %s %s\n", statusMark(contract.HasCode), contract.Name, contract.Address)
+		}
+	}
+
+	b.WriteString("\nOperators\n")
+	if m.data == nil || m.data.Status == nil || len(m.data.Status.Operators) == 0 {
+		b.WriteString("  none configured\n")
+	} else {
+		for _, op := range m.data.Status.Operators {
+			sets := make([]string, 0, len(op.Registrations))
+			for _, reg := range op.Registrations {
+				sets = append(sets, fmt.Sprintf("%d %s", reg.OperatorSetID, statusMark(reg.Registered)))
+			}
+			line := "not registered in EigenLayer"
+			if op.IsOperator {
+				line = "registered"
+				if len(sets) > 0 {
+					line += ", operator sets: " + strings.Join(sets, " ")
+				}
+			}
+			fmt.Fprintf(&b, "  %s %s %s\n", statusMark(op.IsOperator), op.Address, line)
+		}
+	}
+
+	b.WriteString("\nLogs\n")
+	used := strings.Count(b.String(), "\n") + 2
+	visible := 10
+	if m.height > 0 {
+		visible = max(m.height-used, 3)
+	}
+	lines := m.logLines
+	if len(lines) > visible {
+		lines = lines[len(lines)-visible:]
+	}
+	for _, line := range lines {
+		b.WriteString("  " + line + "\n")
+	}
+
+	b.WriteString("\n" + m.notice)
+	return b.String()
+}
+
+// shortHex abbreviates long hex strings such as hashes and addresses, leaving anything else untouched
+func shortHex(s string) string {
+	if !strings.HasPrefix(s, "0x") || len(s) <= 16 {
+		return s
+	}
+	return s[:10] + "…" + s[len(s)-4:]
+}
+
+// dashboardLogWriter splits whatever is written to it into lines for the dashboard. Lines are dropped
+// rather than blocking the writer when the dashboard falls behind.
+type dashboardLogWriter struct {
+	mu    sync.Mutex
+	buf   []byte
+	lines chan string
+}
+
+func newDashboardLogWriter() *dashboardLogWriter {
+	return &dashboardLogWriter{lines: make(chan string, dashboardLogBuffer)}
+}
+
+func (w *dashboardLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.buf[:i]), "\r")
+		w.buf = w.buf[i+1:]
+		select {
+		case w.lines <- line:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// avsRunner keeps the offchain AVS components running in the background so they can be restarted
+type avsRunner struct {
+	parent      context.Context
+	output      io.Writer
+	captureLogs bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start launches the components
+func (r *avsRunner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.startLocked()
+}
+
+// Restart stops the running components, waits for them to exit and launches them again
+func (r *avsRunner) Restart() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopLocked()
+	r.startLocked()
+}
+
+// Stop stops the running components and waits for them to exit
+func (r *avsRunner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopLocked()
+}
+
+func (r *avsRunner) startLocked() {
+	ctx, cancel := context.WithCancel(r.parent)
+	done := make(chan struct{})
+	r.cancel, r.done = cancel, done
+
+	go func() {
+		defer close(done)
+		// The dashboard owns the terminal, so nothing may be logged or written to stderr directly
+		opts := common.ScriptOptions{Output: r.output, Stderr: io.Discard}
+		err := runAVSComponents(ctx, logger.NewNoopLogger(), r.captureLogs, opts)
+		switch {
+		case ctx.Err() != nil:
+			fmt.Fprintln(r.output, "AVS components stopped")
+		case err != nil:
+			fmt.Fprintf(r.output, "AVS components exited: %v\n", err)
+		default:
+			fmt.Fprintln(r.output, "AVS run script exited")
+		}
+	}()
+}
+
+func (r *avsRunner) stopLocked() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+	r.cancel, r.done = nil, nil
+}
+
+// devnetDashboardBackend reads the running devnet and acts on it for the dashboard
+type devnetDashboardBackend struct {
+	rpcUrls map[string]string
+	client  *ethclient.Client
+	avs     *avsRunner
+
+	// Transactions seen so far and the last L1 block they were collected from
+	transactions []dashboardTx
+	lastBlock    uint64
+}
+
+func newDevnetDashboardBackend(ctx context.Context, rpcUrls map[string]string, avs *avsRunner) (*devnetDashboardBackend, error) {
+	client, err := ethclient.DialContext(ctx, rpcUrls[devnet.L1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", rpcUrls[devnet.L1], err)
+	}
+	return &devnetDashboardBackend{rpcUrls: rpcUrls, client: client, avs: avs}, nil
+}
+
+func (d *devnetDashboardBackend) Close() {
+	d.client.Close()
+}
+
+// Poll re-reads the devnet context, so contracts and registrations written after the dashboard opened show up
+func (d *devnetDashboardBackend) Poll(ctx context.Context) (*dashboardData, error) {
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	status := devnet.CollectDevnetStatus(ctx, cfg)
+
+	var forkBlock uint64
+	for _, chain := range status.Chains {
+		if chain.Name == devnet.L1 {
+			forkBlock = chain.ForkBlock
+		}
+	}
+	err = d.collectTransactions(ctx, forkBlock)
+	return &dashboardData{Status: status, Transactions: d.transactions}, err
+}
+
+// collectTransactions scans the L1 blocks mined since the last poll, never reaching back into forked history
+func (d *devnetDashboardBackend) collectTransactions(ctx context.Context, forkBlock uint64) error {
+	head, err := d.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	from := max(d.lastBlock+1, forkBlock+1)
+	if head >= dashboardRecentBlocks {
+		from = max(from, head-dashboardRecentBlocks+1)
+	}
+
+	for number := from; number <= head; number++ {
+		block, err := d.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+		for _, tx := range block.Transactions() {
+			summary := dashboardTx{Block: number, Hash: tx.Hash().Hex()}
+			if sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+				summary.From = sender.Hex()
+			}
+			if tx.To() != nil {
+				summary.To = tx.To().Hex()
+			}
+			d.transactions = append([]dashboardTx{summary}, d.transactions...)
+		}
+		d.lastBlock = number
+	}
+	if len(d.transactions) > dashboardMaxTransactions {
+		d.transactions = d.transactions[:dashboardMaxTransactions]
+	}
+	return nil
+}
+
+// Mine mines one block on every chain of the devnet
+func (d *devnetDashboardBackend) Mine(ctx context.Context) (string, error) {
+	names := make([]string, 0, len(d.rpcUrls))
+	for name := range d.rpcUrls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		anvil, err := devnet.DialAnvil(ctx, d.rpcUrls[name])
+		if err != nil {
+			return "", err
+		}
+		err = anvil.Mine(ctx, 1)
+		anvil.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to mine on %s: %w", name, err)
+		}
+	}
+	return fmt.Sprintf("Mined a block on %s", strings.Join(names, ", ")), nil
+}
+
+// Snapshot saves the devnet under a timestamped name
+func (d *devnetDashboardBackend) Snapshot(ctx context.Context) (string, error) {
+	name := "dashboard-" + time.Now().UTC().Format("20060102-150405")
+	if _, err := devnet.SaveSnapshot(ctx, d.rpcUrls, name, getDevnetContextPath(), getDevnetOutputsDir()); err != nil {
+		return "", fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return fmt.Sprintf("Saved snapshot %s", name), nil
+}
+
+// RestartAVS restarts the offchain AVS components
+func (d *devnetDashboardBackend) RestartAVS(ctx context.Context) (string, error) {
+	d.avs.Restart()
+	return "Restarted AVS components", nil
+}
+
+// runDevnetDashboard starts the offchain AVS components in the background and shows the dashboard
+// until the user quits or the command is canceled
+func runDevnetDashboard(cCtx *cli.Context, projectName string, rpcUrls map[string]string) error {
+	logs := newDashboardLogWriter()
+	avs := &avsRunner{parent: cCtx.Context, output: logs, captureLogs: cCtx.Bool("capture-logs")}
+
+	backend, err := newDevnetDashboardBackend(cCtx.Context, rpcUrls, avs)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	avs.Start()
+	defer avs.Stop()
+
+	model := newDashboardModel(cCtx.Context, backend, logs.lines, projectName)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(cCtx.Context)).Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("devnet dashboard failed: %w", err)
+	}
+	return nil
+}
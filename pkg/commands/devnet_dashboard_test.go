@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDashboardBackend struct {
+	mined    int
+	snapshot error
+}
+
+func (f *fakeDashboardBackend) Poll(ctx context.Context) (*dashboardData, error) {
+	return &dashboardData{
+		Status: &devnet.DevnetStatus{
+			Chains:    []devnet.ChainStatus{{Name: devnet.L1, RPCURL: "http://localhost:8545", Reachable: true, BlockNumber: 42}},
+			Contracts: []devnet.ContractStatus{{Name: "TaskMailbox", Address: "0x00000000000000000000000000000000000000aa", HasCode: true}},
+			Operators: []devnet.OperatorStatus{{
+				Address:       "0x00000000000000000000000000000000000000bb",
+				IsOperator:    true,
+				Registrations: []devnet.OperatorRegistrationInfo{{OperatorSetID: 0, Registered: true}},
+			}},
+		},
+		Transactions: []dashboardTx{{Block: 42, Hash: "0x1111111111111111111111111111111111111111111111111111111111111111", From: "0x00000000000000000000000000000000000000bb"}},
+	}, nil
+}
+
+func (f *fakeDashboardBackend) Mine(ctx context.Context) (string, error) {
+	f.mined++
+	return "Mined a block on l1", nil
+}
+
+func (f *fakeDashboardBackend) Snapshot(ctx context.Context) (string, error) {
+	return "", f.snapshot
+}
+
+func (f *fakeDashboardBackend) RestartAVS(ctx context.Context) (string, error) {
+	return "Restarted AVS components", nil
+}
+
+// runCmd runs a command and feeds its message back into the model
+func runCmd(t *testing.T, m tea.Model, cmd tea.Cmd) tea.Model {
+	t.Helper()
+	require.NotNil(t, cmd)
+	m, _ = m.Update(cmd())
+	return m
+}
+
+func TestDashboardModelRendersPoll(t *testing.T) {
+	backend := &fakeDashboardBackend{}
+	m := newDashboardModel(context.Background(), backend, make(chan string), "my-avs")
+
+	view := m.View()
+	assert.Contains(t, view, "waiting for the first refresh")
+
+	updated := runCmd(t, m, m.poll())
+	view = updated.View()
+	assert.Contains(t, view, "my-avs")
+	assert.Contains(t, view, "block "+devnet.Cyan+"42")
+	assert.Contains(t, view, "TaskMailbox 0x00000000000000000000000000000000000000aa")
+	assert.Contains(t, view, "registered, operator sets: 0")
+	assert.Contains(t, view, "#42 0x11111111…1111 0x00000000…00bb → contract creation")
+}
+
+func TestDashboardModelKeyBindings(t *testing.T) {
+	backend := &fakeDashboardBackend{snapshot: errors.New("disk full")}
+	var m tea.Model = newDashboardModel(context.Background(), backend, make(chan string), "my-avs")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	assert.Contains(t, m.View(), "Mining a block")
+
+	// A second action is ignored until the first one finishes
+	_, ignored := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Nil(t, ignored)
+
+	m = runCmd(t, m, cmd)
+	assert.Equal(t, 1, backend.mined)
+	assert.Contains(t, m.View(), "Mined a block on l1")
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = runCmd(t, m, cmd)
+	assert.Contains(t, m.View(), "disk full")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestDashboardModelLogs(t *testing.T) {
+	writer := newDashboardLogWriter()
+	var m tea.Model = newDashboardModel(context.Background(), &fakeDashboardBackend{}, writer.lines, "my-avs")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	_, err := fmt.Fprint(writer, "aggregator: started\r\nexecutor: ")
+	require.NoError(t, err)
+	_, err = fmt.Fprint(writer, "waiting for tasks\n")
+	require.NoError(t, err)
+
+	dm := m.(dashboardModel)
+	m = runCmd(t, m, dm.waitForLog())
+	m = runCmd(t, m, dm.waitForLog())
+	view := m.View()
+	assert.Contains(t, view, "  aggregator: started\n")
+	assert.Contains(t, view, "  executor: waiting for tasks\n")
+
+	for i := 0; i < dashboardMaxLogLines+10; i++ {
+		m, _ = m.Update(dashboardLogMsg{line: fmt.Sprintf("line %d", i)})
+	}
+	assert.Len(t, m.(dashboardModel).logLines, dashboardMaxLogLines)
+	assert.NotContains(t, m.View(), "aggregator: started")
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// applyForkPreset points chain at the preset's network and sets the preset, chain id, fork block and EigenLayer
+// addresses in the devnet context for this run. The RPC url stays in the environment and is never written.
+func applyForkPreset(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, contextNode *yaml.Node, chain *devnet.ChainSpec, preset devnet.ForkPreset) error {
+	forkUrl, err := preset.RPCURL()
+	if err != nil {
+		return err
+	}
+	block, err := preset.ResolveForkBlock(cCtx.Context, forkUrl)
+	if err != nil {
+		return err
+	}
+	logger.Info("Forking %s from %s at block %d", chain.Name, preset.Name, block)
+
+	chain.ChainID = preset.ChainID
+	chain.ForkURL = forkUrl
+	chain.ForkBlock = int(block)
+
+	// The preset only applies to this run; the next start that is not a resume restores the values it replaced
+	reason := "--fork " + preset.Name
+	chainIDNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(preset.ChainID)}
+	if err := devnet.OverrideContextValue(contextNode, []string{"chains", chain.Name, "chain_id"}, chainIDNode, reason); err != nil {
+		return fmt.Errorf("failed to record fork preset in context: %w", err)
+	}
+	forkNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	chainNode := common.GetChildByKey(common.GetChildByKey(contextNode, "chains"), chain.Name)
+	if existing := common.GetChildByKey(chainNode, "fork"); existing != nil && existing.Kind == yaml.MappingNode {
+		forkNode = common.CloneNode(existing)
+	}
+	common.SetMappingValue(forkNode, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "block"},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatUint(block, 10)})
+	common.SetMappingValue(forkNode, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "preset"},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: preset.Name})
+	if err := devnet.OverrideContextValue(contextNode, []string{"chains", chain.Name, "fork"}, forkNode, reason); err != nil {
+		return fmt.Errorf("failed to record fork preset in context: %w", err)
+	}
+
+	// Keep the in-memory config in step with the context file
+	envCtx := config.Context[devnet.CONTEXT]
+	chainCfg := envCtx.Chains[chain.Name]
+	fork := common.ForkConfig{}
+	if chainCfg.Fork != nil {
+		fork = *chainCfg.Fork
+	}
+	fork.Block = int(block)
+	fork.Preset = preset.Name
+	chainCfg.ChainID = preset.ChainID
+	chainCfg.Fork = &fork
+	envCtx.Chains[chain.Name] = chainCfg
+
+	if preset.EigenLayer != nil {
+		if err := devnet.SetContextEigenLayer(contextNode, preset.EigenLayer, reason); err != nil {
+			return err
+		}
+		envCtx.EigenLayer = preset.EigenLayer
+	}
+	config.Context[devnet.CONTEXT] = envCtx
+	return nil
+}
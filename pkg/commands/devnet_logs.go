@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	"github.com/urfave/cli/v2"
+)
+
+// DevnetLogsCommand defines the "devnet logs" command
+var DevnetLogsCommand = &cli.Command{
+	Name:      "logs",
+	Usage:     "Show logs of a devnet chain or of an offchain AVS component",
+	ArgsUsage: "[chain|component]",
+	Description: "Without an argument the L1 chain container's logs are shown. Pass a chain name from the devnet context (e.g. l2) " +
+		"for that chain, or a component captured by `--capture-logs` (e.g. aggregator) for its log in .devkit/logs; " +
+		"`run` shows the run script's whole output.",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Show chain logs since a relative duration (e.g. 10m) or RFC3339 timestamp",
+		},
+		&cli.StringFlag{
+			Name:  "tail",
+			Usage: "Number of lines to show from the end of the logs, or \"all\"",
+			Value: "all",
+		},
+		&cli.BoolFlag{
+			Name:    "follow",
+			Aliases: []string{"f"},
+			Usage:   "Keep streaming new log output",
+		},
+	}, common.GlobalFlags...),
+	Action: DevnetLogsAction,
+}
+
+func DevnetLogsAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	target := cCtx.Args().First()
+	if target == "" {
+		target = devnet.L1
+	}
+
+	tail := strings.ToLower(cCtx.String("tail"))
+	lines := -1
+	if tail != "all" {
+		n, err := strconv.Atoi(tail)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid --tail %q: use a non-negative number or \"all\"", cCtx.String("tail"))
+		}
+		lines = n
+	}
+
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+
+	if _, ok := cfg.Context[devnet.CONTEXT].Chains[target]; ok {
+		// Chains run by the anvil backend log to a file in the project
+		if isNativeDevnetChain(cCtx, cfg.Config.Project.Name, target) {
+			if cCtx.String("since") != "" {
+				logger.Warn("--since only applies to chains run by the docker backend; showing %s by --tail", devnet.GetNativeChainLogPath(target))
+			}
+			return devnet.TailFile(cCtx.Context, devnet.GetNativeChainLogPath(target), lines, cCtx.Bool("follow"), os.Stdout)
+		}
+
+		// Chain containers are read through the Docker API
+		if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+			return cli.Exit(err.Error(), 1)
+		}
+		containerName := devnet.GetDevnetContainerName(cfg.Config.Project.Name, target)
+		return common.StreamContainerLogs(cCtx.Context, containerName, common.ContainerLogOptions{
+			Since:  cCtx.String("since"),
+			Tail:   tail,
+			Follow: cCtx.Bool("follow"),
+		}, os.Stdout, os.Stderr)
+	}
+
+	// Anything else is a component log captured by AVSRun under .devkit/logs
+	if err := devnet.ValidateLogName(target); err != nil {
+		return err
+	}
+	path := devnet.GetComponentLogPath(target)
+	if _, err := os.Stat(path); err != nil {
+		components, _ := devnet.ListComponentLogs()
+		if len(components) == 0 {
+			components = []string{"none"}
+		}
+		return fmt.Errorf("no chain or component log named %q; chains: %s; components: %s (start with --capture-logs to capture them)",
+			target, strings.Join(devnet.GetDevnetChainNames(cfg), ", "), strings.Join(components, ", "))
+	}
+	if cCtx.String("since") != "" {
+		logger.Warn("--since only applies to chain logs; showing %s by --tail", path)
+	}
+	return devnet.TailFile(cCtx.Context, path, lines, cCtx.Bool("follow"), os.Stdout)
+}
+
+// isNativeDevnetChain reports whether the project's chain was last started by the anvil backend
+func isNativeDevnetChain(cCtx *cli.Context, projectName, chainName string) bool {
+	containerManager, err := devnet.NewContainerManager()
+	if err != nil {
+		return false
+	}
+	defer containerManager.Close()
+
+	c, err := containerManager.Get(cCtx.Context, devnet.GetDevnetContainerName(projectName, chainName))
+	if err == nil {
+		return c.Backend == devnet.BACKEND_ANVIL
+	}
+	// A chain whose anvil already exited still leaves its log behind
+	_, err = os.Stat(devnet.GetNativeChainLogPath(chainName))
+	return err == nil
+}
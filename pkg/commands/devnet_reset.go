@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// resetDevnet tears down every chain of the project's devnet together with the state its backend keeps,
+// clears the state earlier runs wrote into the devnet context and removes the devnet contract outputs.
+// It returns the config reloaded from the cleaned context.
+func resetDevnet(cCtx *cli.Context, logger iface.Logger, backend devnet.Backend, config *common.ConfigWithContextConfig, rootNode, contextNode *yaml.Node, yamlPath string) (*common.ConfigWithContextConfig, error) {
+	logger.Title("Resetting devnet...")
+
+	projectName := config.Config.Project.Name
+	for _, chainName := range devnet.GetDevnetChainNames(config) {
+		if err := backend.DestroyChain(cCtx.Context, projectName, chainName); err != nil {
+			return nil, fmt.Errorf("failed to remove devnet chain %s: %w", chainName, err)
+		}
+		logger.Info("Removed devnet chain %s and its state", chainName)
+		if err := devnet.UnregisterDevnetContainers(devnet.GetDevnetContainerName(projectName, chainName)); err != nil {
+			logger.Warn("Failed to remove devnet chain %s from the devnet registry: %v", chainName, err)
+		}
+	}
+
+	if err := devnet.DiscardDevnetState(); err != nil {
+		return nil, err
+	}
+
+	if err := devnet.ResetDevnetContext(contextNode, cCtx.Int("port")); err != nil {
+		return nil, err
+	}
+	if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+		return nil, fmt.Errorf("failed to save reset context: %w", err)
+	}
+	logger.Info("Cleared deployed contracts, operator sets, operator registrations and RPC URLs from %s", yamlPath)
+
+	if err := os.RemoveAll(getDevnetOutputsDir()); err != nil {
+		return nil, fmt.Errorf("failed to remove %s: %w", getDevnetOutputsDir(), err)
+	}
+	logger.Info("Removed %s", getDevnetOutputsDir())
+
+	reloaded, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload configurations after reset: %w", err)
+	}
+	return reloaded, nil
+}
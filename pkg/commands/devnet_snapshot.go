@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	"github.com/urfave/cli/v2"
+)
+
+// DevnetSnapshotCommand defines the "devnet snapshot" command
+var DevnetSnapshotCommand = &cli.Command{
+	Name:  "snapshot",
+	Usage: "Save, load and list named devnet state snapshots",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "save",
+			Usage:     "Save the running devnet's chain state and context under the given name",
+			ArgsUsage: "<name>",
+			Flags:     common.GlobalFlags,
+			Action:    SnapshotSaveAction,
+		},
+		{
+			Name:      "load",
+			Usage:     "Load a saved snapshot into the running devnet and restore its context",
+			ArgsUsage: "<name>",
+			Flags:     common.GlobalFlags,
+			Action:    SnapshotLoadAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "List saved devnet snapshots",
+			Flags:  common.GlobalFlags,
+			Action: SnapshotListAction,
+		},
+	},
+}
+
+func SnapshotSaveAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	name := cCtx.Args().First()
+	if name == "" {
+		return fmt.Errorf("snapshot name is required: devkit avs devnet snapshot save <name>")
+	}
+
+	rpcUrls, err := getDevnetRPCURLs()
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Saving devnet snapshot %s...", name)
+	meta, err := devnet.SaveSnapshot(cCtx.Context, rpcUrls, name, getDevnetContextPath(), getDevnetOutputsDir())
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	logger.Info("Saved snapshot %s (%s) to %s", meta.Name, formatSnapshotChains(meta), devnet.GetSnapshotDir(meta.Name))
+	return nil
+}
+
+func SnapshotLoadAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	name := cCtx.Args().First()
+	if name == "" {
+		return fmt.Errorf("snapshot name is required: devkit avs devnet snapshot load <name>")
+	}
+	meta, err := devnet.ReadSnapshotMetadata(name)
+	if err != nil {
+		return err
+	}
+
+	rpcUrls, err := getDevnetRPCURLs()
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Loading devnet snapshot %s...", name)
+	if err := restoreDevnetSnapshot(cCtx, meta, rpcUrls); err != nil {
+		return err
+	}
+
+	logger.Info("Loaded snapshot %s (%s)", meta.Name, formatSnapshotChains(meta))
+	return nil
+}
+
+func SnapshotListAction(cCtx *cli.Context) error {
+	snapshots, err := devnet.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Printf("%s🚫 No devnet snapshots saved.%s\n", devnet.Yellow, devnet.Reset)
+		return nil
+	}
+
+	fmt.Printf("%s📸 Devnet Snapshots:%s\n\n", devnet.Blue, devnet.Reset)
+	for _, s := range snapshots {
+		fmt.Printf("%s  -  %s%-25s%s %s%s%s  %s\n",
+			devnet.Cyan, devnet.Reset,
+			s.Name,
+			devnet.Reset,
+			devnet.Yellow, formatSnapshotChains(&s), devnet.Reset,
+			s.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+		)
+	}
+	return nil
+}
+
+// restoreDevnetSnapshot loads the snapshot's state into each running chain in rpcUrls (keyed by chain name),
+// then restores the context and contract outputs saved with it, pointing every chain at its running node.
+func restoreDevnetSnapshot(cCtx *cli.Context, meta *devnet.SnapshotMetadata, rpcUrls map[string]string) error {
+	for chainName, rpcUrl := range rpcUrls {
+		if _, ok := meta.Chains[chainName]; !ok {
+			continue
+		}
+		if err := devnet.LoadSnapshotState(cCtx.Context, rpcUrl, meta.Name, chainName); err != nil {
+			return fmt.Errorf("failed to load snapshot state: %w", err)
+		}
+	}
+
+	// Restore the context as it was when the snapshot was taken
+	rootNode, err := common.LoadYAML(filepath.Join(devnet.GetSnapshotDir(meta.Name), devnet.SnapshotContextFile))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot context: %w", err)
+	}
+	if len(rootNode.Content) == 0 {
+		return fmt.Errorf("empty YAML root node in snapshot %s", meta.Name)
+	}
+	contextNode := common.GetChildByKey(rootNode.Content[0], "context")
+	if contextNode == nil {
+		return fmt.Errorf("missing 'context' key in snapshot %s", meta.Name)
+	}
+
+	// The snapshot may have been taken on other ports
+	chainsNode := common.GetChildByKey(contextNode, "chains")
+	if chainsNode != nil {
+		for i := 0; i < len(chainsNode.Content); i += 2 {
+			rpcUrl, ok := rpcUrls[chainsNode.Content[i].Value]
+			if !ok {
+				continue
+			}
+			if rpcUrlNode := common.GetChildByKey(chainsNode.Content[i+1], "rpc_url"); rpcUrlNode != nil {
+				rpcUrlNode.Value = rpcUrl
+			}
+		}
+	}
+	if err := common.WriteYAML(getDevnetContextPath(), rootNode); err != nil {
+		return fmt.Errorf("failed to restore context: %w", err)
+	}
+	if err := devnet.RestoreSnapshotContextOverrides(meta.Name); err != nil {
+		return fmt.Errorf("failed to restore context overrides: %w", err)
+	}
+
+	if err := devnet.RestoreSnapshotOutputs(meta.Name, getDevnetOutputsDir()); err != nil {
+		return fmt.Errorf("failed to restore contract outputs: %w", err)
+	}
+	return nil
+}
+
+// getDevnetRPCURLs returns the RPC url recorded for every chain in the devnet context, keyed by chain name
+func getDevnetRPCURLs() (map[string]string, error) {
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	rpcUrls := make(map[string]string)
+	for name, chainCfg := range cfg.Context[devnet.CONTEXT].Chains {
+		if chainCfg.RPCURL != "" {
+			rpcUrls[name] = chainCfg.RPCURL
+		}
+	}
+	if _, ok := rpcUrls[devnet.L1]; !ok {
+		return nil, fmt.Errorf("L1 rpc_url not set in context '%s'; is the devnet running?", devnet.CONTEXT)
+	}
+	return rpcUrls, nil
+}
+
+// formatSnapshotChains renders each chain of a snapshot as "name@block", L1 first
+func formatSnapshotChains(meta *devnet.SnapshotMetadata) string {
+	names := make([]string, 0, len(meta.Chains))
+	for name := range meta.Chains {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == devnet.L1 || names[j] == devnet.L1 {
+			return names[i] == devnet.L1
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s@%d", name, meta.Chains[name].BlockNumber))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getDevnetContextPath returns the path to the devnet context yaml
+func getDevnetContextPath() string {
+	return filepath.Join("config", "contexts", devnet.CONTEXT+".yaml")
+}
+
+// getDevnetOutputsDir returns the directory contract outputs are written to for the devnet context
+func getDevnetOutputsDir() string {
+	return getContractOutputsDir(devnet.CONTEXT)
+}
+
+// getContractOutputsDir returns the directory contract outputs are written to for a context
+func getContractOutputsDir(contextName string) string {
+	return filepath.Join("contracts", "outputs", contextName)
+}
@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// stakeOperatorDevnet deposits the operator's configured stake into every strategy of the operator set and
+// allocates it. The stake is the operator's total, so each of its operator sets receives an equal share
+// (1/operatorSetCount) of the operator's magnitude. Steps already done on chain are skipped.
+func stakeOperatorDevnet(ctx context.Context, logger iface.Logger, cfg *common.ConfigWithContextConfig, operatorAddress string, operatorSetID uint32, operatorSetCount int) error {
+	envCtx, ok := cfg.Context[devnet.CONTEXT]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", devnet.CONTEXT)
+	}
+	l1Cfg, ok := envCtx.Chains[devnet.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", devnet.CONTEXT)
+	}
+
+	operatorSpec, err := common.GetOperatorSpec(envCtx, operatorAddress)
+	if err != nil {
+		return err
+	}
+	if operatorSpec.Stake == "" {
+		logger.Info("No stake configured for operator %s, skipping deposit and allocation", operatorAddress)
+		return nil
+	}
+	stake, err := devnet.ParseStake(operatorSpec.Stake)
+	if err != nil {
+		return fmt.Errorf("operator %s: %w", operatorAddress, err)
+	}
+	if stake.IsZero() {
+		logger.Info("Stake for operator %s is zero, skipping deposit and allocation", operatorAddress)
+		return nil
+	}
+
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	allocationManagerAddr, delegationManagerAddr := devnet.GetEigenLayerAddresses(cfg)
+
+	operatorSigner, err := common.OperatorSigner(*operatorSpec)
+	if err != nil {
+		return err
+	}
+
+	contractCaller, err := common.NewContractCaller(
+		operatorSigner,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contract caller: %w", err)
+	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(devnet.CONTEXT))
+
+	operator := ethcommon.HexToAddress(operatorAddress)
+	avs := ethcommon.HexToAddress(envCtx.Avs.Address)
+
+	strategies, err := contractCaller.GetStrategiesInOperatorSet(ctx, avs, operatorSetID)
+	if err != nil {
+		return fmt.Errorf("failed to get strategies for operator set %d: %w", operatorSetID, err)
+	}
+	if len(strategies) == 0 {
+		logger.Info("Operator set %d has no strategies, skipping stake for operator %s", operatorSetID, operatorAddress)
+		return nil
+	}
+
+	// Deposit the stake into each strategy the operator set uses
+	staked := make([]ethcommon.Address, 0, len(strategies))
+	for _, strategy := range strategies {
+		if strategy == ethcommon.HexToAddress(devnet.BEACON_CHAIN_ETH_STRATEGY_ADDRESS) {
+			logger.Warn("Skipping beacon chain ETH strategy for operator %s; native restaking cannot be simulated on the devnet", operatorAddress)
+			continue
+		}
+		// Strategies from the context may only exist on the forked network
+		if code, err := client.CodeAt(ctx, strategy, nil); err != nil {
+			return fmt.Errorf("failed to get code of strategy %s: %w", strategy.Hex(), err)
+		} else if len(code) == 0 {
+			logger.Warn("Skipping strategy %s for operator %s; it has no code on this chain", strategy.Hex(), operatorAddress)
+			continue
+		}
+		if err := depositStake(ctx, logger, contractCaller, l1Cfg.RPCURL, operator, strategy, stake); err != nil {
+			return fmt.Errorf("failed to deposit stake into strategy %s: %w", strategy.Hex(), err)
+		}
+		staked = append(staked, strategy)
+	}
+	if len(staked) == 0 {
+		return nil
+	}
+
+	// GetDevnetBlockTimeOrDefault falls back to 12s alongside its error
+	blockTime, _ := devnet.GetDevnetBlockTimeOrDefault(cfg, devnet.L1)
+	if err := ensureAllocationDelay(ctx, logger, contractCaller, l1Cfg.RPCURL, operator, uint64(blockTime)); err != nil {
+		return err
+	}
+
+	// Allocate this operator set's share of the operator's magnitude in every staked strategy
+	allocStrategies := make([]ethcommon.Address, 0, len(staked))
+	magnitudes := make([]uint64, 0, len(staked))
+	for _, strategy := range staked {
+		current, err := contractCaller.GetAllocatedMagnitude(ctx, operator, avs, operatorSetID, strategy)
+		if err != nil {
+			return fmt.Errorf("failed to get allocation for strategy %s: %w", strategy.Hex(), err)
+		}
+		if current > 0 {
+			logger.Info("Operator %s already allocates to operator set %d in strategy %s", operatorAddress, operatorSetID, strategy.Hex())
+			continue
+		}
+		maxMagnitude, err := contractCaller.GetMaxMagnitude(ctx, operator, strategy)
+		if err != nil {
+			return fmt.Errorf("failed to get max magnitude for strategy %s: %w", strategy.Hex(), err)
+		}
+		allocatable, err := contractCaller.GetAllocatableMagnitude(ctx, operator, strategy)
+		if err != nil {
+			return fmt.Errorf("failed to get allocatable magnitude for strategy %s: %w", strategy.Hex(), err)
+		}
+		magnitude := min(maxMagnitude/uint64(max(operatorSetCount, 1)), allocatable)
+		if magnitude == 0 {
+			logger.Warn("Operator %s has no magnitude left to allocate in strategy %s", operatorAddress, strategy.Hex())
+			continue
+		}
+		allocStrategies = append(allocStrategies, strategy)
+		magnitudes = append(magnitudes, magnitude)
+	}
+	if len(allocStrategies) == 0 {
+		return nil
+	}
+
+	logger.Info("Allocating stake of operator %s to operator set %d", operatorAddress, operatorSetID)
+	return contractCaller.ModifyAllocations(ctx, operator, avs, operatorSetID, allocStrategies, magnitudes)
+}
+
+// depositStake tops the operator's deposit in strategy up to the configured stake, acquiring the
+// underlying token on the fork first
+func depositStake(ctx context.Context, logger iface.Logger, contractCaller *common.ContractCaller, rpcURL string, operator, strategy ethcommon.Address, stake *devnet.Stake) error {
+	token, err := contractCaller.GetStrategyUnderlyingToken(ctx, strategy)
+	if err != nil {
+		return fmt.Errorf("failed to get underlying token: %w", err)
+	}
+	decimals, err := contractCaller.GetERC20Decimals(ctx, token)
+	if err != nil {
+		return err
+	}
+	amount, err := stake.BaseUnits(decimals)
+	if err != nil {
+		return err
+	}
+
+	deposited, err := contractCaller.GetStrategyUserUnderlying(ctx, strategy, operator)
+	if err != nil {
+		return fmt.Errorf("failed to get existing deposit: %w", err)
+	}
+	if deposited.Cmp(amount) >= 0 {
+		logger.Info("Operator %s already has %s deposited in strategy %s", operator.Hex(), stake.Raw, strategy.Hex())
+		return nil
+	}
+	needed := new(big.Int).Sub(amount, deposited)
+
+	if err := devnet.DealERC20(ctx, rpcURL, token, operator, needed); err != nil {
+		return err
+	}
+
+	logger.Info("Depositing %s of %s for operator %s into strategy %s", needed, token.Hex(), operator.Hex(), strategy.Hex())
+	return contractCaller.DepositIntoStrategy(ctx, strategy, token, needed)
+}
+
+// ensureAllocationDelay makes the operator's allocation delay take effect by mining past the configuration delay.
+// The blocks are mined in a single anvil_mine call, blockTime seconds apart, so the chain's clock warps forward by
+// the same span a live chain would take.
+func ensureAllocationDelay(ctx context.Context, logger iface.Logger, contractCaller *common.ContractCaller, rpcURL string, operator ethcommon.Address, blockTime uint64) error {
+	isSet, _, err := contractCaller.GetAllocationDelay(ctx, operator)
+	if err != nil {
+		return fmt.Errorf("failed to get allocation delay: %w", err)
+	}
+	if isSet {
+		return nil
+	}
+
+	configDelay, err := contractCaller.GetAllocationConfigurationDelay(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get allocation configuration delay: %w", err)
+	}
+
+	anvil, err := devnet.DialAnvil(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	blocks := uint64(configDelay) + 1
+	logger.Info("Mining %d blocks (%s) so the allocation delay of operator %s takes effect", blocks, time.Duration(blocks*blockTime)*time.Second, operator.Hex())
+	return anvil.MineWithInterval(ctx, blocks, blockTime)
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	"github.com/urfave/cli/v2"
+)
+
+// DevnetStatusCommand defines the "devnet status" command
+var DevnetStatusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "Shows chain, contract, operator set and operator registration health of the devnet",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "Output format: table or json",
+			Value: "table",
+		},
+	}, common.GlobalFlags...),
+	Action: DevnetStatusAction,
+}
+
+func DevnetStatusAction(cCtx *cli.Context) error {
+	output := strings.ToLower(cCtx.String("output"))
+	if output != "table" && output != "json" {
+		return fmt.Errorf("unsupported output %q: use table or json", output)
+	}
+
+	cfg, err := common.LoadConfigWithContextConfig(devnet.CONTEXT)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+
+	status := devnet.CollectDevnetStatus(cCtx.Context, cfg)
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	}
+
+	printDevnetStatus(status)
+	return nil
+}
+
+// printDevnetStatus renders the status report as human readable tables
+func printDevnetStatus(status *devnet.DevnetStatus) {
+	fmt.Printf("%s⛓️  Chains:%s\n\n", devnet.Blue, devnet.Reset)
+	for _, c := range status.Chains {
+		fmt.Printf("%s  -  %s%-6s %s  %s\n", devnet.Cyan, devnet.Reset, c.Name, statusMark(c.Reachable && c.Error == ""), c.RPCURL)
+		if c.Reachable {
+			fmt.Printf("       chain id %-10d block %-12d timestamp %d\n", c.ChainID, c.BlockNumber, c.BlockTimestamp)
+		}
+		if c.Forked && c.ForkHost != "" {
+			fmt.Printf("       forked from %s at block %d\n", c.ForkHost, c.ForkBlock)
+		} else if c.Forked {
+			fmt.Printf("       forked at block %d\n", c.ForkBlock)
+		}
+		if c.Error != "" {
+			fmt.Printf("       %s%s%s\n", devnet.Yellow, c.Error, devnet.Reset)
+		}
+	}
+
+	fmt.Printf("\n%s📄 Deployed Contracts:%s\n\n", devnet.Blue, devnet.Reset)
+	if len(status.Contracts) == 0 {
+		fmt.Printf("     none recorded in context\n")
+	}
+	for _, c := range status.Contracts {
+		fmt.Printf("%s  -  %s%-30s %s  %s\n", devnet.Cyan, devnet.Reset, c.Name, statusMark(c.HasCode), c.Address)
+	}
+
+	fmt.Printf("\n%s🧩 Operator Sets:%s\n\n", devnet.Blue, devnet.Reset)
+	if len(status.OperatorSets) == 0 {
+		fmt.Printf("     none recorded in context\n")
+	}
+	for _, s := range status.OperatorSets {
+		fmt.Printf("%s  -  %s%-6d %s  strategies: %s\n", devnet.Cyan, devnet.Reset, s.ID, statusMark(s.Exists), strings.Join(s.Strategies, ", "))
+	}
+
+	fmt.Printf("\n%s👷 Operators:%s\n\n", devnet.Blue, devnet.Reset)
+	for _, o := range status.Operators {
+		sets := make([]string, 0, len(o.Registrations))
+		for _, r := range o.Registrations {
+			sets = append(sets, fmt.Sprintf("%d %s", r.OperatorSetID, statusMark(r.Registered)))
+		}
+		fmt.Printf("%s  -  %s%s  operator %s  operator sets: %s\n", devnet.Cyan, devnet.Reset, o.Address, statusMark(o.IsOperator), strings.Join(sets, ", "))
+		if o.Error != "" {
+			fmt.Printf("       %s%s%s\n", devnet.Yellow, o.Error, devnet.Reset)
+		}
+	}
+
+	if status.Healthy {
+		fmt.Printf("\n%s✅ Devnet is healthy%s\n", devnet.Green, devnet.Reset)
+	} else {
+		fmt.Printf("\n%s⚠️  Devnet is not healthy%s\n", devnet.Yellow, devnet.Reset)
+	}
+}
+
+// statusMark renders a boolean check as a coloured tick or cross
+func statusMark(ok bool) string {
+	if ok {
+		return devnet.Green + "✔" + devnet.Reset
+	}
+	return devnet.Yellow + "✘" + devnet.Reset
+}
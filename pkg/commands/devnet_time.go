@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// chainTargetFlags select which chain of which context a time control command acts on
+var chainTargetFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "context",
+		Usage: "Context whose chain RPC to target",
+		Value: devnet.CONTEXT,
+	},
+	&cli.StringFlag{
+		Name:  "chain",
+		Usage: "Chain in the context to target (e.g. l1, l2)",
+		Value: devnet.L1,
+	},
+}
+
+// DevnetMineCommand defines the "devnet mine" command
+var DevnetMineCommand = &cli.Command{
+	Name:      "mine",
+	Usage:     "Mine blocks immediately",
+	ArgsUsage: "[n]",
+	Flags:     append(append([]cli.Flag{}, chainTargetFlags...), common.GlobalFlags...),
+	Action:    DevnetMineAction,
+}
+
+// DevnetWarpCommand defines the "devnet warp" command
+var DevnetWarpCommand = &cli.Command{
+	Name:        "warp",
+	Usage:       "Move chain time forward by a duration or to a timestamp and mine a block",
+	ArgsUsage:   "<duration|timestamp>",
+	Description: "Durations accept Go syntax plus days (e.g. 90s, 12h, 7d). Timestamps are unix seconds or RFC3339.",
+	Flags:       append(append([]cli.Flag{}, chainTargetFlags...), common.GlobalFlags...),
+	Action:      DevnetWarpAction,
+}
+
+// DevnetAutomineCommand defines the "devnet automine" command
+var DevnetAutomineCommand = &cli.Command{
+	Name:        "automine",
+	Usage:       "Switch between mining per transaction, interval mining and manual mining",
+	ArgsUsage:   "<on|off|interval>",
+	Description: "on mines a block per transaction, off stops mining until `devnet mine` is called, an interval (e.g. 3s) mines on a timer.",
+	Flags:       append(append([]cli.Flag{}, chainTargetFlags...), common.GlobalFlags...),
+	Action:      DevnetAutomineAction,
+}
+
+func DevnetMineAction(cCtx *cli.Context) error {
+	blocks := uint64(1)
+	if arg := cCtx.Args().First(); arg != "" {
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil || n == 0 {
+			return fmt.Errorf("invalid block count %q: use a positive number", arg)
+		}
+		blocks = n
+	}
+
+	rpcUrl, err := getChainTargetRPCURL(cCtx)
+	if err != nil {
+		return err
+	}
+	anvil, err := devnet.DialAnvil(cCtx.Context, rpcUrl)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	if err := anvil.Mine(cCtx.Context, blocks); err != nil {
+		return err
+	}
+	return printChainHead(cCtx.Context, rpcUrl, fmt.Sprintf("Mined %d block(s)", blocks))
+}
+
+func DevnetWarpAction(cCtx *cli.Context) error {
+	target, err := devnet.ParseWarpTarget(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	rpcUrl, err := getChainTargetRPCURL(cCtx)
+	if err != nil {
+		return err
+	}
+	anvil, err := devnet.DialAnvil(cCtx.Context, rpcUrl)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	if target.Timestamp != 0 {
+		err = anvil.SetNextBlockTimestamp(cCtx.Context, target.Timestamp)
+	} else {
+		err = anvil.IncreaseTime(cCtx.Context, uint64(target.Duration/time.Second))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Time only moves once a block is mined
+	if err := anvil.Mine(cCtx.Context, 1); err != nil {
+		return err
+	}
+	return printChainHead(cCtx.Context, rpcUrl, "Warped chain time")
+}
+
+func DevnetAutomineAction(cCtx *cli.Context) error {
+	mode, err := devnet.ParseAutomineMode(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	rpcUrl, err := getChainTargetRPCURL(cCtx)
+	if err != nil {
+		return err
+	}
+	anvil, err := devnet.DialAnvil(cCtx.Context, rpcUrl)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	// Interval mining and automine are exclusive; clear both before applying the requested mode
+	if err := anvil.SetIntervalMining(cCtx.Context, 0); err != nil {
+		return err
+	}
+	if err := anvil.SetAutomine(cCtx.Context, mode.Automine); err != nil {
+		return err
+	}
+	if mode.Interval > 0 {
+		if err := anvil.SetIntervalMining(cCtx.Context, uint64(mode.Interval/time.Second)); err != nil {
+			return err
+		}
+	}
+
+	var summary string
+	switch {
+	case mode.Automine:
+		summary = "Automine on: a block is mined for every transaction"
+	case mode.Interval > 0:
+		summary = fmt.Sprintf("Interval mining on: a block is mined every %s", mode.Interval)
+	default:
+		summary = "Mining off: blocks are only mined with `devnet mine`"
+	}
+	return printChainHead(cCtx.Context, rpcUrl, summary)
+}
+
+// getChainTargetRPCURL resolves the rpc_url of the --chain in the --context
+func getChainTargetRPCURL(cCtx *cli.Context) (string, error) {
+	contextName := cCtx.String("context")
+	chainName := cCtx.String("chain")
+
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load configurations: %w", err)
+	}
+	chainCfg, ok := cfg.Context[contextName].Chains[chainName]
+	if !ok || chainCfg.RPCURL == "" {
+		return "", fmt.Errorf("rpc_url for chain '%s' not set in context '%s'", chainName, contextName)
+	}
+	return chainCfg.RPCURL, nil
+}
+
+// printChainHead prints a summary line followed by the chain's latest block number and timestamp
+func printChainHead(ctx context.Context, rpcUrl string, summary string) error {
+	client, err := ethclient.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", rpcUrl, err)
+	}
+	defer client.Close()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	blockTime := time.Unix(int64(header.Time), 0).UTC()
+	fmt.Printf("%s✅ %s%s\n", devnet.Green, summary, devnet.Reset)
+	fmt.Printf("   block %s%d%s  timestamp %s%d%s (%s)\n",
+		devnet.Cyan, header.Number.Uint64(), devnet.Reset,
+		devnet.Cyan, header.Time, devnet.Reset,
+		blockTime.Format(time.RFC3339),
+	)
+	return nil
+}
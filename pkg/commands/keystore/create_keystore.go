@@ -1,8 +1,11 @@
 package keystore
 
 import (
+	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -11,17 +14,32 @@ import (
 
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/signing/bn254"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/signing/keystore"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 )
 
+// Key types a keystore can hold
+const (
+	KeyTypeBN254 = "bn254"
+	KeyTypeECDSA = "ecdsa"
+)
+
+// scrypt parameters for ECDSA keystores; tests lower them to keep encryption fast
+var (
+	ecdsaScryptN = ethkeystore.StandardScryptN
+	ecdsaScryptP = ethkeystore.StandardScryptP
+)
+
 var CreateCommand = &cli.Command{
 	Name:  "create",
-	Usage: "Generates a Bls keystore JSON file for a private key",
+	Usage: "Generates a Bls or ECDSA keystore JSON file for a private key",
 	Flags: append([]cli.Flag{
 		&cli.StringFlag{
-			Name:     "key",
-			Usage:    "Bls private key in large number",
-			Required: true,
+			Name:  "key",
+			Usage: "Private key: a large number for bn254, hex for ecdsa (a new ecdsa key is generated when omitted)",
 		},
 		&cli.StringFlag{
 			Name:     "path",
@@ -30,8 +48,8 @@ var CreateCommand = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:  "type",
-			Usage: "Curve type (only 'bn254' supported)",
-			Value: "bn254",
+			Usage: "Key type: 'bn254' for a Bls keystore or 'ecdsa' for a Web3 Secret Storage (V3) keystore",
+			Value: KeyTypeBN254,
 		},
 		&cli.StringFlag{
 			Name:  "password",
@@ -47,6 +65,14 @@ var CreateCommand = &cli.Command{
 		curve := cCtx.String("type")
 		password := cCtx.String("password")
 
+		if curve == KeyTypeECDSA {
+			_, err := CreateECDSAKeystore(logger, privateKey, path, password)
+			return err
+		}
+		if privateKey == "" {
+			return errors.New("--key is required for bn254 keystores")
+		}
+
 		logger.Debug("🔐 Starting Bls keystore creation")
 		logger.Debug("• Curve: %s", curve)
 		logger.Debug("• Output Path: %s", path)
@@ -61,7 +87,7 @@ func CreateBLSKeystore(logger iface.Logger, privateKey, path, password, curve st
 		return errors.New("invalid path: must include full file name ending in .json")
 	}
 
-	if curve != "bn254" {
+	if curve != KeyTypeBN254 {
 		return fmt.Errorf("unsupported curve: %s", curve)
 	}
 
@@ -97,3 +123,86 @@ func CreateBLSKeystore(logger iface.Logger, privateKey, path, password, curve st
 
 	return nil
 }
+
+// CreateECDSAKeystore encrypts a hex ECDSA private key, or a newly generated one when privateKeyHex is empty,
+// into a Web3 Secret Storage (V3) keystore at path and returns its address
+func CreateECDSAKeystore(logger iface.Logger, privateKeyHex, path, password string) (ethcommon.Address, error) {
+	if filepath.Ext(path) != ".json" {
+		return ethcommon.Address{}, errors.New("invalid path: must include full file name ending in .json")
+	}
+
+	logger.Debug("🔐 Starting ECDSA keystore creation")
+	logger.Debug("• Output Path: %s", path)
+
+	var key *ecdsa.PrivateKey
+	var err error
+	if privateKeyHex == "" {
+		logger.Debug("• Generating a new private key")
+		key, err = crypto.GenerateKey()
+	} else {
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	}
+	if err != nil {
+		return ethcommon.Address{}, fmt.Errorf("invalid ecdsa private key: %w", err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return ethcommon.Address{}, fmt.Errorf("keystore %s already exists", path)
+	}
+	if err := writeECDSAKeystore(key, path, password); err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	logger.Info("✅ Keystore generated successfully")
+	logger.Info("🔑 Address: %s", address.Hex())
+	if privateKeyHex == "" {
+		logger.Info("The keystore holds the only copy of this new key: keep it and its password safe")
+	}
+	return address, nil
+}
+
+// writeECDSAKeystore encrypts key with password and writes it to path, readable only by the owner
+func writeECDSAKeystore(key *ecdsa.PrivateKey, path, password string) error {
+	keyJSON, err := ethkeystore.EncryptKey(&ethkeystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, password, ecdsaScryptN, ecdsaScryptP)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt keystore: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+	if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore %s: %w", path, err)
+	}
+	return nil
+}
+
+// keystoreHeader holds the unencrypted fields that tell Bls and ECDSA keystores apart
+type keystoreHeader struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"publicKey"`
+	CurveType string `json:"curveType"`
+	Version   int    `json:"version"`
+}
+
+// DetectKeyType reports whether keyJSON is a bn254 Bls keystore or an ECDSA (V3) keystore
+func DetectKeyType(keyJSON []byte) (string, error) {
+	var header keystoreHeader
+	if err := json.Unmarshal(keyJSON, &header); err != nil {
+		return "", fmt.Errorf("invalid keystore JSON: %w", err)
+	}
+	switch {
+	case header.CurveType == KeyTypeBN254, header.CurveType == "" && header.PublicKey != "":
+		return KeyTypeBN254, nil
+	case header.CurveType != "":
+		return "", fmt.Errorf("unsupported curve: %s", header.CurveType)
+	case header.Version == 3 && header.Address != "":
+		return KeyTypeECDSA, nil
+	default:
+		return "", errors.New("unrecognized keystore: neither a bn254 nor an ecdsa (V3) keystore")
+	}
+}
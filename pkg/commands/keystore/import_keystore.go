@@ -0,0 +1,143 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var ImportCommand = &cli.Command{
+	Name:  "import",
+	Usage: "Moves the operator ecdsa_key values of a context into ECDSA keystores and references them from the context",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "Context whose operator keys are imported",
+			Value: devnet.CONTEXT,
+		},
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Directory to write the keystore files to",
+			Value: "keystores",
+		},
+		&cli.StringFlag{
+			Name:  "password-env",
+			Usage: "Environment variable holding the keystore password; the context references the variable, not the password",
+		},
+		&cli.StringFlag{
+			Name:  "password",
+			Usage: "Password to encrypt the keystores, written to the context next to each reference",
+		},
+	}, common.GlobalFlags...),
+	Action: func(cCtx *cli.Context) error {
+		logger := common.LoggerFromContext(cCtx.Context)
+
+		contextName := cCtx.String("context")
+		passwordEnv := cCtx.String("password-env")
+		password := cCtx.String("password")
+
+		ref := common.SignerConfig{Type: common.SignerTypeKeystore}
+		switch {
+		case passwordEnv != "" && password != "":
+			return errors.New("use either --password-env or --password, not both")
+		case passwordEnv != "":
+			var ok bool
+			if password, ok = os.LookupEnv(passwordEnv); !ok {
+				return fmt.Errorf("environment variable %s is not set", passwordEnv)
+			}
+			ref.PasswordEnv = passwordEnv
+		case cCtx.IsSet("password"):
+			logger.Warn("The keystore password is written to context '%s' in plaintext; prefer --password-env", contextName)
+			ref.Password = password
+		default:
+			return errors.New("--password-env or --password is required to encrypt the keystores")
+		}
+
+		contextPath := filepath.Join(common.DefaultConfigWithContextConfigPath, "contexts", contextName+".yaml")
+		return ImportECDSAKeys(logger, contextPath, cCtx.String("dir"), password, ref)
+	},
+}
+
+// ImportECDSAKeys writes the ecdsa_key of every operator in the context file at contextPath to a keystore in dir
+// encrypted with password, and replaces the key in the context with ref pointing at that keystore. A keystore
+// left by an earlier run that decrypts to the same key is reused.
+func ImportECDSAKeys(logger iface.Logger, contextPath, dir, password string, ref common.SignerConfig) error {
+	rootNode, err := common.LoadYAML(contextPath)
+	if err != nil {
+		return fmt.Errorf("failed to read context %s: %w", contextPath, err)
+	}
+	if len(rootNode.Content) == 0 {
+		return fmt.Errorf("empty YAML root node in %s", contextPath)
+	}
+	contextNode := common.GetChildByKey(rootNode.Content[0], "context")
+	if contextNode == nil {
+		return fmt.Errorf("missing 'context' key in %s", contextPath)
+	}
+	operators := common.GetChildByKey(contextNode, "operators")
+	if operators == nil || operators.Kind != yaml.SequenceNode {
+		return fmt.Errorf("no operators in %s", contextPath)
+	}
+
+	imported := 0
+	for _, entry := range operators.Content {
+		var op common.OperatorSpec
+		if err := entry.Decode(&op); err != nil {
+			return fmt.Errorf("invalid operator in %s: %w", contextPath, err)
+		}
+		if op.Signer != nil || op.ECDSAKey == "" {
+			logger.Debug("Operator %s has no ecdsa_key to import", op.Address)
+			continue
+		}
+
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(op.ECDSAKey, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid ecdsa_key for operator %s: %w", op.Address, err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		if !strings.EqualFold(address.Hex(), op.Address) {
+			return fmt.Errorf("ecdsa_key of operator %s belongs to %s", op.Address, address.Hex())
+		}
+
+		path := filepath.Join(dir, address.Hex()+".ecdsa.keystore.json")
+		if existing, err := os.ReadFile(path); err == nil {
+			stored, err := ethkeystore.DecryptKey(existing, password)
+			if err != nil || stored.Address != address {
+				return fmt.Errorf("keystore %s already exists and does not hold the key of operator %s", path, op.Address)
+			}
+			logger.Info("Reusing keystore %s for operator %s", path, address.Hex())
+		} else {
+			if err := writeECDSAKeystore(key, path, password); err != nil {
+				return err
+			}
+			logger.Info("Wrote keystore %s for operator %s", path, address.Hex())
+		}
+
+		opRef := ref
+		opRef.Path = path
+		if _, err := common.SetOperatorSigner(contextNode, op.Address, opRef); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	if imported == 0 {
+		logger.Info("No operator ecdsa_key to import in %s", contextPath)
+		return nil
+	}
+	if err := common.WriteYAML(contextPath, rootNode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", contextPath, err)
+	}
+	logger.Info("✅ Imported %d operator key(s); %s now references the keystores", imported, contextPath)
+	return nil
+}
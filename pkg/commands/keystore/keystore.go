@@ -10,5 +10,6 @@ var KeystoreCommand = &cli.Command{
 	Subcommands: []*cli.Command{
 		CreateCommand,
 		ReadCommand,
+		ImportCommand,
 	},
 }
@@ -8,9 +8,14 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
 	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 )
 
 func TestKeystoreCreateAndRead(t *testing.T) {
@@ -100,3 +105,148 @@ func TestKeystoreCreateAndRead(t *testing.T) {
 	require.Contains(t, output, "Save this BLS private key in a secure location")
 	require.Contains(t, output, key)
 }
+
+// anvil's fourth dev account, the first operator of the devnet context
+const (
+	testECDSAKey     = "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+	testECDSAAddress = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+)
+
+func useLightScrypt(t *testing.T) {
+	t.Helper()
+	n, p := ecdsaScryptN, ecdsaScryptP
+	ecdsaScryptN, ecdsaScryptP = ethkeystore.LightScryptN, ethkeystore.LightScryptP
+	t.Cleanup(func() { ecdsaScryptN, ecdsaScryptP = n, p })
+}
+
+func TestECDSAKeystoreCreateAndRead(t *testing.T) {
+	useLightScrypt(t)
+	path := filepath.Join(t.TempDir(), "operator1.ecdsa.keystore.json")
+
+	address, err := CreateECDSAKeystore(logger.NewNoopLogger(), testECDSAKey, path, "testpass")
+	require.NoError(t, err)
+	assert.Equal(t, testECDSAAddress, address.Hex())
+
+	_, err = CreateECDSAKeystore(logger.NewNoopLogger(), testECDSAKey, path, "testpass")
+	assert.ErrorContains(t, err, "already exists")
+
+	keyJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	keyType, err := DetectKeyType(keyJSON)
+	require.NoError(t, err)
+	assert.Equal(t, KeyTypeECDSA, keyType)
+
+	// The file is a standard V3 keystore any Ethereum tool can decrypt
+	key, err := ethkeystore.DecryptKey(keyJSON, "testpass")
+	require.NoError(t, err)
+	assert.Equal(t, testECDSAAddress, key.Address.Hex())
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	readCmd, _ := testutils.WithTestConfigAndNoopLoggerAndAccess(ReadCommand)
+	app := &cli.App{Name: "devkit", Commands: []*cli.Command{{Name: "keystore", Subcommands: []*cli.Command{readCmd}}}}
+	require.NoError(t, app.Run([]string{"devkit", "keystore", "read", "--path", path, "--password", "testpass"}))
+	assert.Contains(t, buf.String(), testECDSAAddress)
+	assert.Contains(t, buf.String(), testECDSAKey)
+
+	require.Error(t, app.Run([]string{"devkit", "keystore", "read", "--path", path, "--password", "wrong"}))
+}
+
+func TestCreateECDSAKeystoreGeneratesKey(t *testing.T) {
+	useLightScrypt(t)
+	path := filepath.Join(t.TempDir(), "new.json")
+
+	address, err := CreateECDSAKeystore(logger.NewNoopLogger(), "", path, "testpass")
+	require.NoError(t, err)
+	keyJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	key, err := ethkeystore.DecryptKey(keyJSON, "testpass")
+	require.NoError(t, err)
+	assert.Equal(t, address, key.Address)
+
+	_, err = CreateECDSAKeystore(logger.NewNoopLogger(), "", filepath.Join(t.TempDir(), "new.txt"), "testpass")
+	assert.ErrorContains(t, err, "ending in .json")
+}
+
+func TestDetectKeyType(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr string
+	}{
+		{"bn254", `{"publicKey":"abc","crypto":{},"version":4,"curveType":"bn254"}`, KeyTypeBN254, ""},
+		{"bn254 without curve", `{"publicKey":"abc","crypto":{},"version":4}`, KeyTypeBN254, ""},
+		{"ecdsa", `{"address":"90f79bf6eb2c4f870365e785982e1f101e93b906","crypto":{},"version":3}`, KeyTypeECDSA, ""},
+		{"bls381", `{"publicKey":"abc","crypto":{},"version":4,"curveType":"bls381"}`, "", "unsupported curve: bls381"},
+		{"unknown", `{"crypto":{}}`, "", "unrecognized keystore"},
+		{"not json", `not json`, "", "invalid keystore JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectKeyType([]byte(tt.json))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+const importTestContext = `version: 0.0.8
+context:
+  name: "devnet"
+  operators:
+    - address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+      # Operator 1
+      ecdsa_key: "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+      stake: "1000ETH"
+    - address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+      signer:
+        type: "web3signer"
+        url: "http://localhost:9000"
+`
+
+func TestImportECDSAKeys(t *testing.T) {
+	useLightScrypt(t)
+	dir := t.TempDir()
+	contextPath := filepath.Join(dir, "devnet.yaml")
+	require.NoError(t, os.WriteFile(contextPath, []byte(importTestContext), 0644))
+	keystoreDir := filepath.Join(dir, "keystores")
+	ref := common.SignerConfig{Type: common.SignerTypeKeystore, PasswordEnv: "OPERATOR_KEYSTORE_PASSWORD"}
+
+	require.NoError(t, ImportECDSAKeys(logger.NewNoopLogger(), contextPath, keystoreDir, "testpass", ref))
+
+	raw, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "ecdsa_key")
+	assert.Contains(t, string(raw), "# Operator 1")
+
+	var cfg struct {
+		Context common.ChainContextConfig `yaml:"context"`
+	}
+	require.NoError(t, yaml.Unmarshal(raw, &cfg))
+	require.Len(t, cfg.Context.Operators, 2)
+	imported := cfg.Context.Operators[0]
+	assert.Equal(t, "1000ETH", imported.Stake)
+	require.NotNil(t, imported.Signer)
+	assert.Equal(t, filepath.Join(keystoreDir, testECDSAAddress+".ecdsa.keystore.json"), imported.Signer.Path)
+	assert.Equal(t, "OPERATOR_KEYSTORE_PASSWORD", imported.Signer.PasswordEnv)
+	assert.Equal(t, common.SignerTypeWeb3Signer, cfg.Context.Operators[1].Signer.Type, "existing signers are kept")
+
+	// The rewritten context signs through the keystore
+	t.Setenv("OPERATOR_KEYSTORE_PASSWORD", "testpass")
+	signer, err := common.OperatorSigner(imported)
+	require.NoError(t, err)
+	assert.Equal(t, testECDSAAddress, signer.Address().Hex())
+
+	// Importing again after the context was restored reuses the keystore, but not one with another password
+	require.NoError(t, os.WriteFile(contextPath, []byte(importTestContext), 0644))
+	require.NoError(t, ImportECDSAKeys(logger.NewNoopLogger(), contextPath, keystoreDir, "testpass", ref))
+	require.NoError(t, os.WriteFile(contextPath, []byte(importTestContext), 0644))
+	err = ImportECDSAKeys(logger.NewNoopLogger(), contextPath, keystoreDir, "other", ref)
+	assert.ErrorContains(t, err, "already exists")
+}
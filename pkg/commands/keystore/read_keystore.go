@@ -2,16 +2,21 @@ package keystore
 
 import (
 	"fmt"
+	"log"
+	"os"
+
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/signing/bn254"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/signing/keystore"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
-	"log"
 )
 
 var ReadCommand = &cli.Command{
 	Name:  "read",
-	Usage: "Print the Bls key from a given keystore file, password",
+	Usage: "Print the Bls or ECDSA key from a given keystore file, password",
 	Flags: append([]cli.Flag{
 		&cli.StringFlag{
 			Name:     "path",
@@ -28,22 +33,51 @@ var ReadCommand = &cli.Command{
 		path := cCtx.String("path")
 		password := cCtx.String("password")
 
-		scheme := bn254.NewScheme()
-		keystoreData, err := keystore.LoadKeystoreFile(path)
-
+		keyJSON, err := os.ReadFile(path)
 		if err != nil {
 			return fmt.Errorf("failed to load the keystore file from given path %s", path)
 		}
-
-		privateKeyData, err := keystoreData.GetPrivateKey(password, scheme)
+		keyType, err := DetectKeyType(keyJSON)
 		if err != nil {
-			return fmt.Errorf("failed to extract the private key from the keystore file")
+			return fmt.Errorf("failed to read keystore %s: %w", path, err)
+		}
+		if keyType == KeyTypeECDSA {
+			return readECDSAKeystore(keyJSON, password)
 		}
-		log.Println("✅ Keystore generated successfully")
-		log.Println("")
-		log.Println("🔑 Save this BLS private key in a secure location:")
-		log.Printf("    %s\n", privateKeyData.Bytes())
-		log.Println("")
-		return nil
+		return readBLSKeystore(path, password)
 	},
 }
+
+func readBLSKeystore(path, password string) error {
+	scheme := bn254.NewScheme()
+	keystoreData, err := keystore.LoadKeystoreFile(path)
+
+	if err != nil {
+		return fmt.Errorf("failed to load the keystore file from given path %s", path)
+	}
+
+	privateKeyData, err := keystoreData.GetPrivateKey(password, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to extract the private key from the keystore file")
+	}
+	log.Println("✅ Keystore generated successfully")
+	log.Println("")
+	log.Println("🔑 Save this BLS private key in a secure location:")
+	log.Printf("    %s\n", privateKeyData.Bytes())
+	log.Println("")
+	return nil
+}
+
+func readECDSAKeystore(keyJSON []byte, password string) error {
+	key, err := ethkeystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return fmt.Errorf("failed to extract the private key from the keystore file: %w", err)
+	}
+	log.Println("✅ ECDSA keystore decrypted successfully")
+	log.Println("")
+	log.Printf("📬 Address: %s\n", key.Address.Hex())
+	log.Println("🔑 Save this ECDSA private key in a secure location:")
+	log.Printf("    %s\n", hexutil.Encode(crypto.FromECDSA(key.PrivateKey)))
+	log.Println("")
+	return nil
+}
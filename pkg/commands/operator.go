@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// operatorFlags are shared by every operator subcommand
+var operatorFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:     "operator",
+		Usage:    "Address of the operator; its ecdsa_key is taken from the context",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:  "context",
+		Usage: "Context whose L1 chain, EigenLayer addresses and operators are used",
+		Value: devnet.CONTEXT,
+	},
+}, common.DryRunFlags...)
+
+// OperatorCommand defines the "operator" command
+var OperatorCommand = &cli.Command{
+	Name:  "operator",
+	Usage: "Manage the lifecycle of the context's operators",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "deregister",
+			Usage: "Deregister the operator from the AVS's operator sets and drop their operator_registrations",
+			Flags: append(append([]cli.Flag{
+				&cli.IntSliceFlag{
+					Name:     "operator-set",
+					Usage:    "ID of an operator set to deregister from; repeat for several",
+					Required: true,
+				},
+			}, operatorFlags...), common.GlobalFlags...),
+			Action: OperatorDeregisterAction,
+		},
+		{
+			Name:  "update-metadata",
+			Usage: "Emit a new metadata URI for the operator and record it in the context",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "uri",
+					Usage:    "The operator's new metadata URI",
+					Required: true,
+				},
+			}, operatorFlags...), common.GlobalFlags...),
+			Action: OperatorUpdateMetadataAction,
+		},
+		{
+			Name:  "undelegate",
+			Usage: "Undelegate a staker from the operator, queueing withdrawals of all its delegated shares",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "staker",
+					Usage:    "Address of the staker delegated to the operator",
+					Required: true,
+				},
+			}, operatorFlags...), common.GlobalFlags...),
+			Action: OperatorUndelegateAction,
+		},
+	},
+}
+
+func OperatorDeregisterAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	operatorSetIDs, err := parseOperatorSetIDs(cCtx.IntSlice("operator-set"))
+	if err != nil {
+		return err
+	}
+
+	contextName := cCtx.String("context")
+	op, err := newOperatorCaller(contextName, cCtx.String("operator"), logger)
+	if err != nil {
+		return err
+	}
+	defer op.client.Close()
+
+	if !ethcommon.IsHexAddress(op.envCtx.Avs.Address) {
+		return fmt.Errorf("avs.address is not set in context '%s'", contextName)
+	}
+	avsAddress := ethcommon.HexToAddress(op.envCtx.Avs.Address)
+
+	if err := op.caller.DeregisterFromOperatorSets(cCtx.Context, op.address, avsAddress, operatorSetIDs); err != nil {
+		return fmt.Errorf("failed to deregister operator %s: %w", op.address.Hex(), err)
+	}
+
+	removed := 0
+	if err := updateContextYAML(cCtx.Context, contextName, func(contextNode *yaml.Node) error {
+		removed = common.RemoveOperatorRegistrations(contextNode, op.address.Hex(), operatorSetIDs)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("Operator %s deregistered from operator sets %v of AVS %s", op.address.Hex(), operatorSetIDs, avsAddress.Hex())
+	logger.Info("Removed %d operator registration(s) from context '%s'", removed, contextName)
+	return nil
+}
+
+func OperatorUpdateMetadataAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	uri := cCtx.String("uri")
+	if uri == "" {
+		return fmt.Errorf("--uri cannot be empty")
+	}
+
+	contextName := cCtx.String("context")
+	op, err := newOperatorCaller(contextName, cCtx.String("operator"), logger)
+	if err != nil {
+		return err
+	}
+	defer op.client.Close()
+
+	// The new URI is recorded on the operator's address entry; check it exists before changing anything on chain
+	if !hasOperatorAddressEntry(op.envCtx, op.address) {
+		return fmt.Errorf("operator %s has no address entry in context '%s'", op.address.Hex(), contextName)
+	}
+
+	isOperator, err := op.caller.IsOperator(cCtx.Context, op.address)
+	if err != nil {
+		return fmt.Errorf("failed to check whether %s is an operator: %w", op.address.Hex(), err)
+	}
+	if !isOperator {
+		return fmt.Errorf("%s is not registered as an operator", op.address.Hex())
+	}
+
+	if err := op.caller.UpdateOperatorMetadataURI(cCtx.Context, op.address, uri); err != nil {
+		return fmt.Errorf("failed to update metadata URI of operator %s: %w", op.address.Hex(), err)
+	}
+
+	if err := updateContextYAML(cCtx.Context, contextName, func(contextNode *yaml.Node) error {
+		if !common.SetOperatorMetadataURI(contextNode, op.address.Hex(), uri) {
+			return fmt.Errorf("operator %s has no address entry in context '%s'", op.address.Hex(), contextName)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("Operator %s metadata URI updated to %s", op.address.Hex(), uri)
+	return nil
+}
+
+func OperatorUndelegateAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	stakerFlag := cCtx.String("staker")
+	if !ethcommon.IsHexAddress(stakerFlag) {
+		return fmt.Errorf("invalid staker address %q", stakerFlag)
+	}
+	staker := ethcommon.HexToAddress(stakerFlag)
+
+	// The DelegationManager reverts with OperatorsCannotUndelegate; catch it before sending
+	if staker == ethcommon.HexToAddress(cCtx.String("operator")) {
+		return fmt.Errorf("operators cannot undelegate from themselves; use `devkit avs operator deregister` to leave operator sets")
+	}
+
+	contextName := cCtx.String("context")
+	op, err := newOperatorCaller(contextName, cCtx.String("operator"), logger)
+	if err != nil {
+		return err
+	}
+	defer op.client.Close()
+
+	delegatedTo, err := op.caller.GetDelegatedTo(cCtx.Context, staker)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator %s is delegated to: %w", staker.Hex(), err)
+	}
+	if delegatedTo != op.address {
+		return fmt.Errorf("staker %s is not delegated to operator %s", staker.Hex(), op.address.Hex())
+	}
+
+	if err := op.caller.Undelegate(cCtx.Context, staker); err != nil {
+		return fmt.Errorf("failed to undelegate staker %s: %w", staker.Hex(), err)
+	}
+
+	logger.Info("Staker %s undelegated from operator %s; its withdrawals are queued", staker.Hex(), op.address.Hex())
+	return nil
+}
+
+// operatorCaller signs the operator's transactions on the context's L1 chain
+type operatorCaller struct {
+	envCtx  common.ChainContextConfig
+	address ethcommon.Address
+	client  *ethclient.Client
+	caller  *common.ContractCaller
+}
+
+// newOperatorCaller loads the context and connects a ContractCaller signing with the operator's ecdsa_key
+func newOperatorCaller(contextName, operatorAddress string, logger iface.Logger) (*operatorCaller, error) {
+	if !ethcommon.IsHexAddress(operatorAddress) {
+		return nil, fmt.Errorf("invalid operator address %q", operatorAddress)
+	}
+
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return nil, fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1Cfg, ok := envCtx.Chains[devnet.L1]
+	if !ok {
+		return nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+
+	operator, err := common.GetOperatorSpec(envCtx, operatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorSigner, err := common.OperatorSigner(*operator)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+
+	allocationManagerAddr, delegationManagerAddr := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+
+	contractCaller, err := common.NewContractCaller(
+		operatorSigner,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		logger,
+	)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to create contract caller: %w", err)
+	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
+
+	return &operatorCaller{
+		envCtx:  envCtx,
+		address: ethcommon.HexToAddress(operatorAddress),
+		client:  client,
+		caller:  contractCaller,
+	}, nil
+}
+
+// hasOperatorAddressEntry reports whether the context lists an operator under address, which is the entry
+// common.SetOperatorMetadataURI updates
+func hasOperatorAddressEntry(envCtx common.ChainContextConfig, address ethcommon.Address) bool {
+	for _, op := range envCtx.Operators {
+		if strings.EqualFold(op.Address, address.Hex()) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOperatorSetIDs checks that every --operator-set value is a valid uint32 operator set ID
+func parseOperatorSetIDs(values []int) ([]uint32, error) {
+	ids := make([]uint32, 0, len(values))
+	for _, v := range values {
+		if v < 0 || uint64(v) > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid operator set ID %d", v)
+		}
+		ids = append(ids, uint32(v))
+	}
+	return ids, nil
+}
+
+// updateContextYAML applies update to the context mapping of config/contexts/<contextName>.yaml and writes it back,
+// unless ctx belongs to a dry run
+func updateContextYAML(ctx context.Context, contextName string, update func(contextNode *yaml.Node) error) error {
+	contextPath := filepath.Join(common.DefaultConfigWithContextConfigPath, "contexts", contextName+".yaml")
+	rootNode, err := common.LoadYAML(contextPath)
+	if err != nil {
+		return fmt.Errorf("failed to read context %q: %w", contextName, err)
+	}
+	if len(rootNode.Content) == 0 {
+		return fmt.Errorf("empty YAML root node in %s", contextPath)
+	}
+	contextNode := common.GetChildByKey(rootNode.Content[0], "context")
+	if contextNode == nil {
+		return fmt.Errorf("missing 'context' key in %s", contextPath)
+	}
+	if err := update(contextNode); err != nil {
+		return err
+	}
+	if common.IsDryRun(ctx) {
+		common.LoggerFromContext(ctx).Info("Dry run: context '%s' is left unchanged", contextName)
+		return nil
+	}
+	if err := common.WriteYAML(contextPath, rootNode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", contextPath, err)
+	}
+	return nil
+}
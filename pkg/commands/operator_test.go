@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+func setupOperatorApp(t *testing.T) *cli.App {
+	tmpDir, err := testutils.CreateTempAVSProject(t)
+	require.NoError(t, err)
+
+	oldWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWD)
+		os.RemoveAll(tmpDir)
+	})
+
+	cmdWithLogger, _ := testutils.WithTestConfigAndNoopLoggerAndAccess(OperatorCommand)
+	return &cli.App{
+		Name:     "devkit",
+		Commands: []*cli.Command{cmdWithLogger},
+	}
+}
+
+func TestOperatorCommands_RejectInvalidInput(t *testing.T) {
+	app := setupOperatorApp(t)
+	const operator = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "negative operator set",
+			args:    []string{"deregister", "--operator", operator, "--operator-set", "-1"},
+			wantErr: "invalid operator set ID -1",
+		},
+		{
+			name:    "operator not in context",
+			args:    []string{"deregister", "--operator", "0x0000000000000000000000000000000000000001", "--operator-set", "0"},
+			wantErr: "not found in config",
+		},
+		{
+			name:    "invalid operator address",
+			args:    []string{"update-metadata", "--operator", "0x123", "--uri", "https://op.example"},
+			wantErr: "invalid operator address",
+		},
+		{
+			name:    "operator undelegating itself",
+			args:    []string{"undelegate", "--operator", operator, "--staker", operator},
+			wantErr: "operators cannot undelegate",
+		},
+		{
+			name:    "unknown context",
+			args:    []string{"undelegate", "--operator", operator, "--staker", "0x0000000000000000000000000000000000000001", "--context", "holesky"},
+			wantErr: "failed to load configurations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.Run(append([]string{"devkit", "operator"}, tt.args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseOperatorSetIDs(t *testing.T) {
+	ids, err := parseOperatorSetIDs([]int{0, 3, 4294967295})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0, 3, 4294967295}, ids)
+
+	_, err = parseOperatorSetIDs([]int{4294967296})
+	assert.Error(t, err)
+}
+
+func TestUpdateContextYAML_DryRunLeavesContextUnchanged(t *testing.T) {
+	setupOperatorApp(t)
+	contextPath := filepath.Join("config", "contexts", "devnet.yaml")
+	before, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+
+	setName := func(contextNode *yaml.Node) error {
+		common.SetMappingValue(contextNode, &yaml.Node{Kind: yaml.ScalarNode, Value: "name"}, &yaml.Node{Kind: yaml.ScalarNode, Value: "renamed"})
+		return nil
+	}
+
+	dryRunCtx := common.WithDryRun(context.Background(), common.NewDryRunReport())
+	require.NoError(t, updateContextYAML(dryRunCtx, "devnet", setName))
+	after, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	require.NoError(t, updateContextYAML(context.Background(), "devnet", setName))
+	after, err = os.ReadFile(contextPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(after), "name: renamed")
+}
+
+func TestOperatorUpdateMetadata_RequiresAddressEntryBeforeSending(t *testing.T) {
+	app := setupOperatorApp(t)
+	const operator = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+	// The operator's ecdsa_key still resolves it, but its address entry no longer matches; the chain is
+	// unreachable, so reaching the transaction would fail with a connection error instead
+	contextPath := filepath.Join("config", "contexts", "devnet.yaml")
+	data, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+	contextYAML := strings.ReplaceAll(string(data), "http://localhost:8545", "http://127.0.0.1:1")
+	contextYAML = strings.Replace(contextYAML, `address: "`+operator+`"`, `address: "0x0000000000000000000000000000000000000001"`, 1)
+	require.NoError(t, os.WriteFile(contextPath, []byte(contextYAML), 0o644))
+
+	err = app.Run([]string{"devkit", "operator", "update-metadata", "--operator", operator, "--uri", "https://op.example"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no address entry in context 'devnet'")
+}
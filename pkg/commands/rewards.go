@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// RewardsCommand defines the "rewards" command
+var RewardsCommand = &cli.Command{
+	Name:  "rewards",
+	Usage: "Submit AVS rewards to the EigenLayer RewardsCoordinator",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "submit",
+			Usage: "Send the rewards submissions described in a YAML or JSON spec from the AVS",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "spec",
+					Usage:    "Path to the rewards spec file",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Context whose L1 chain, AVS key and RewardsCoordinator are used",
+					Value: devnet.CONTEXT,
+				},
+			}, common.DryRunFlags...), common.GlobalFlags...),
+			Action: RewardsSubmitAction,
+		},
+	},
+}
+
+func RewardsSubmitAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+	ctx := cCtx.Context
+
+	spec, err := devnet.LoadRewardsSpec(cCtx.String("spec"))
+	if err != nil {
+		return err
+	}
+
+	contextName := cCtx.String("context")
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1Cfg, ok := envCtx.Chains[devnet.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+
+	// Submissions are made by the AVS itself, so its signer has to sign for avs.address
+	avsSigner, err := common.AVSSigner(envCtx.Avs)
+	if err != nil {
+		return fmt.Errorf("context '%s': %w", contextName, err)
+	}
+	if avsSigner == nil {
+		return fmt.Errorf("context '%s' has neither an avs_private_key nor an avs_signer", contextName)
+	}
+	avsAddr := avsSigner.Address()
+	if !strings.EqualFold(avsAddr.Hex(), envCtx.Avs.Address) {
+		return fmt.Errorf("the AVS signer in context '%s' signs for %s, not avs.address %s", contextName, avsAddr.Hex(), envCtx.Avs.Address)
+	}
+
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	allocationManagerAddr, delegationManagerAddr := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	contractCaller, err := common.NewContractCaller(
+		avsSigner,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contract caller: %w", err)
+	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
+
+	rewardsCoordinatorAddr := ethcommon.HexToAddress(devnet.GetContextRewardsCoordinatorAddress(cfg, contextName))
+	params, err := contractCaller.GetRewardsCoordinatorParams(ctx, rewardsCoordinatorAddr)
+	if err != nil {
+		return fmt.Errorf("failed to read RewardsCoordinator %s: %w", rewardsCoordinatorAddr.Hex(), err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	submissions, err := spec.Build(*params, head.Time, func(token ethcommon.Address) (uint8, error) {
+		return contractCaller.GetERC20Decimals(ctx, token)
+	})
+	if err != nil {
+		return err
+	}
+
+	// On a devnet the AVS is dealt the reward tokens on the fork; elsewhere it has to hold them already
+	totals := submissions.TokenTotals()
+	tokens := make([]ethcommon.Address, 0, len(totals))
+	for token := range totals {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return bytes.Compare(tokens[i].Bytes(), tokens[j].Bytes()) < 0 })
+
+	anvil, err := devnet.DialAnvil(ctx, l1Cfg.RPCURL)
+	if err != nil {
+		return err
+	}
+	isDevnet := anvil.IsAnvil(ctx)
+	anvil.Close()
+
+	for _, token := range tokens {
+		if isDevnet && common.IsDryRun(ctx) {
+			logger.Info("Dry run: not dealing %s of %s to the AVS on the devnet", totals[token], token.Hex())
+		} else if isDevnet {
+			// Deal on top of what the AVS already holds so tokens it has set aside elsewhere are kept
+			balance, err := contractCaller.GetERC20Balance(ctx, token, avsAddr)
+			if err != nil {
+				return err
+			}
+			if err := devnet.DealERC20(ctx, l1Cfg.RPCURL, token, avsAddr, new(big.Int).Add(balance, totals[token])); err != nil {
+				return fmt.Errorf("failed to obtain reward token %s on the devnet: %w", token.Hex(), err)
+			}
+		}
+		logger.Info("Approving RewardsCoordinator %s to transfer %s of %s from the AVS", rewardsCoordinatorAddr.Hex(), totals[token], token.Hex())
+		if err := contractCaller.ApproveERC20(ctx, token, rewardsCoordinatorAddr, totals[token]); err != nil {
+			return fmt.Errorf("failed to approve reward token %s: %w", token.Hex(), err)
+		}
+	}
+
+	if len(submissions.AVS) > 0 {
+		if err := contractCaller.CreateAVSRewardsSubmission(ctx, rewardsCoordinatorAddr, submissions.AVS); err != nil {
+			return fmt.Errorf("failed to create AVS rewards submission: %w", err)
+		}
+		for _, sub := range submissions.AVS {
+			logger.Info("Submitted %s of %s to the AVS's stakers for %ds from %d", sub.Amount, sub.Token.Hex(), sub.Duration, sub.StartTimestamp)
+		}
+	}
+
+	// Operator-directed submissions go out in one transaction for the AVS and one per operator set
+	avsWide := []rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission{}
+	bySet := map[uint32][]rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission{}
+	setIDs := []uint32{}
+	for _, sub := range submissions.OperatorDirected {
+		if sub.OperatorSetID == nil {
+			avsWide = append(avsWide, sub.Submission)
+			continue
+		}
+		if _, seen := bySet[*sub.OperatorSetID]; !seen {
+			setIDs = append(setIDs, *sub.OperatorSetID)
+		}
+		bySet[*sub.OperatorSetID] = append(bySet[*sub.OperatorSetID], sub.Submission)
+	}
+	if len(avsWide) > 0 {
+		if err := contractCaller.CreateOperatorDirectedAVSRewardsSubmission(ctx, rewardsCoordinatorAddr, avsAddr, avsWide); err != nil {
+			return fmt.Errorf("failed to create operator-directed rewards submission: %w", err)
+		}
+		logger.Info("Submitted %d operator-directed rewards submission(s) for the AVS", len(avsWide))
+	}
+	sort.Slice(setIDs, func(i, j int) bool { return setIDs[i] < setIDs[j] })
+	for _, setID := range setIDs {
+		if err := contractCaller.CreateOperatorDirectedOperatorSetRewardsSubmission(ctx, rewardsCoordinatorAddr, avsAddr, setID, bySet[setID]); err != nil {
+			return fmt.Errorf("failed to create operator-directed rewards submission for operator set %d: %w", setID, err)
+		}
+		logger.Info("Submitted %d operator-directed rewards submission(s) for operator set %d", len(bySet[setID]), setID)
+	}
+
+	logger.Info("Rewards submitted to RewardsCoordinator %s", rewardsCoordinatorAddr.Hex())
+	return nil
+}
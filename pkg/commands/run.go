@@ -1,11 +1,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"path"
 	"path/filepath"
 
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
 
 	"github.com/urfave/cli/v2"
 )
@@ -14,7 +18,12 @@ import (
 var RunCommand = &cli.Command{
 	Name:  "run",
 	Usage: "Start offchain AVS components",
-	Flags: append([]cli.Flag{}, common.GlobalFlags...),
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "capture-logs",
+			Usage: "Capture the run script's output in .devkit/logs: run.log for all of it and <component>.log for lines tagged `[component]` or `component |`, readable with `devnet logs <component>`",
+		},
+	}, common.GlobalFlags...),
 	Action: func(cCtx *cli.Context) error {
 		// Invoke and return AVSRun
 		return AVSRun(cCtx)
@@ -28,6 +37,19 @@ func AVSRun(cCtx *cli.Context) error {
 	// Print task if verbose
 	logger.Debug("Starting offchain AVS components...")
 
+	if err := runAVSComponents(cCtx.Context, logger, cCtx.Bool("capture-logs"), common.ScriptOptions{}); err != nil {
+		return err
+	}
+
+	logger.Info("Offchain AVS components started successfully!")
+
+	return nil
+}
+
+// runAVSComponents runs the template's run script until it exits or ctx is canceled. opts.Output and
+// opts.Stderr are passed through to the script; captureLogs additionally writes its output to .devkit/logs, see
+// devnet.ComponentLogWriter.
+func runAVSComponents(ctx context.Context, logger iface.Logger, captureLogs bool, opts common.ScriptOptions) error {
 	// Run the script from root of project dir
 	// (@TODO (GD): this should always be the root of the project, but we need to do this everywhere (ie reading ctx/config etc))
 	const dir = ""
@@ -43,12 +65,25 @@ func AVSRun(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to load context: %w", err)
 	}
 
+	// Optionally capture the script's output: all of it in run.log and each component's tagged lines in their own log
+	if captureLogs {
+		logs, err := devnet.NewComponentLogWriter(devnet.GetLogsDir())
+		if err != nil {
+			return fmt.Errorf("failed to capture logs: %w", err)
+		}
+		defer logs.Close()
+
+		if opts.Output != nil {
+			opts.Output = io.MultiWriter(logs, opts.Output)
+		} else {
+			opts.Output = logs
+		}
+		logger.Info("Capturing the run script's output in %s, split into <component>.log by each line's [component] prefix", devnet.GetLogsDir())
+	}
+
 	// Run init on the template init script
-	if _, err := common.CallTemplateScript(cCtx.Context, logger, dir, scriptPath, common.ExpectNonJSONResponse, contextJSON); err != nil {
+	if _, err := common.CallTemplateScriptWithOptions(ctx, logger, dir, scriptPath, common.ExpectNonJSONResponse, opts, contextJSON); err != nil {
 		return fmt.Errorf("run failed: %w", err)
 	}
-
-	logger.Info("Offchain AVS components started successfully!")
-
 	return nil
 }
@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// SlashCommand defines the "slash" command
+var SlashCommand = &cli.Command{
+	Name:  "slash",
+	Usage: "Slash an operator's allocation to one of the AVS's operator sets",
+	Flags: append(append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "operator",
+			Usage:    "Address of the operator to slash",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:     "operator-set",
+			Usage:    "ID of the AVS operator set the operator is slashed through",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "strategies",
+			Usage: "Strategies to slash; defaults to every strategy in the operator set",
+		},
+		&cli.StringFlag{
+			Name:     "wad",
+			Usage:    "Fraction of the allocation to slash, e.g. 0.25 or 25%",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "description",
+			Usage: "Reason recorded in the OperatorSlashed event",
+			Value: "devkit slashing simulation",
+		},
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "Context whose L1 chain, AVS and EigenLayer addresses are used",
+			Value: devnet.CONTEXT,
+		},
+	}, common.DryRunFlags...), common.GlobalFlags...),
+	Action: SlashAction,
+}
+
+func SlashAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+	ctx := cCtx.Context
+
+	operatorFlag := cCtx.String("operator")
+	if !ethcommon.IsHexAddress(operatorFlag) {
+		return fmt.Errorf("invalid operator address %q", operatorFlag)
+	}
+	operator := ethcommon.HexToAddress(operatorFlag)
+	operatorSetIDs, err := parseOperatorSetIDs([]int{cCtx.Int("operator-set")})
+	if err != nil {
+		return err
+	}
+	operatorSetID := operatorSetIDs[0]
+	wad, err := common.ParseWadToSlash(cCtx.String("wad"))
+	if err != nil {
+		return err
+	}
+	requested, err := parseStrategyAddresses(cCtx.StringSlice("strategies"))
+	if err != nil {
+		return err
+	}
+
+	contextName := cCtx.String("context")
+	cfg, err := common.LoadConfigWithContextConfig(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1Cfg, ok := envCtx.Chains[devnet.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	if !ethcommon.IsHexAddress(envCtx.Avs.Address) {
+		return fmt.Errorf("avs.address is not set in context '%s'", contextName)
+	}
+	avsAddress := ethcommon.HexToAddress(envCtx.Avs.Address)
+
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	// Sign as the AVS when the context holds its key or a signer for it; on anvil the AVS can be impersonated
+	// instead, which also covers AVSs whose address is a contract. A dry run needs neither, as it only
+	// simulates the call from the AVS.
+	contextSigner, err := common.AVSSigner(envCtx.Avs)
+	if err != nil {
+		return fmt.Errorf("context '%s': %w", contextName, err)
+	}
+	var avsSigner common.Signer
+	if contextSigner != nil && contextSigner.Address() == avsAddress {
+		avsSigner = contextSigner
+	}
+	var anvil *devnet.AnvilClient
+	if avsSigner == nil && !common.IsDryRun(ctx) {
+		anvil, err = devnet.DialAnvil(ctx, l1Cfg.RPCURL)
+		if err != nil {
+			return err
+		}
+		defer anvil.Close()
+		if !anvil.IsAnvil(ctx) {
+			return fmt.Errorf("context '%s' has no key or signer for avs.address %s, and the chain is not anvil so the AVS cannot be impersonated", contextName, avsAddress.Hex())
+		}
+	}
+
+	allocationManagerAddr, delegationManagerAddr := devnet.GetContextEigenLayerAddresses(cfg, contextName)
+	contractCaller, err := common.NewContractCaller(
+		avsSigner,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contract caller: %w", err)
+	}
+	contractCaller.UseContractOutputs(getContractOutputsDir(contextName))
+
+	exists, err := contractCaller.IsOperatorSet(ctx, avsAddress, operatorSetID)
+	if err != nil {
+		return fmt.Errorf("failed to check operator set %d: %w", operatorSetID, err)
+	}
+	if !exists {
+		return fmt.Errorf("operator set %d does not exist for AVS %s", operatorSetID, avsAddress.Hex())
+	}
+	slashable, err := contractCaller.IsOperatorSlashable(ctx, operator, avsAddress, operatorSetID)
+	if err != nil {
+		return fmt.Errorf("failed to check whether %s is slashable: %w", operator.Hex(), err)
+	}
+	if !slashable {
+		return fmt.Errorf("operator %s is not slashable by operator set %d of AVS %s", operator.Hex(), operatorSetID, avsAddress.Hex())
+	}
+
+	inSet, err := contractCaller.GetStrategiesInOperatorSet(ctx, avsAddress, operatorSetID)
+	if err != nil {
+		return fmt.Errorf("failed to get strategies in operator set %d: %w", operatorSetID, err)
+	}
+	strategies, err := selectSlashStrategies(requested, inSet)
+	if err != nil {
+		return fmt.Errorf("operator set %d: %w", operatorSetID, err)
+	}
+
+	before, err := readMagnitudes(ctx, contractCaller, operator, avsAddress, operatorSetID, strategies)
+	if err != nil {
+		return err
+	}
+
+	params := allocationmanager.IAllocationManagerTypesSlashingParams{
+		Operator:      operator,
+		OperatorSetId: operatorSetID,
+		Strategies:    strategies,
+		WadsToSlash:   make([]*big.Int, len(strategies)),
+		Description:   cCtx.String("description"),
+	}
+	for i := range strategies {
+		params.WadsToSlash[i] = wad
+	}
+
+	logger.Info("Slashing %s%% of operator %s's allocation to operator set %d of AVS %s", formatWadPercent(wad), operator.Hex(), operatorSetID, avsAddress.Hex())
+	if common.IsDryRun(ctx) {
+		data, err := common.PackSlashOperator(avsAddress, params)
+		if err != nil {
+			return fmt.Errorf("failed to encode slashOperator call: %w", err)
+		}
+		allocationManager := ethcommon.HexToAddress(allocationManagerAddr)
+		return contractCaller.SimulateTransaction(ctx, fmt.Sprintf("SlashOperator for %s", operator.Hex()), ethereum.CallMsg{
+			From: avsAddress,
+			To:   &allocationManager,
+			Data: data,
+		})
+	}
+
+	var txHash ethcommon.Hash
+	if avsSigner != nil {
+		txHash, err = contractCaller.SlashOperator(ctx, avsAddress, params)
+		if err != nil {
+			return fmt.Errorf("failed to slash operator %s: %w", operator.Hex(), err)
+		}
+	} else {
+		logger.Info("Impersonating AVS %s on anvil", avsAddress.Hex())
+		txHash, err = slashAsImpersonatedAVS(ctx, client, anvil, contractCaller, ethcommon.HexToAddress(allocationManagerAddr), avsAddress, params)
+		if err != nil {
+			return fmt.Errorf("failed to slash operator %s: %w", operator.Hex(), err)
+		}
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt of slash transaction %s: %w", txHash.Hex(), err)
+	}
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block %s: %w", receipt.BlockNumber, err)
+	}
+
+	after, err := readMagnitudes(ctx, contractCaller, operator, avsAddress, operatorSetID, strategies)
+	if err != nil {
+		return err
+	}
+
+	record := common.SlashingRecord{
+		Operator:      operator.Hex(),
+		OperatorSetID: operatorSetID,
+		Description:   params.Description,
+		TxHash:        txHash.Hex(),
+		BlockNumber:   receipt.BlockNumber.Uint64(),
+		Timestamp:     time.Unix(int64(header.Time), 0).UTC().Format(time.RFC3339),
+	}
+	for i, strategy := range strategies {
+		record.Strategies = append(record.Strategies, common.SlashedStrategy{
+			Strategy:                 strategy.Hex(),
+			WadToSlash:               wad.String(),
+			AllocatedMagnitudeBefore: before.allocated[i],
+			AllocatedMagnitudeAfter:  after.allocated[i],
+			MaxMagnitudeBefore:       before.max[i],
+			MaxMagnitudeAfter:        after.max[i],
+		})
+	}
+	printSlashedMagnitudes(logger, record)
+
+	if err := updateContextYAML(ctx, contextName, func(contextNode *yaml.Node) error {
+		return common.AppendSlashingRecord(contextNode, record)
+	}); err != nil {
+		return err
+	}
+	logger.Info("Slash recorded in slashing_history of context '%s' (tx %s)", contextName, txHash.Hex())
+	return nil
+}
+
+// parseStrategyAddresses parses --strategies values, which may each hold a comma-separated list
+func parseStrategyAddresses(values []string) ([]ethcommon.Address, error) {
+	strategies := []ethcommon.Address{}
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			if !ethcommon.IsHexAddress(s) {
+				return nil, fmt.Errorf("invalid strategy address %q", s)
+			}
+			strategies = append(strategies, ethcommon.HexToAddress(s))
+		}
+	}
+	return strategies, nil
+}
+
+// selectSlashStrategies returns the requested strategies, or all of inSet without any, sorted ascending and
+// without duplicates as slashOperator requires. Every strategy must belong to the operator set.
+func selectSlashStrategies(requested, inSet []ethcommon.Address) ([]ethcommon.Address, error) {
+	members := make(map[ethcommon.Address]bool, len(inSet))
+	for _, strategy := range inSet {
+		members[strategy] = true
+	}
+
+	selected := requested
+	if len(selected) == 0 {
+		selected = inSet
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no strategies to slash: the operator set has none")
+	}
+
+	unique := map[ethcommon.Address]bool{}
+	strategies := []ethcommon.Address{}
+	for _, strategy := range selected {
+		if !members[strategy] {
+			return nil, fmt.Errorf("strategy %s is not in the operator set", strategy.Hex())
+		}
+		if !unique[strategy] {
+			unique[strategy] = true
+			strategies = append(strategies, strategy)
+		}
+	}
+	sort.Slice(strategies, func(i, j int) bool { return bytes.Compare(strategies[i].Bytes(), strategies[j].Bytes()) < 0 })
+	return strategies, nil
+}
+
+// operatorMagnitudes holds an operator's magnitudes per strategy, in the order they were read
+type operatorMagnitudes struct {
+	allocated []uint64
+	max       []uint64
+}
+
+// readMagnitudes reads the operator's magnitude allocated to the operator set and max magnitude for each strategy
+func readMagnitudes(ctx context.Context, caller *common.ContractCaller, operator, avsAddress ethcommon.Address, operatorSetID uint32, strategies []ethcommon.Address) (*operatorMagnitudes, error) {
+	magnitudes := &operatorMagnitudes{allocated: make([]uint64, len(strategies))}
+	for i, strategy := range strategies {
+		allocated, err := caller.GetAllocatedMagnitude(ctx, operator, avsAddress, operatorSetID, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocated magnitude of %s for strategy %s: %w", operator.Hex(), strategy.Hex(), err)
+		}
+		magnitudes.allocated[i] = allocated
+	}
+	maxMagnitudes, err := caller.GetMaxMagnitudes(ctx, operator, strategies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max magnitudes of %s: %w", operator.Hex(), err)
+	}
+	magnitudes.max = maxMagnitudes
+	return magnitudes, nil
+}
+
+// slashAsImpersonatedAVS sends slashOperator from the AVS address through anvil and waits for it to be mined
+func slashAsImpersonatedAVS(ctx context.Context, client *ethclient.Client, anvil *devnet.AnvilClient, caller *common.ContractCaller, allocationManager, avsAddress ethcommon.Address, params allocationmanager.IAllocationManagerTypesSlashingParams) (ethcommon.Hash, error) {
+	data, err := common.PackSlashOperator(avsAddress, params)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	// An AVS contract may hold no ether to pay for gas
+	balance, err := client.BalanceAt(ctx, avsAddress, nil)
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to get balance of %s: %w", avsAddress.Hex(), err)
+	}
+	if balance.Sign() == 0 {
+		if err := anvil.SetBalance(ctx, avsAddress, new(big.Int).Set(common.WAD)); err != nil {
+			return ethcommon.Hash{}, err
+		}
+	}
+
+	txHash, err := anvil.SendAs(ctx, avsAddress, allocationManager, data)
+	if err != nil {
+		return ethcommon.Hash{}, caller.DecodeRevertError(err)
+	}
+	receipt, err := bind.WaitMinedHash(ctx, client, txHash)
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("waiting for SlashOperator transaction (hash: %s): %w", txHash.Hex(), err)
+	}
+	if receipt.Status == 0 {
+		return ethcommon.Hash{}, fmt.Errorf("SlashOperator transaction (hash: %s) reverted", txHash.Hex())
+	}
+	return txHash, nil
+}
+
+// formatWadPercent renders a WAD amount as a percentage, e.g. 2.5e17 as "25"
+func formatWadPercent(wad *big.Int) string {
+	percent := new(big.Rat).SetFrac(new(big.Int).Mul(wad, big.NewInt(100)), common.WAD)
+	return strings.TrimSuffix(strings.TrimRight(percent.FloatString(4), "0"), ".")
+}
+
+// printSlashedMagnitudes logs each slashed strategy's magnitudes before and after the slash
+func printSlashedMagnitudes(logger iface.Logger, record common.SlashingRecord) {
+	logger.Title("Operator magnitudes")
+	for _, s := range record.Strategies {
+		logger.Info("  %s  allocated %d → %d  max %d → %d", s.Strategy, s.AllocatedMagnitudeBefore, s.AllocatedMagnitudeAfter, s.MaxMagnitudeBefore, s.MaxMagnitudeAfter)
+	}
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSlashCommand_RejectsInvalidInput(t *testing.T) {
+	tmpDir, err := testutils.CreateTempAVSProject(t)
+	require.NoError(t, err)
+	oldWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWD)
+		os.RemoveAll(tmpDir)
+	})
+
+	cmdWithLogger, _ := testutils.WithTestConfigAndNoopLoggerAndAccess(SlashCommand)
+	app := &cli.App{Name: "devkit", Commands: []*cli.Command{cmdWithLogger}}
+	const operator = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "invalid operator",
+			args:    []string{"--operator", "0x123", "--operator-set", "0", "--wad", "0.1"},
+			wantErr: "invalid operator address",
+		},
+		{
+			name:    "negative operator set",
+			args:    []string{"--operator", operator, "--operator-set", "-1", "--wad", "0.1"},
+			wantErr: "invalid operator set ID -1",
+		},
+		{
+			name:    "wad above one",
+			args:    []string{"--operator", operator, "--operator-set", "0", "--wad", "150%"},
+			wantErr: "at most 1",
+		},
+		{
+			name:    "invalid strategy",
+			args:    []string{"--operator", operator, "--operator-set", "0", "--wad", "0.1", "--strategies", "steth"},
+			wantErr: "invalid strategy address",
+		},
+		{
+			name:    "unknown context",
+			args:    []string{"--operator", operator, "--operator-set", "0", "--wad", "0.1", "--context", "holesky"},
+			wantErr: "failed to load configurations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.Run(append([]string{"devkit", "slash"}, tt.args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSelectSlashStrategies(t *testing.T) {
+	a := ethcommon.HexToAddress("0x0a")
+	b := ethcommon.HexToAddress("0x0b")
+	c := ethcommon.HexToAddress("0x0c")
+	inSet := []ethcommon.Address{c, a, b}
+
+	strategies, err := selectSlashStrategies(nil, inSet)
+	require.NoError(t, err)
+	assert.Equal(t, []ethcommon.Address{a, b, c}, strategies)
+
+	strategies, err = selectSlashStrategies([]ethcommon.Address{c, a, c}, inSet)
+	require.NoError(t, err)
+	assert.Equal(t, []ethcommon.Address{a, c}, strategies)
+
+	_, err = selectSlashStrategies([]ethcommon.Address{ethcommon.HexToAddress("0x0d")}, inSet)
+	assert.ErrorContains(t, err, "is not in the operator set")
+
+	_, err = selectSlashStrategies(nil, nil)
+	assert.ErrorContains(t, err, "no strategies to slash")
+}
+
+func TestParseStrategyAddresses(t *testing.T) {
+	const (
+		a = "0x000000000000000000000000000000000000000a"
+		b = "0x000000000000000000000000000000000000000b"
+		c = "0x000000000000000000000000000000000000000c"
+	)
+	strategies, err := parseStrategyAddresses([]string{a + ", " + b, c})
+	require.NoError(t, err)
+	assert.Equal(t, []ethcommon.Address{
+		ethcommon.HexToAddress(a),
+		ethcommon.HexToAddress(b),
+		ethcommon.HexToAddress(c),
+	}, strategies)
+
+	_, err = parseStrategyAddresses([]string{a + ",0x0b"})
+	assert.ErrorContains(t, err, `invalid strategy address "0x0b"`)
+}
+
+func TestFormatWadPercent(t *testing.T) {
+	assert.Equal(t, "25", formatWadPercent(big.NewInt(250000000000000000)))
+	assert.Equal(t, "100", formatWadPercent(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)))
+	assert.Equal(t, "12.5", formatWadPercent(big.NewInt(125000000000000000)))
+}
@@ -31,26 +31,55 @@ type ForkConfig struct {
 	Url       string `json:"url" yaml:"url"`
 	Block     int    `json:"block" yaml:"block"`
 	BlockTime int    `json:"block_time" yaml:"block_time"`
+	Preset    string `json:"preset,omitempty" yaml:"preset,omitempty"`
+}
+
+// SignerConfig references a key held outside the context, in place of a plaintext private key
+type SignerConfig struct {
+	// Type is "keystore" or "web3signer"
+	Type string `json:"type" yaml:"type"`
+	// Path is the Web3 Secret Storage file of a keystore signer
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Password decrypts the keystore; PasswordEnv names an environment variable holding it instead
+	Password    string `json:"password,omitempty" yaml:"password,omitempty"`
+	PasswordEnv string `json:"password_env,omitempty" yaml:"password_env,omitempty"`
+	// URL is the JSON-RPC endpoint of a web3signer signer
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Address is the account a web3signer signer signs for; defaults to the operator or AVS address. A keystore
+	// signer may set it too, otherwise its address is read from the keystore file.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
 }
 
 type OperatorSpec struct {
-	Address             string `json:"address" yaml:"address"`
-	ECDSAKey            string `json:"ecdsa_key" yaml:"ecdsa_key"`
-	BlsKeystorePath     string `json:"bls_keystore_path" yaml:"bls_keystore_path"`
-	BlsKeystorePassword string `json:"bls_keystore_password" yaml:"bls_keystore_password"`
-	Stake               string `json:"stake" yaml:"stake"`
+	Address             string        `json:"address" yaml:"address"`
+	ECDSAKey            string        `json:"ecdsa_key" yaml:"ecdsa_key"`
+	Signer              *SignerConfig `json:"signer,omitempty" yaml:"signer,omitempty"`
+	BlsKeystorePath     string        `json:"bls_keystore_path" yaml:"bls_keystore_path"`
+	BlsKeystorePassword string        `json:"bls_keystore_password" yaml:"bls_keystore_password"`
+	Stake               string        `json:"stake" yaml:"stake"`
+	MetadataURI         string        `json:"metadata_uri,omitempty" yaml:"metadata_uri,omitempty"`
 }
 
 type AvsConfig struct {
-	Address          string `json:"address" yaml:"address"`
-	MetadataUri      string `json:"metadata_url" yaml:"metadata_url"`
-	AVSPrivateKey    string `json:"avs_private_key" yaml:"avs_private_key"`
-	RegistrarAddress string `json:"registrar_address" yaml:"registrar_address"`
+	Address          string        `json:"address" yaml:"address"`
+	MetadataUri      string        `json:"metadata_url" yaml:"metadata_url"`
+	AVSPrivateKey    string        `json:"avs_private_key" yaml:"avs_private_key"`
+	AVSSigner        *SignerConfig `json:"avs_signer,omitempty" yaml:"avs_signer,omitempty"`
+	RegistrarAddress string        `json:"registrar_address" yaml:"registrar_address"`
 }
 
 type EigenLayerConfig struct {
-	AllocationManager string `json:"allocation_manager" yaml:"allocation_manager"`
-	DelegationManager string `json:"delegation_manager" yaml:"delegation_manager"`
+	AllocationManager    string `json:"allocation_manager" yaml:"allocation_manager"`
+	DelegationManager    string `json:"delegation_manager" yaml:"delegation_manager"`
+	StrategyManager      string `json:"strategy_manager,omitempty" yaml:"strategy_manager,omitempty"`
+	AVSDirectory         string `json:"avs_directory,omitempty" yaml:"avs_directory,omitempty"`
+	RewardsCoordinator   string `json:"rewards_coordinator,omitempty" yaml:"rewards_coordinator,omitempty"`
+	PermissionController string `json:"permission_controller,omitempty" yaml:"permission_controller,omitempty"`
+}
+
+type FundingConfig struct {
+	TargetBalance  string   `json:"target_balance" yaml:"target_balance"`
+	ExtraAddresses []string `json:"extra_addresses" yaml:"extra_addresses"`
 }
 
 type ChainConfig struct {
@@ -95,17 +124,41 @@ type OperatorRegistration struct {
 	Payload       string `json:"payload" yaml:"payload"`
 }
 
+type SlashedStrategy struct {
+	Strategy                 string `json:"strategy" yaml:"strategy"`
+	WadToSlash               string `json:"wad_to_slash" yaml:"wad_to_slash"`
+	AllocatedMagnitudeBefore uint64 `json:"allocated_magnitude_before" yaml:"allocated_magnitude_before"`
+	AllocatedMagnitudeAfter  uint64 `json:"allocated_magnitude_after" yaml:"allocated_magnitude_after"`
+	MaxMagnitudeBefore       uint64 `json:"max_magnitude_before" yaml:"max_magnitude_before"`
+	MaxMagnitudeAfter        uint64 `json:"max_magnitude_after" yaml:"max_magnitude_after"`
+}
+
+type SlashingRecord struct {
+	Operator      string            `json:"operator" yaml:"operator"`
+	OperatorSetID uint32            `json:"operator_set_id" yaml:"operator_set_id"`
+	Description   string            `json:"description" yaml:"description"`
+	TxHash        string            `json:"tx_hash" yaml:"tx_hash"`
+	BlockNumber   uint64            `json:"block_number" yaml:"block_number"`
+	Timestamp     string            `json:"timestamp" yaml:"timestamp"`
+	Strategies    []SlashedStrategy `json:"strategies" yaml:"strategies"`
+}
+
 type ChainContextConfig struct {
 	Name                  string                 `json:"name" yaml:"name"`
 	Chains                map[string]ChainConfig `json:"chains" yaml:"chains"`
+	DevnetBackend         string                 `json:"devnet_backend,omitempty" yaml:"devnet_backend,omitempty"`
 	DeployerPrivateKey    string                 `json:"deployer_private_key" yaml:"deployer_private_key"`
+	DeployerSigner        *SignerConfig          `json:"deployer_signer,omitempty" yaml:"deployer_signer,omitempty"`
 	AppDeployerPrivateKey string                 `json:"app_private_key" yaml:"app_private_key"`
+	AppSigner             *SignerConfig          `json:"app_signer,omitempty" yaml:"app_signer,omitempty"`
 	Operators             []OperatorSpec         `json:"operators" yaml:"operators"`
 	Avs                   AvsConfig              `json:"avs" yaml:"avs"`
 	EigenLayer            *EigenLayerConfig      `json:"eigenlayer" yaml:"eigenlayer"`
 	DeployedContracts     []DeployedContract     `json:"deployed_contracts,omitempty" yaml:"deployed_contracts,omitempty"`
 	OperatorSets          []OperatorSet          `json:"operator_sets" yaml:"operator_sets"`
 	OperatorRegistrations []OperatorRegistration `json:"operator_registrations" yaml:"operator_registrations"`
+	Funding               *FundingConfig         `json:"funding,omitempty" yaml:"funding,omitempty"`
+	SlashingHistory       []SlashingRecord       `json:"slashing_history,omitempty" yaml:"slashing_history,omitempty"`
 }
 
 func LoadBaseConfig() (map[string]interface{}, error) {
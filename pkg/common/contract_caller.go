@@ -3,21 +3,23 @@ package common
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
 	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
 	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	istrategy "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/IStrategy"
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -26,17 +28,19 @@ type ContractCaller struct {
 	allocationManager *allocationmanager.AllocationManager
 	delegationManager *delegationmanager.DelegationManager
 	ethclient         *ethclient.Client
-	privateKey        *ecdsa.PrivateKey
+	signer            Signer
 	chainID           *big.Int
 	logger            iface.Logger
-}
 
-func NewContractCaller(privateKeyHex string, chainID *big.Int, client *ethclient.Client, allocationManagerAddr, delegationManagerAddr common.Address, logger iface.Logger) (*ContractCaller, error) {
-	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
-	}
+	revertDecoder      *RevertDecoder
+	contractOutputsDir string
+	outputABIs         []*abi.ABI
+	outputsLoaded      bool
+}
 
+// NewContractCaller connects to the EigenLayer core contracts. Transactions are signed by signer; a nil signer
+// gives a read-only caller whose transactions fail before they are built.
+func NewContractCaller(signer Signer, chainID *big.Int, client *ethclient.Client, allocationManagerAddr, delegationManagerAddr common.Address, logger iface.Logger) (*ContractCaller, error) {
 	allocationManager, err := allocationmanager.NewAllocationManager(allocationManagerAddr, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create AllocationManager: %w", err)
@@ -51,16 +55,51 @@ func NewContractCaller(privateKeyHex string, chainID *big.Int, client *ethclient
 		allocationManager: allocationManager,
 		delegationManager: delegationManager,
 		ethclient:         client,
-		privateKey:        privateKey,
+		signer:            signer,
 		chainID:           chainID,
 		logger:            logger,
+		revertDecoder:     EigenLayerRevertDecoder(),
 	}, nil
 }
 
-func (cc *ContractCaller) buildTxOpts() (*bind.TransactOpts, error) {
-	opts, err := bind.NewKeyedTransactorWithChainID(cc.privateKey, cc.chainID)
+// UseContractOutputs also decodes reverts and dry-run calls against the ABIs of the project contracts in dir,
+// i.e. contracts/outputs/<context>. They are only read once a revert or dry run needs them.
+func (cc *ContractCaller) UseContractOutputs(dir string) {
+	cc.contractOutputsDir = dir
+	cc.outputABIs = nil
+	cc.outputsLoaded = false
+}
+
+// contractOutputABIs reads the ABIs of the project contracts on first use and adds their errors to the revert decoder
+func (cc *ContractCaller) contractOutputABIs() []*abi.ABI {
+	if cc.contractOutputsDir == "" || cc.outputsLoaded {
+		return cc.outputABIs
+	}
+	cc.outputsLoaded = true
+	abis, err := LoadContractOutputABIs(cc.contractOutputsDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %w", err)
+		cc.logger.Debug("Failed to load contract outputs for decoding: %v", err)
+	}
+	cc.outputABIs = abis
+	for _, parsed := range abis {
+		cc.revertDecoder.Add(parsed)
+	}
+	return cc.outputABIs
+}
+
+// buildTxOpts signs with the caller's signer. In a dry run transactions are only built, unsigned and with a
+// placeholder gas limit so bind skips its own estimation; SendAndWaitForTransaction simulates them instead.
+func (cc *ContractCaller) buildTxOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	if cc.signer == nil {
+		return nil, fmt.Errorf("contract caller is read-only: no signer configured")
+	}
+	opts := NewTransactOpts(ctx, cc.signer, cc.chainID)
+	if IsDryRun(ctx) {
+		opts.NoSend = true
+		opts.GasLimit = dryRunGasLimit
+		opts.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		}
 	}
 	return opts, nil
 }
@@ -73,24 +112,130 @@ func (cc *ContractCaller) SendAndWaitForTransaction(
 
 	tx, err := fn()
 	if err != nil {
+		// Gas estimation runs the call, so the node returns the revert data of a call that would fail
+		err = cc.DecodeRevertError(err)
 		cc.logger.Error("%s failed during execution: %v", txDescription, err)
 		return fmt.Errorf("%s execution: %w", txDescription, err)
 	}
 
+	if IsDryRun(ctx) {
+		if cc.signer == nil {
+			return fmt.Errorf("%s: contract caller is read-only: no signer configured", txDescription)
+		}
+		return cc.SimulateTransaction(ctx, txDescription, ethereum.CallMsg{
+			From:       cc.signer.Address(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	}
+
 	receipt, err := bind.WaitMined(ctx, cc.ethclient, tx)
 	if err != nil {
 		cc.logger.Error("Waiting for %s transaction (hash: %s) failed: %v", txDescription, tx.Hash().Hex(), err)
 		return fmt.Errorf("waiting for %s transaction (hash: %s): %w", txDescription, tx.Hash().Hex(), err)
 	}
 	if receipt.Status == 0 {
-		cc.logger.Error("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
-		return fmt.Errorf("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
+		err := cc.replayFailedTransaction(ctx, tx, receipt)
+		cc.logger.Error("%s transaction (hash: %s) failed: %v", txDescription, tx.Hash().Hex(), err)
+		return fmt.Errorf("%s transaction (hash: %s) failed: %w", txDescription, tx.Hash().Hex(), err)
 	}
 	return nil
 }
 
+// SimulateTransaction runs eth_estimateGas and eth_call for msg against the latest block and records the
+// outcome in the dry-run report of ctx. A simulated revert is recorded, not returned; it is marked unverified
+// when the call follows one from the same sender that would have changed the state it runs against.
+func (cc *ContractCaller) SimulateTransaction(ctx context.Context, txDescription string, msg ethereum.CallMsg) error {
+	report := DryRunFromContext(ctx)
+	if report == nil {
+		return fmt.Errorf("%s: no dry run in progress", txDescription)
+	}
+
+	call := DryRunCall{
+		Description: txDescription,
+		ChainID:     cc.chainID.Uint64(),
+		Sender:      msg.From.Hex(),
+		Data:        hexutil.Encode(msg.Data),
+	}
+	if msg.To != nil {
+		call.Target = msg.To.Hex()
+	}
+	if msg.Value != nil && msg.Value.Sign() > 0 {
+		call.Value = msg.Value.String()
+	}
+	call.Method, call.Signature, call.Args = DecodeCallData(msg.Data, cc.callABIs()...)
+
+	if gas, err := cc.ethclient.EstimateGas(ctx, msg); err != nil {
+		call.fail(cc.DecodeRevertError(err))
+	} else {
+		call.GasEstimate = gas
+		msg.Gas = gas
+		if _, err := cc.ethclient.CallContract(ctx, msg, nil); err != nil {
+			call.fail(cc.DecodeRevertError(err))
+		} else {
+			call.Success = true
+		}
+	}
+	if !call.Success {
+		call.Unverified = report.followsSimulatedCall(call)
+	}
+	report.Add(call)
+	return nil
+}
+
+// callABIs returns the ABIs calldata sent by the caller is decoded against
+func (cc *ContractCaller) callABIs() []*abi.ABI {
+	abis := append([]*abi.ABI{}, EigenLayerABIs()...)
+	abis = append(abis, &ERC20ABI)
+	return append(abis, cc.contractOutputABIs()...)
+}
+
+// DecodeRevertError replaces an error carrying revert data with a RevertError naming the decoded error;
+// other errors are returned unchanged
+func (cc *ContractCaller) DecodeRevertError(err error) error {
+	data, ok := RevertData(err)
+	if !ok {
+		return err
+	}
+	return &RevertError{Reason: cc.decodeRevert(data), Data: data, err: err}
+}
+
+// decodeRevert decodes revert data, loading the project's contract outputs the first time an error is unknown
+func (cc *ContractCaller) decodeRevert(data []byte) string {
+	if !cc.revertDecoder.Known(data) {
+		cc.contractOutputABIs()
+	}
+	return cc.revertDecoder.Decode(data)
+}
+
+// replayFailedTransaction re-executes a mined, reverted transaction with eth_call at its block to recover
+// the revert data, which receipts do not carry
+func (cc *ContractCaller) replayFailedTransaction(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("could not recover sender to replay the transaction: %w", err)
+	}
+	_, err = cc.ethclient.CallContract(ctx, ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}, receipt.BlockNumber)
+	if err == nil {
+		if receipt.GasUsed >= tx.Gas() {
+			return fmt.Errorf("out of gas (used all %d)", tx.Gas())
+		}
+		return fmt.Errorf("transaction reverted, but replaying it at block %s did not", receipt.BlockNumber)
+	}
+	return cc.DecodeRevertError(err)
+}
+
 func (cc *ContractCaller) UpdateAVSMetadata(ctx context.Context, avsAddress common.Address, metadataURI string) error {
-	opts, err := cc.buildTxOpts()
+	opts, err := cc.buildTxOpts(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
@@ -115,7 +260,7 @@ func (cc *ContractCaller) UpdateAVSMetadata(ctx context.Context, avsAddress comm
 
 // SetAVSRegistrar sets the registrar address for an AVS
 func (cc *ContractCaller) SetAVSRegistrar(ctx context.Context, avsAddress, registrarAddress common.Address) error {
-	opts, err := cc.buildTxOpts()
+	opts, err := cc.buildTxOpts(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
@@ -139,7 +284,7 @@ func (cc *ContractCaller) SetAVSRegistrar(ctx context.Context, avsAddress, regis
 
 // CreateOperatorSets creates operator sets for an AVS
 func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress common.Address, sets []allocationmanager.IAllocationManagerTypesCreateSetParams) error {
-	opts, err := cc.buildTxOpts()
+	opts, err := cc.buildTxOpts(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
@@ -162,8 +307,33 @@ func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress com
 	return err
 }
 
+// AddStrategiesToOperatorSet adds strategies to an existing operator set of the AVS
+func (cc *ContractCaller) AddStrategiesToOperatorSet(ctx context.Context, avsAddress common.Address, operatorSetID uint32, strategies []common.Address) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("AddStrategiesToOperatorSet %d", operatorSetID), func() (*types.Transaction, error) {
+		tx, err := cc.allocationManager.AddStrategiesToOperatorSet(opts, avsAddress, operatorSetID, strategies)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for AddStrategiesToOperatorSet: %s\n"+
+					"avsAddress: %s\n"+
+					"operatorSetId: %d\n"+
+					"strategies: %s",
+				tx.Hash().Hex(),
+				avsAddress,
+				operatorSetID,
+				strategies,
+			)
+		}
+		return tx, err
+	})
+}
+
 func (cc *ContractCaller) RegisterAsOperator(ctx context.Context, operatorAddress common.Address, allocationDelay uint32, metadataURI string) error {
-	opts, err := cc.buildTxOpts()
+	opts, err := cc.buildTxOpts(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
@@ -189,7 +359,7 @@ func (cc *ContractCaller) RegisterAsOperator(ctx context.Context, operatorAddres
 }
 
 func (cc *ContractCaller) RegisterForOperatorSets(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetIDs []uint32, payload []byte) error {
-	opts, err := cc.buildTxOpts()
+	opts, err := cc.buildTxOpts(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
@@ -221,6 +391,488 @@ func (cc *ContractCaller) RegisterForOperatorSets(ctx context.Context, operatorA
 	return err
 }
 
+// GetStrategiesInOperatorSet returns the strategies an AVS has added to the operator set
+func (cc *ContractCaller) GetStrategiesInOperatorSet(ctx context.Context, avsAddress common.Address, operatorSetID uint32) ([]common.Address, error) {
+	return cc.allocationManager.GetStrategiesInOperatorSet(&bind.CallOpts{Context: ctx}, allocationmanager.OperatorSet{
+		Avs: avsAddress,
+		Id:  operatorSetID,
+	})
+}
+
+// GetStrategyUnderlyingToken returns the token a strategy accepts deposits in
+func (cc *ContractCaller) GetStrategyUnderlyingToken(ctx context.Context, strategyAddress common.Address) (common.Address, error) {
+	strategy, err := istrategy.NewIStrategy(strategyAddress, cc.ethclient)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create IStrategy: %w", err)
+	}
+	return strategy.UnderlyingToken(&bind.CallOpts{Context: ctx})
+}
+
+// GetStrategyUserUnderlying returns the amount of underlying token the user's shares in a strategy are worth
+func (cc *ContractCaller) GetStrategyUserUnderlying(ctx context.Context, strategyAddress, user common.Address) (*big.Int, error) {
+	strategy, err := istrategy.NewIStrategy(strategyAddress, cc.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IStrategy: %w", err)
+	}
+	return strategy.UserUnderlyingView(&bind.CallOpts{Context: ctx}, user)
+}
+
+// GetERC20Decimals returns the decimals of an ERC20 token
+func (cc *ContractCaller) GetERC20Decimals(ctx context.Context, tokenAddress common.Address) (uint8, error) {
+	token := bind.NewBoundContract(tokenAddress, ERC20ABI, cc.ethclient, cc.ethclient, cc.ethclient)
+	var out []interface{}
+	if err := token.Call(&bind.CallOpts{Context: ctx}, &out, "decimals"); err != nil {
+		return 0, fmt.Errorf("failed to read decimals of %s: %w", tokenAddress.Hex(), err)
+	}
+	return *abi.ConvertType(out[0], new(uint8)).(*uint8), nil
+}
+
+// GetERC20Balance returns the token balance of holder
+func (cc *ContractCaller) GetERC20Balance(ctx context.Context, tokenAddress, holder common.Address) (*big.Int, error) {
+	token := bind.NewBoundContract(tokenAddress, ERC20ABI, cc.ethclient, cc.ethclient, cc.ethclient)
+	var out []interface{}
+	if err := token.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", holder); err != nil {
+		return nil, fmt.Errorf("failed to read %s balance of %s: %w", tokenAddress.Hex(), holder.Hex(), err)
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// ApproveERC20 approves spender to transfer amount of the token on behalf of the caller
+func (cc *ContractCaller) ApproveERC20(ctx context.Context, tokenAddress, spender common.Address, amount *big.Int) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	token := bind.NewBoundContract(tokenAddress, ERC20ABI, cc.ethclient, cc.ethclient, cc.ethclient)
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("Approve %s", tokenAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := token.Transact(opts, "approve", spender, amount)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for Approve: %s\n"+
+					"token: %s\n"+
+					"spender: %s\n"+
+					"amount: %s",
+				tx.Hash().Hex(),
+				tokenAddress,
+				spender,
+				amount,
+			)
+		}
+		return tx, err
+	})
+}
+
+// DepositIntoStrategy deposits amount of token into strategy through the StrategyManager used by the DelegationManager
+func (cc *ContractCaller) DepositIntoStrategy(ctx context.Context, strategyAddress, tokenAddress common.Address, amount *big.Int) error {
+	strategyManagerAddr, err := cc.GetStrategyManagerAddress(ctx)
+	if err != nil {
+		return err
+	}
+	strategyManager, err := strategymanager.NewStrategyManager(strategyManagerAddr, cc.ethclient)
+	if err != nil {
+		return fmt.Errorf("failed to create StrategyManager: %w", err)
+	}
+
+	if err := cc.ApproveERC20(ctx, tokenAddress, strategyManagerAddr, amount); err != nil {
+		return err
+	}
+
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("DepositIntoStrategy %s", strategyAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := strategyManager.DepositIntoStrategy(opts, strategyAddress, tokenAddress, amount)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for DepositIntoStrategy: %s\n"+
+					"strategy: %s\n"+
+					"token: %s\n"+
+					"amount: %s",
+				tx.Hash().Hex(),
+				strategyAddress,
+				tokenAddress,
+				amount,
+			)
+		}
+		return tx, err
+	})
+}
+
+// GetStrategyManagerAddress returns the StrategyManager the DelegationManager is wired to
+func (cc *ContractCaller) GetStrategyManagerAddress(ctx context.Context) (common.Address, error) {
+	addr, err := cc.delegationManager.StrategyManager(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get StrategyManager address: %w", err)
+	}
+	return addr, nil
+}
+
+// GetAllocationDelay returns whether the operator's allocation delay is in effect and its value
+func (cc *ContractCaller) GetAllocationDelay(ctx context.Context, operatorAddress common.Address) (bool, uint32, error) {
+	return cc.allocationManager.GetAllocationDelay(&bind.CallOpts{Context: ctx}, operatorAddress)
+}
+
+// GetAllocationConfigurationDelay returns the number of blocks before a new allocation delay takes effect
+func (cc *ContractCaller) GetAllocationConfigurationDelay(ctx context.Context) (uint32, error) {
+	return cc.allocationManager.ALLOCATIONCONFIGURATIONDELAY(&bind.CallOpts{Context: ctx})
+}
+
+// GetAllocatableMagnitude returns the magnitude the operator has not yet allocated for a strategy
+func (cc *ContractCaller) GetAllocatableMagnitude(ctx context.Context, operatorAddress, strategyAddress common.Address) (uint64, error) {
+	return cc.allocationManager.GetAllocatableMagnitude(&bind.CallOpts{Context: ctx}, operatorAddress, strategyAddress)
+}
+
+// GetMaxMagnitude returns the total magnitude the operator can allocate for a strategy
+func (cc *ContractCaller) GetMaxMagnitude(ctx context.Context, operatorAddress, strategyAddress common.Address) (uint64, error) {
+	return cc.allocationManager.GetMaxMagnitude(&bind.CallOpts{Context: ctx}, operatorAddress, strategyAddress)
+}
+
+// GetAllocatedMagnitude returns the magnitude the operator currently allocates to the operator set for a strategy
+func (cc *ContractCaller) GetAllocatedMagnitude(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetID uint32, strategyAddress common.Address) (uint64, error) {
+	allocation, err := cc.allocationManager.GetAllocation(&bind.CallOpts{Context: ctx}, operatorAddress, allocationmanager.OperatorSet{
+		Avs: avsAddress,
+		Id:  operatorSetID,
+	}, strategyAddress)
+	if err != nil {
+		return 0, err
+	}
+	return allocation.CurrentMagnitude, nil
+}
+
+// ModifyAllocations sets the operator's magnitude for each strategy in the operator set
+func (cc *ContractCaller) ModifyAllocations(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetID uint32, strategies []common.Address, magnitudes []uint64) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	params := []allocationmanager.IAllocationManagerTypesAllocateParams{{
+		OperatorSet: allocationmanager.OperatorSet{
+			Avs: avsAddress,
+			Id:  operatorSetID,
+		},
+		Strategies:    strategies,
+		NewMagnitudes: magnitudes,
+	}}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("ModifyAllocations for %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := cc.allocationManager.ModifyAllocations(opts, operatorAddress, params)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for ModifyAllocations: %s\n"+
+					"  operatorAddress: %s\n"+
+					"  avsAddress: %s\n"+
+					"  operatorSetID: %d\n"+
+					"  strategies: %v\n"+
+					"  magnitudes: %v\n",
+				tx.Hash().Hex(),
+				operatorAddress.Hex(),
+				avsAddress.Hex(),
+				operatorSetID,
+				strategies,
+				magnitudes,
+			)
+		}
+		return tx, err
+	})
+}
+
+// DeregisterFromOperatorSets removes the operator from the AVS's operator sets
+func (cc *ContractCaller) DeregisterFromOperatorSets(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetIDs []uint32) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	params := allocationmanager.IAllocationManagerTypesDeregisterParams{
+		Operator:       operatorAddress,
+		Avs:            avsAddress,
+		OperatorSetIds: operatorSetIDs,
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("DeregisterFromOperatorSets for %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := cc.allocationManager.DeregisterFromOperatorSets(opts, params)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for DeregisterFromOperatorSets: %s\n"+
+					"  operatorAddress: %s\n"+
+					"  avsAddress: %s\n"+
+					"  operatorSetIDs: %v\n",
+				tx.Hash().Hex(),
+				operatorAddress.Hex(),
+				avsAddress.Hex(),
+				operatorSetIDs,
+			)
+		}
+		return tx, err
+	})
+}
+
+// UpdateOperatorMetadataURI emits a new metadata URI for the operator
+func (cc *ContractCaller) UpdateOperatorMetadataURI(ctx context.Context, operatorAddress common.Address, metadataURI string) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("UpdateOperatorMetadataURI for %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := cc.delegationManager.UpdateOperatorMetadataURI(opts, operatorAddress, metadataURI)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for UpdateOperatorMetadataURI: %s\n"+
+					"  operatorAddress: %s\n"+
+					"  metadataURI: %s\n",
+				tx.Hash().Hex(),
+				operatorAddress.Hex(),
+				metadataURI,
+			)
+		}
+		return tx, err
+	})
+}
+
+// Undelegate undelegates the staker from its operator, queueing withdrawals of all its delegated shares
+func (cc *ContractCaller) Undelegate(ctx context.Context, stakerAddress common.Address) error {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("Undelegate for %s", stakerAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := cc.delegationManager.Undelegate(opts, stakerAddress)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for Undelegate: %s\n"+
+					"  stakerAddress: %s\n",
+				tx.Hash().Hex(),
+				stakerAddress.Hex(),
+			)
+		}
+		return tx, err
+	})
+}
+
+// IsOperator returns whether the address is registered as an operator with the DelegationManager
+func (cc *ContractCaller) IsOperator(ctx context.Context, operatorAddress common.Address) (bool, error) {
+	return cc.delegationManager.IsOperator(&bind.CallOpts{Context: ctx}, operatorAddress)
+}
+
+// GetDelegatedTo returns the operator the staker is delegated to, or the zero address
+func (cc *ContractCaller) GetDelegatedTo(ctx context.Context, stakerAddress common.Address) (common.Address, error) {
+	return cc.delegationManager.DelegatedTo(&bind.CallOpts{Context: ctx}, stakerAddress)
+}
+
+// GetAVSRegistrar returns the registrar the AllocationManager calls for the AVS; the AVS itself when none was set
+func (cc *ContractCaller) GetAVSRegistrar(ctx context.Context, avsAddress common.Address) (common.Address, error) {
+	return cc.allocationManager.GetAVSRegistrar(&bind.CallOpts{Context: ctx}, avsAddress)
+}
+
+// GetAVSMetadataURI returns the metadata URI the AVS last emitted at or after fromBlock. The URI is only kept
+// in AVSMetadataURIUpdated events, so found is false when the AVS emitted none in that range.
+func (cc *ContractCaller) GetAVSMetadataURI(ctx context.Context, avsAddress common.Address, fromBlock uint64) (uri string, found bool, err error) {
+	it, err := cc.allocationManager.FilterAVSMetadataURIUpdated(&bind.FilterOpts{Start: fromBlock, Context: ctx}, []common.Address{avsAddress})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to filter AVSMetadataURIUpdated events: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		uri, found = it.Event.MetadataURI, true
+	}
+	if err := it.Error(); err != nil {
+		return "", false, fmt.Errorf("failed to read AVSMetadataURIUpdated events: %w", err)
+	}
+	return uri, found, nil
+}
+
+// IsOperatorSet returns whether the AVS has created the operator set
+func (cc *ContractCaller) IsOperatorSet(ctx context.Context, avsAddress common.Address, operatorSetID uint32) (bool, error) {
+	return cc.allocationManager.IsOperatorSet(&bind.CallOpts{Context: ctx}, allocationmanager.OperatorSet{
+		Avs: avsAddress,
+		Id:  operatorSetID,
+	})
+}
+
+// GetRegisteredSets returns the operator sets the operator is registered for, across every AVS
+func (cc *ContractCaller) GetRegisteredSets(ctx context.Context, operatorAddress common.Address) ([]allocationmanager.OperatorSet, error) {
+	return cc.allocationManager.GetRegisteredSets(&bind.CallOpts{Context: ctx}, operatorAddress)
+}
+
+// RewardsCoordinatorParams are the limits the RewardsCoordinator enforces on rewards submissions, in seconds
+type RewardsCoordinatorParams struct {
+	CalculationInterval  uint32
+	MaxRewardsDuration   uint32
+	MaxRetroactiveLength uint32
+	MaxFutureLength      uint32
+	GenesisTimestamp     uint32
+}
+
+// GetRewardsCoordinatorParams returns the limits the RewardsCoordinator enforces on rewards submissions
+func (cc *ContractCaller) GetRewardsCoordinatorParams(ctx context.Context, rewardsCoordinatorAddress common.Address) (*RewardsCoordinatorParams, error) {
+	rc, err := rewardscoordinator.NewRewardsCoordinatorCaller(rewardsCoordinatorAddress, cc.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RewardsCoordinator: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	params := &RewardsCoordinatorParams{}
+	if params.CalculationInterval, err = rc.CALCULATIONINTERVALSECONDS(opts); err != nil {
+		return nil, fmt.Errorf("failed to get CALCULATION_INTERVAL_SECONDS: %w", err)
+	}
+	if params.MaxRewardsDuration, err = rc.MAXREWARDSDURATION(opts); err != nil {
+		return nil, fmt.Errorf("failed to get MAX_REWARDS_DURATION: %w", err)
+	}
+	if params.MaxRetroactiveLength, err = rc.MAXRETROACTIVELENGTH(opts); err != nil {
+		return nil, fmt.Errorf("failed to get MAX_RETROACTIVE_LENGTH: %w", err)
+	}
+	if params.MaxFutureLength, err = rc.MAXFUTURELENGTH(opts); err != nil {
+		return nil, fmt.Errorf("failed to get MAX_FUTURE_LENGTH: %w", err)
+	}
+	if params.GenesisTimestamp, err = rc.GENESISREWARDSTIMESTAMP(opts); err != nil {
+		return nil, fmt.Errorf("failed to get GENESIS_REWARDS_TIMESTAMP: %w", err)
+	}
+	return params, nil
+}
+
+// CreateAVSRewardsSubmission submits rewards for the AVS's operators and stakers; the caller is the AVS
+func (cc *ContractCaller) CreateAVSRewardsSubmission(ctx context.Context, rewardsCoordinatorAddress common.Address, submissions []rewardscoordinator.IRewardsCoordinatorTypesRewardsSubmission) error {
+	rc, err := rewardscoordinator.NewRewardsCoordinatorTransactor(rewardsCoordinatorAddress, cc.ethclient)
+	if err != nil {
+		return fmt.Errorf("failed to create RewardsCoordinator: %w", err)
+	}
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, "CreateAVSRewardsSubmission", func() (*types.Transaction, error) {
+		tx, err := rc.CreateAVSRewardsSubmission(opts, submissions)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for CreateAVSRewardsSubmission: %s\n"+
+					"  rewardsCoordinator: %s\n"+
+					"  submissions: %d\n",
+				tx.Hash().Hex(),
+				rewardsCoordinatorAddress.Hex(),
+				len(submissions),
+			)
+		}
+		return tx, err
+	})
+}
+
+// CreateOperatorDirectedAVSRewardsSubmission submits rewards paid to the AVS's operators in fixed amounts
+func (cc *ContractCaller) CreateOperatorDirectedAVSRewardsSubmission(ctx context.Context, rewardsCoordinatorAddress, avsAddress common.Address, submissions []rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission) error {
+	rc, err := rewardscoordinator.NewRewardsCoordinatorTransactor(rewardsCoordinatorAddress, cc.ethclient)
+	if err != nil {
+		return fmt.Errorf("failed to create RewardsCoordinator: %w", err)
+	}
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("CreateOperatorDirectedAVSRewardsSubmission for %s", avsAddress.Hex()), func() (*types.Transaction, error) {
+		tx, err := rc.CreateOperatorDirectedAVSRewardsSubmission(opts, avsAddress, submissions)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for CreateOperatorDirectedAVSRewardsSubmission: %s\n"+
+					"  avsAddress: %s\n"+
+					"  submissions: %d\n",
+				tx.Hash().Hex(),
+				avsAddress.Hex(),
+				len(submissions),
+			)
+		}
+		return tx, err
+	})
+}
+
+// CreateOperatorDirectedOperatorSetRewardsSubmission submits rewards paid to an operator set's operators in fixed amounts
+func (cc *ContractCaller) CreateOperatorDirectedOperatorSetRewardsSubmission(ctx context.Context, rewardsCoordinatorAddress, avsAddress common.Address, operatorSetID uint32, submissions []rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission) error {
+	rc, err := rewardscoordinator.NewRewardsCoordinatorTransactor(rewardsCoordinatorAddress, cc.ethclient)
+	if err != nil {
+		return fmt.Errorf("failed to create RewardsCoordinator: %w", err)
+	}
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	operatorSet := rewardscoordinator.OperatorSet{Avs: avsAddress, Id: operatorSetID}
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("CreateOperatorDirectedOperatorSetRewardsSubmission for operator set %d", operatorSetID), func() (*types.Transaction, error) {
+		tx, err := rc.CreateOperatorDirectedOperatorSetRewardsSubmission(opts, operatorSet, submissions)
+		if err == nil && tx != nil {
+			cc.logger.Debug(
+				"Transaction hash for CreateOperatorDirectedOperatorSetRewardsSubmission: %s\n"+
+					"  avsAddress: %s\n"+
+					"  operatorSetID: %d\n"+
+					"  submissions: %d\n",
+				tx.Hash().Hex(),
+				avsAddress.Hex(),
+				operatorSetID,
+				len(submissions),
+			)
+		}
+		return tx, err
+	})
+}
+
+// IsOperatorSlashable reports whether the operator is registered for the operator set or still within its deallocation delay
+func (cc *ContractCaller) IsOperatorSlashable(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetID uint32) (bool, error) {
+	return cc.allocationManager.IsOperatorSlashable(&bind.CallOpts{Context: ctx}, operatorAddress, allocationmanager.OperatorSet{
+		Avs: avsAddress,
+		Id:  operatorSetID,
+	})
+}
+
+// GetMaxMagnitudes returns the operator's max magnitude for each strategy
+func (cc *ContractCaller) GetMaxMagnitudes(ctx context.Context, operatorAddress common.Address, strategies []common.Address) ([]uint64, error) {
+	return cc.allocationManager.GetMaxMagnitudes0(&bind.CallOpts{Context: ctx}, operatorAddress, strategies)
+}
+
+// SlashOperator slashes the operator's allocations to one of the AVS's operator sets and returns the transaction hash
+func (cc *ContractCaller) SlashOperator(ctx context.Context, avsAddress common.Address, params allocationmanager.IAllocationManagerTypesSlashingParams) (common.Hash, error) {
+	opts, err := cc.buildTxOpts(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	var txHash common.Hash
+	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("SlashOperator for %s", params.Operator.Hex()), func() (*types.Transaction, error) {
+		tx, err := cc.allocationManager.SlashOperator(opts, avsAddress, params)
+		if err == nil && tx != nil {
+			txHash = tx.Hash()
+			cc.logger.Debug(
+				"Transaction hash for SlashOperator: %s\n"+
+					"  avsAddress: %s\n"+
+					"  operatorAddress: %s\n"+
+					"  operatorSetID: %d\n"+
+					"  strategies: %v\n"+
+					"  wadsToSlash: %v\n",
+				tx.Hash().Hex(),
+				avsAddress.Hex(),
+				params.Operator.Hex(),
+				params.OperatorSetId,
+				params.Strategies,
+				params.WadsToSlash,
+			)
+		}
+		return tx, err
+	})
+	return txHash, err
+}
+
+// PackSlashOperator ABI-encodes an AllocationManager.slashOperator call, for sending it from an account without its key
+func PackSlashOperator(avsAddress common.Address, params allocationmanager.IAllocationManagerTypesSlashingParams) ([]byte, error) {
+	parsed, err := allocationmanager.AllocationManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AllocationManager ABI: %w", err)
+	}
+	return parsed.Pack("slashOperator", avsAddress, params)
+}
+
 func IsValidABI(v interface{}) error {
 	b, err := json.Marshal(v) // serialize ABI field
 	if err != nil {
@@ -0,0 +1,165 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// AnvilClient wraps the anvil_* and evm_* JSON-RPC namespaces exposed by a devnet
+type AnvilClient struct {
+	rpc *rpc.Client
+}
+
+// DialAnvil connects to the anvil node listening at rpcURL
+func DialAnvil(ctx context.Context, rpcURL string) (*AnvilClient, error) {
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to devnet at %s: %w", rpcURL, err)
+	}
+	return &AnvilClient{rpc: client}, nil
+}
+
+// Close releases the underlying RPC connection
+func (a *AnvilClient) Close() {
+	a.rpc.Close()
+}
+
+// DumpState returns the hex encoded chain state as produced by anvil_dumpState
+func (a *AnvilClient) DumpState(ctx context.Context) (string, error) {
+	var state string
+	if err := a.rpc.CallContext(ctx, &state, "anvil_dumpState"); err != nil {
+		return "", fmt.Errorf("anvil_dumpState: %w", err)
+	}
+	return state, nil
+}
+
+// LoadState merges a state blob produced by DumpState into the running chain
+func (a *AnvilClient) LoadState(ctx context.Context, state string) error {
+	var ok bool
+	if err := a.rpc.CallContext(ctx, &ok, "anvil_loadState", state); err != nil {
+		return fmt.Errorf("anvil_loadState: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("anvil_loadState: node rejected state")
+	}
+	return nil
+}
+
+// SetStorageAt overwrites a single storage slot of the contract at addr
+func (a *AnvilClient) SetStorageAt(ctx context.Context, addr common.Address, slot, value common.Hash) error {
+	var ok bool
+	if err := a.rpc.CallContext(ctx, &ok, "anvil_setStorageAt", addr, slot, value); err != nil {
+		return fmt.Errorf("anvil_setStorageAt: %w", err)
+	}
+	return nil
+}
+
+// Mine mines the given number of blocks immediately
+func (a *AnvilClient) Mine(ctx context.Context, blocks uint64) error {
+	if err := a.rpc.CallContext(ctx, nil, "anvil_mine", hexutil.Uint64(blocks)); err != nil {
+		return fmt.Errorf("anvil_mine: %w", err)
+	}
+	return nil
+}
+
+// MineWithInterval mines the given number of blocks in one call, spacing their timestamps interval seconds apart
+// so the clock moves forward with them
+func (a *AnvilClient) MineWithInterval(ctx context.Context, blocks, interval uint64) error {
+	if err := a.rpc.CallContext(ctx, nil, "anvil_mine", hexutil.Uint64(blocks), hexutil.Uint64(interval)); err != nil {
+		return fmt.Errorf("anvil_mine: %w", err)
+	}
+	return nil
+}
+
+// IncreaseTime moves the timestamp of the next block forward by the given number of seconds
+func (a *AnvilClient) IncreaseTime(ctx context.Context, seconds uint64) error {
+	if err := a.rpc.CallContext(ctx, nil, "evm_increaseTime", hexutil.Uint64(seconds)); err != nil {
+		return fmt.Errorf("evm_increaseTime: %w", err)
+	}
+	return nil
+}
+
+// SetNextBlockTimestamp fixes the timestamp of the next mined block
+func (a *AnvilClient) SetNextBlockTimestamp(ctx context.Context, timestamp uint64) error {
+	if err := a.rpc.CallContext(ctx, nil, "evm_setNextBlockTimestamp", hexutil.Uint64(timestamp)); err != nil {
+		return fmt.Errorf("evm_setNextBlockTimestamp: %w", err)
+	}
+	return nil
+}
+
+// SetAutomine toggles mining a block for every transaction
+func (a *AnvilClient) SetAutomine(ctx context.Context, enabled bool) error {
+	if err := a.rpc.CallContext(ctx, nil, "evm_setAutomine", enabled); err != nil {
+		return fmt.Errorf("evm_setAutomine: %w", err)
+	}
+	return nil
+}
+
+// SetIntervalMining mines a block every interval seconds; 0 disables interval mining
+func (a *AnvilClient) SetIntervalMining(ctx context.Context, seconds uint64) error {
+	if err := a.rpc.CallContext(ctx, nil, "evm_setIntervalMining", seconds); err != nil {
+		return fmt.Errorf("evm_setIntervalMining: %w", err)
+	}
+	return nil
+}
+
+// SetBalance sets the ether balance of addr to exactly balance wei
+func (a *AnvilClient) SetBalance(ctx context.Context, addr common.Address, balance *big.Int) error {
+	if err := a.rpc.CallContext(ctx, nil, "anvil_setBalance", addr, (*hexutil.Big)(balance)); err != nil {
+		return fmt.Errorf("anvil_setBalance: %w", err)
+	}
+	return nil
+}
+
+// ForkBlock returns the block the node was forked at, read from anvil_nodeInfo; forked is false for a fresh chain
+func (a *AnvilClient) ForkBlock(ctx context.Context) (block uint64, forked bool, err error) {
+	var info struct {
+		ForkConfig struct {
+			ForkBlockNumber *uint64 `json:"forkBlockNumber"`
+		} `json:"forkConfig"`
+	}
+	if err := a.rpc.CallContext(ctx, &info, "anvil_nodeInfo"); err != nil {
+		return 0, false, fmt.Errorf("anvil_nodeInfo: %w", err)
+	}
+	if info.ForkConfig.ForkBlockNumber == nil {
+		return 0, false, nil
+	}
+	return *info.ForkConfig.ForkBlockNumber, true, nil
+}
+
+// IsAnvil reports whether the node identifies itself as anvil through web3_clientVersion
+func (a *AnvilClient) IsAnvil(ctx context.Context) bool {
+	var version string
+	if err := a.rpc.CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(version), "anvil")
+}
+
+// SendAs sends a transaction from an account whose key the caller does not hold by impersonating it
+// for the duration of the call, and returns the transaction hash
+func (a *AnvilClient) SendAs(ctx context.Context, from, to common.Address, data []byte) (common.Hash, error) {
+	if err := a.rpc.CallContext(ctx, nil, "anvil_impersonateAccount", from); err != nil {
+		return common.Hash{}, fmt.Errorf("anvil_impersonateAccount: %w", err)
+	}
+	defer func() {
+		_ = a.rpc.CallContext(ctx, nil, "anvil_stopImpersonatingAccount", from)
+	}()
+
+	var txHash common.Hash
+	tx := map[string]any{
+		"from": from,
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	}
+	if err := a.rpc.CallContext(ctx, &txHash, "eth_sendTransaction", tx); err != nil {
+		return common.Hash{}, fmt.Errorf("eth_sendTransaction from %s: %w", from.Hex(), err)
+	}
+	return txHash, nil
+}
@@ -0,0 +1,221 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/internal/version"
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/docker/docker/client"
+)
+
+// Devnet backends, selected by devnet_backend in the devnet context or by --backend
+const (
+	// BACKEND_DOCKER runs each chain's anvil in a container through docker compose
+	BACKEND_DOCKER = "docker"
+	// BACKEND_ANVIL runs each chain's anvil directly on the host as a child process
+	BACKEND_ANVIL = "anvil"
+)
+
+// DevnetBackends lists the supported backends, the default first
+var DevnetBackends = []string{BACKEND_DOCKER, BACKEND_ANVIL}
+
+// ChainLaunch describes one chain a backend starts
+type ChainLaunch struct {
+	ProjectName string
+	Chain       ChainSpec
+	// Image is the Foundry image; only the docker backend uses it
+	Image string
+	// BaseArgs are the anvil arguments shared by every chain
+	BaseArgs string
+	// Resume starts the chain on top of the state it dumped when it was last stopped
+	Resume bool
+}
+
+// Backend runs the anvil instances behind a devnet. Its ContainerManager methods discover and control the
+// instances it started, so list, stop and status treat the chains of every backend the same way.
+type Backend interface {
+	ContainerManager
+	// Name is the value that selects the backend
+	Name() string
+	// StartChain launches one chain's anvil; it returns once anvil was started, not once it is ready
+	StartChain(ctx context.Context, launch ChainLaunch) error
+	// DestroyChain removes one chain of a project's devnet together with any state it keeps
+	DestroyChain(ctx context.Context, projectName, chainName string) error
+}
+
+// NewBackend returns the named backend. Tests replace it to inject a fake.
+var NewBackend = newBackend
+
+func newBackend(name string) (Backend, error) {
+	switch name {
+	case BACKEND_DOCKER:
+		return NewDockerBackend()
+	case BACKEND_ANVIL:
+		return NewNativeBackend(), nil
+	}
+	return nil, unknownBackendError(name)
+}
+
+// GetDevnetBackendName returns the backend chosen by flag, falling back to devnet_backend in the devnet
+// context and then to docker
+func GetDevnetBackendName(cfg *devkitcommon.ConfigWithContextConfig, flag string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(flag))
+	if name == "" && cfg != nil {
+		if ctx, ok := cfg.Context[CONTEXT]; ok {
+			name = strings.ToLower(strings.TrimSpace(ctx.DevnetBackend))
+		}
+	}
+	if name == "" {
+		return BACKEND_DOCKER, nil
+	}
+	for _, backend := range DevnetBackends {
+		if backend == name {
+			return name, nil
+		}
+	}
+	return "", unknownBackendError(name)
+}
+
+func unknownBackendError(name string) error {
+	return fmt.Errorf("unknown devnet backend %q; use one of: %s", name, strings.Join(DevnetBackends, ", "))
+}
+
+// dockerBackend starts chains with docker compose and controls them through the Docker Engine API
+type dockerBackend struct {
+	*dockerContainerManager
+}
+
+// NewDockerBackend returns the backend running every chain in its own compose project
+func NewDockerBackend() (Backend, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	return &dockerBackend{dockerContainerManager: &dockerContainerManager{cli: cli}}, nil
+}
+
+func (b *dockerBackend) Name() string {
+	return BACKEND_DOCKER
+}
+
+func (b *dockerBackend) StartChain(ctx context.Context, launch ChainLaunch) error {
+	composePath := WriteEmbeddedArtifacts()
+	projectName, chain := launch.ProjectName, launch.Chain
+
+	// The project's state dir is mounted into the container so anvil's state dump outlives the container
+	if err := ensureDevnetStateDir(); err != nil {
+		return err
+	}
+	stateDir, err := filepath.Abs(GetDevnetStateDir())
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", GetDevnetStateDir(), err)
+	}
+	anvilArgs := chain.AnvilArgs(launch.BaseArgs) + " " + StateArgs(path.Join(DOCKER_STATE_DIR, chain.Name+".json"), launch.Resume)
+
+	cmd := exec.CommandContext(ctx, "docker", "compose", "-p", GetDevnetComposeProjectName(projectName, chain.Name), "-f", composePath, "up", "-d")
+	cmd.Env = append(os.Environ(),
+		"FOUNDRY_IMAGE="+launch.Image,
+		"ANVIL_ARGS="+anvilArgs,
+		"DEVNET_STATE_DIR="+stateDir,
+		fmt.Sprintf("DEVNET_PORT=%d", chain.Port),
+		"AVS_CONTAINER_NAME="+GetDevnetContainerName(projectName, chain.Name),
+		"DEVNET_PROJECT="+projectName,
+		"DEVNET_CHAIN="+chain.Name,
+		"DEVKIT_VERSION="+version.GetVersion(),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker compose up failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (b *dockerBackend) DestroyChain(ctx context.Context, projectName, chainName string) error {
+	composePath := WriteEmbeddedArtifacts()
+	cmd := exec.CommandContext(ctx, "docker", "compose", "-p", GetDevnetComposeProjectName(projectName, chainName), "-f", composePath, "down", "-v", "--remove-orphans")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("docker compose down failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// multiManager presents the instances of several backends as one ContainerManager
+type multiManager struct {
+	managers []ContainerManager
+}
+
+// NewDevnetContainerManager returns a ContainerManager over the chains of every backend. A Docker daemon
+// that cannot be reached only hides the docker chains, so natively run devnets work without Docker.
+func NewDevnetContainerManager() (ContainerManager, error) {
+	docker, err := NewDockerContainerManager()
+	if err != nil {
+		return nil, err
+	}
+	return &multiManager{managers: []ContainerManager{docker, NewNativeBackend()}}, nil
+}
+
+func (m *multiManager) List(ctx context.Context, filter ContainerFilter) ([]DevnetContainer, error) {
+	containers := []DevnetContainer{}
+	for _, manager := range m.managers {
+		found, err := manager.List(ctx, filter)
+		if client.IsErrConnectionFailed(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, found...)
+	}
+	SortDevnetContainers(containers)
+	return containers, nil
+}
+
+func (m *multiManager) Get(ctx context.Context, ref string) (*DevnetContainer, error) {
+	_, c, err := m.owner(ctx, ref)
+	return c, err
+}
+
+func (m *multiManager) Stop(ctx context.Context, ref string) error {
+	manager, _, err := m.owner(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return manager.Stop(ctx, ref)
+}
+
+func (m *multiManager) Remove(ctx context.Context, ref string) error {
+	manager, _, err := m.owner(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return manager.Remove(ctx, ref)
+}
+
+func (m *multiManager) Close() error {
+	var errs []error
+	for _, manager := range m.managers {
+		errs = append(errs, manager.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// owner returns the manager that knows ref together with what it reports about it
+func (m *multiManager) owner(ctx context.Context, ref string) (ContainerManager, *DevnetContainer, error) {
+	for _, manager := range m.managers {
+		c, err := manager.Get(ctx, ref)
+		if err == nil {
+			return manager, c, nil
+		}
+		if !errors.Is(err, ErrContainerNotFound) && !client.IsErrConnectionFailed(err) {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+}
@@ -0,0 +1,64 @@
+package devnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDevnetBackendName(t *testing.T) {
+	cfgWith := func(backend string) *common.ConfigWithContextConfig {
+		return &common.ConfigWithContextConfig{
+			Context: map[string]common.ChainContextConfig{CONTEXT: {DevnetBackend: backend}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *common.ConfigWithContextConfig
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		{name: "defaults to docker", cfg: cfgWith(""), want: BACKEND_DOCKER},
+		{name: "context field", cfg: cfgWith("anvil"), want: BACKEND_ANVIL},
+		{name: "flag overrides context", cfg: cfgWith("anvil"), flag: "Docker", want: BACKEND_DOCKER},
+		{name: "unknown backend", cfg: cfgWith("podman"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDevnetBackendName(tt.cfg, tt.flag)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "docker, anvil")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMultiManagerRoutesToOwner(t *testing.T) {
+	ctx := context.Background()
+	docker := NewFakeContainerManager(DevnetContainer{ID: "c1", Name: "devkit-devnet-a", Project: "a", Chain: L1, Running: true, Backend: BACKEND_DOCKER})
+	native := NewFakeContainerManager(DevnetContainer{ID: "42", Name: "devkit-devnet-b", Project: "b", Chain: L1, Running: true, Backend: BACKEND_ANVIL})
+	manager := &multiManager{managers: []ContainerManager{docker, native}}
+
+	all, err := manager.List(ctx, ContainerFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "a", all[0].Project)
+	assert.Equal(t, BACKEND_ANVIL, all[1].Backend)
+
+	require.NoError(t, StopAndRemoveDevnetContainer(ctx, manager, "devkit-devnet-b"))
+	assert.Empty(t, docker.Stopped, "only the backend that owns the chain is asked to stop it")
+	assert.Equal(t, []string{"devkit-devnet-b"}, native.Removed)
+
+	_, err = manager.Get(ctx, "devkit-devnet-missing")
+	assert.ErrorIs(t, err, ErrContainerNotFound)
+}
@@ -0,0 +1,119 @@
+package devnet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+)
+
+// ChainSpec describes a single anvil instance backing one entry of the devnet context's chains
+type ChainSpec struct {
+	Name      string
+	ChainID   int
+	BlockTime int
+	ForkURL   string
+	ForkBlock int
+	Port      int
+}
+
+// GetDevnetChainNames returns the chain names defined in the devnet context, L1 first and the rest sorted
+func GetDevnetChainNames(cfg *common.ConfigWithContextConfig) []string {
+	names := make([]string, 0, len(cfg.Context[CONTEXT].Chains))
+	for name := range cfg.Context[CONTEXT].Chains {
+		if name != L1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := cfg.Context[CONTEXT].Chains[L1]; ok {
+		names = append([]string{L1}, names...)
+	}
+	return names
+}
+
+// ResolveDevnetChains builds a ChainSpec for every chain in the devnet context.
+// L1 listens on basePort and each further chain takes the next port in GetDevnetChainNames order.
+func ResolveDevnetChains(cfg *common.ConfigWithContextConfig, basePort int) ([]ChainSpec, error) {
+	names := GetDevnetChainNames(cfg)
+	if len(names) == 0 || names[0] != L1 {
+		return nil, fmt.Errorf("failed to find a chain with name: %s in devnet.yaml", L1)
+	}
+
+	specs := make([]ChainSpec, 0, len(names))
+	for i, name := range names {
+		chainConfig := cfg.Context[CONTEXT].Chains[name]
+		if chainConfig.Fork == nil {
+			return nil, fmt.Errorf("fork not set for %s; set fork in ./config/contexts/devnet.yaml", name)
+		}
+
+		// Get the chain_id from env/config
+		chainId, err := GetDevnetChainIdOrDefault(cfg, name)
+		if err != nil {
+			chainId = common.DefaultAnvilChainId
+		}
+
+		// Get the block_time from env/config
+		blockTime, err := GetDevnetBlockTimeOrDefault(cfg, name)
+		if err != nil {
+			blockTime = 12
+		}
+
+		// An unset fork url is left empty; callers decide whether a fresh chain is acceptable
+		forkUrl, _ := GetDevnetForkUrlDefault(cfg, name)
+
+		specs = append(specs, ChainSpec{
+			Name:      name,
+			ChainID:   chainId,
+			BlockTime: blockTime,
+			ForkURL:   forkUrl,
+			ForkBlock: chainConfig.Fork.Block,
+			Port:      basePort + i,
+		})
+	}
+	return specs, nil
+}
+
+// AnvilArgs returns the anvil arguments for this chain appended to baseArgs.
+// The fork url is rewritten so it is reachable from inside the container; an empty ForkURL starts a fresh chain.
+func (c ChainSpec) AnvilArgs(baseArgs string) string {
+	forkUrl := c.ForkURL
+	if forkUrl != "" {
+		forkUrl = EnsureDockerHost(forkUrl)
+	}
+	return c.anvilArgs(baseArgs, forkUrl)
+}
+
+// HostAnvilArgs returns the anvil arguments for this chain when anvil runs directly on the host,
+// listening on the chain's port on localhost and forking from the fork url as configured.
+func (c ChainSpec) HostAnvilArgs(baseArgs string) string {
+	return c.anvilArgs(fmt.Sprintf("--host 127.0.0.1 --port %d %s", c.Port, baseArgs), c.ForkURL)
+}
+
+func (c ChainSpec) anvilArgs(baseArgs, forkUrl string) string {
+	args := strings.TrimSpace(baseArgs)
+	if forkUrl != "" {
+		args = fmt.Sprintf("%s --fork-url %s --fork-block-number %d", args, forkUrl, c.ForkBlock)
+	}
+	args = fmt.Sprintf("%s --chain-id %d", args, c.ChainID)
+	args = fmt.Sprintf("%s --block-time %d", args, c.BlockTime)
+	return strings.TrimSpace(args)
+}
+
+// GetDevnetContainerName returns the container name for a chain of the project's devnet.
+// L1 keeps the unsuffixed name so existing tooling continues to find it.
+func GetDevnetContainerName(projectName, chainName string) string {
+	if chainName == L1 {
+		return fmt.Sprintf("devkit-devnet-%s", projectName)
+	}
+	return fmt.Sprintf("devkit-devnet-%s-%s", projectName, chainName)
+}
+
+// GetDevnetComposeProjectName returns the docker compose project used for a chain of the project's devnet
+func GetDevnetComposeProjectName(projectName, chainName string) string {
+	if chainName == L1 {
+		return projectName
+	}
+	return fmt.Sprintf("%s-%s", projectName, chainName)
+}
@@ -0,0 +1,66 @@
+package devnet
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDevnetChains(t *testing.T) {
+	t.Setenv("L1_FORK_URL", "")
+	t.Setenv("L2_FORK_URL", "")
+
+	cfg := &common.ConfigWithContextConfig{
+		Context: map[string]common.ChainContextConfig{
+			CONTEXT: {
+				Chains: map[string]common.ChainConfig{
+					"l2": {ChainID: 31338, Fork: &common.ForkConfig{Url: "http://localhost:9545", Block: 200, BlockTime: 2}},
+					"l1": {ChainID: 31337, Fork: &common.ForkConfig{Url: "https://eth.example", Block: 100, BlockTime: 12}},
+					"l3": {ChainID: 31339, Fork: &common.ForkConfig{BlockTime: 1}},
+				},
+			},
+		},
+	}
+
+	chains, err := ResolveDevnetChains(cfg, 8545)
+	require.NoError(t, err)
+	require.Len(t, chains, 3)
+
+	assert.Equal(t, ChainSpec{Name: "l1", ChainID: 31337, BlockTime: 12, ForkURL: "https://eth.example", ForkBlock: 100, Port: 8545}, chains[0])
+	assert.Equal(t, ChainSpec{Name: "l2", ChainID: 31338, BlockTime: 2, ForkURL: "http://localhost:9545", ForkBlock: 200, Port: 8546}, chains[1])
+	assert.Equal(t, "l3", chains[2].Name)
+	assert.Equal(t, 8547, chains[2].Port)
+	assert.Empty(t, chains[2].ForkURL)
+
+	assert.Equal(t,
+		"--base-fee 1 --fork-url https://eth.example --fork-block-number 100 --chain-id 31337 --block-time 12",
+		chains[0].AnvilArgs(" --base-fee 1 "),
+	)
+	assert.Equal(t, "--chain-id 31339 --block-time 1", chains[2].AnvilArgs(""))
+
+	// Anvil run on the host listens on the chain's port and reaches a local fork url as configured
+	assert.Equal(t,
+		"--host 127.0.0.1 --port 8546 --base-fee 1 --fork-url http://localhost:9545 --fork-block-number 200 --chain-id 31338 --block-time 2",
+		chains[1].HostAnvilArgs("--base-fee 1"),
+	)
+}
+
+func TestResolveDevnetChains_MissingL1(t *testing.T) {
+	cfg := &common.ConfigWithContextConfig{
+		Context: map[string]common.ChainContextConfig{
+			CONTEXT: {Chains: map[string]common.ChainConfig{"l2": {ChainID: 1, Fork: &common.ForkConfig{}}}},
+		},
+	}
+	_, err := ResolveDevnetChains(cfg, 8545)
+	assert.Error(t, err)
+}
+
+func TestGetDevnetContainerName(t *testing.T) {
+	assert.Equal(t, "devkit-devnet-my-avs", GetDevnetContainerName("my-avs", L1))
+	assert.Equal(t, "devkit-devnet-my-avs-l2", GetDevnetContainerName("my-avs", "l2"))
+	assert.Equal(t, "my-avs", GetDevnetComposeProjectName("my-avs", L1))
+	assert.Equal(t, "my-avs-l2", GetDevnetComposeProjectName("my-avs", "l2"))
+}
@@ -1,5 +1,7 @@
 package devnet
 
+import "time"
+
 // Foundry Image Date : 21 April 2025
 const FOUNDRY_IMAGE = "ghcr.io/foundry-rs/foundry:stable"
 const CHAIN_ARGS = "--gas-limit 140000000 --base-fee 9400000"
@@ -7,6 +9,21 @@ const FUND_VALUE = "10000000000000000000"
 const CONTEXT = "devnet"
 const L1 = "l1"
 
+// Readiness probing defaults for a freshly started devnet
+const DEVNET_READY_TIMEOUT = 60 * time.Second
+const DEVNET_READY_POLL_INTERVAL = 500 * time.Millisecond
+const DEVNET_READY_PROBE_TIMEOUT = 5 * time.Second
+
 // These are fallback EigenLayer deployment addresses when not specified in context
 const ALLOCATION_MANAGER_ADDRESS = "0x948a420b8CC1d6BFd0B6087C2E7c344a2CD0bc39"
 const DELEGATION_MANAGER_ADDRESS = "0x39053D51B77DC0d36036Fc1fCc8Cb819df8Ef37A"
+const REWARDS_COORDINATOR_ADDRESS = "0x7750d328b314EfFa365A0402CcfD489B80B0adda"
+
+// Virtual strategy for native restaked ETH; it cannot be deposited into through the StrategyManager
+const BEACON_CHAIN_ETH_STRATEGY_ADDRESS = "0xbeaC0eeEeeeeEEeEeEEEEeeEEeEeeeEeeEEBEaC0"
+
+// Parameters of the EigenLayer core deployed by `devnet start --no-fork`; delays are in blocks and kept short for local testing
+const EIGENLAYER_LOCAL_VERSION = "v1.4.2"
+const EIGENLAYER_LOCAL_MIN_WITHDRAWAL_DELAY = 5
+const EIGENLAYER_LOCAL_DEALLOCATION_DELAY = 5
+const EIGENLAYER_LOCAL_ALLOCATION_CONFIGURATION_DELAY = 1
@@ -0,0 +1,237 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+)
+
+// Labels the devnet compose file puts on every chain container; discovery relies on these rather than on names
+const (
+	DEVNET_LABEL_PROJECT = "devkit.devnet.project"
+	DEVNET_LABEL_CHAIN   = "devkit.devnet.chain"
+	DEVNET_LABEL_VERSION = "devkit.devnet.version"
+)
+
+// ANVIL_CONTAINER_PORT is the port anvil listens on inside a devnet container
+const ANVIL_CONTAINER_PORT = 8545
+
+// ErrContainerNotFound is returned when Docker has no container by the requested id or name
+var ErrContainerNotFound = errors.New("container not found")
+
+// DevnetContainer is a devnet chain container as discovered through its labels
+type DevnetContainer struct {
+	ID            string
+	Name          string
+	Project       string
+	Chain         string
+	DevkitVersion string
+	// HostPorts are the host ports anvil's RPC port is published on
+	HostPorts []int
+	Running   bool
+	Created   time.Time
+	// Backend is the devnet backend running the chain
+	Backend string
+}
+
+// HostPort returns the first host port anvil's RPC is published on, or 0 when it is not published
+func (c DevnetContainer) HostPort() int {
+	if len(c.HostPorts) == 0 {
+		return 0
+	}
+	return c.HostPorts[0]
+}
+
+// ContainerFilter narrows which devnet containers List returns
+type ContainerFilter struct {
+	// Project limits the result to one project's devnet
+	Project string
+	// Chain limits the result to one chain
+	Chain string
+	// All includes stopped containers
+	All bool
+}
+
+// ContainerManager discovers and controls devnet chain containers
+type ContainerManager interface {
+	// List returns the devnet containers matching filter
+	List(ctx context.Context, filter ContainerFilter) ([]DevnetContainer, error)
+	// Get returns the devnet container with the given id or name, or ErrContainerNotFound
+	Get(ctx context.Context, ref string) (*DevnetContainer, error)
+	// Stop stops a container; stopping a stopped container is not an error
+	Stop(ctx context.Context, ref string) error
+	// Remove removes a stopped container
+	Remove(ctx context.Context, ref string) error
+	Close() error
+}
+
+// NewContainerManager returns the ContainerManager the devnet commands use. Tests replace it to inject a fake.
+var NewContainerManager = NewDevnetContainerManager
+
+// dockerContainerManager talks to the Docker Engine API
+type dockerContainerManager struct {
+	cli *client.Client
+}
+
+// NewDockerContainerManager returns a ContainerManager backed by the Docker Engine API of the environment's daemon
+func NewDockerContainerManager() (ContainerManager, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	return &dockerContainerManager{cli: cli}, nil
+}
+
+func (m *dockerContainerManager) List(ctx context.Context, filter ContainerFilter) ([]DevnetContainer, error) {
+	args := filters.NewArgs(filters.Arg("label", DEVNET_LABEL_PROJECT))
+	if filter.Project != "" {
+		args.Add("label", DEVNET_LABEL_PROJECT+"="+filter.Project)
+	}
+	if filter.Chain != "" {
+		args.Add("label", DEVNET_LABEL_CHAIN+"="+filter.Chain)
+	}
+
+	summaries, err := m.cli.ContainerList(ctx, container.ListOptions{All: filter.All, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devnet containers: %w", err)
+	}
+	containers := make([]DevnetContainer, 0, len(summaries))
+	for _, summary := range summaries {
+		containers = append(containers, devnetContainerFromSummary(summary))
+	}
+	SortDevnetContainers(containers)
+	return containers, nil
+}
+
+func (m *dockerContainerManager) Get(ctx context.Context, ref string) (*DevnetContainer, error) {
+	resp, err := m.cli.ContainerInspect(ctx, ref)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w", ref, err)
+	}
+
+	c := &DevnetContainer{
+		ID:      resp.ID,
+		Name:    strings.TrimPrefix(resp.Name, "/"),
+		Backend: BACKEND_DOCKER,
+	}
+	if resp.Config != nil {
+		c.Project = resp.Config.Labels[DEVNET_LABEL_PROJECT]
+		c.Chain = resp.Config.Labels[DEVNET_LABEL_CHAIN]
+		c.DevkitVersion = resp.Config.Labels[DEVNET_LABEL_VERSION]
+	}
+	if resp.State != nil {
+		c.Running = resp.State.Running
+	}
+	if created, err := time.Parse(time.RFC3339Nano, resp.Created); err == nil {
+		c.Created = created
+	}
+	if resp.NetworkSettings != nil {
+		for port, bindings := range resp.NetworkSettings.Ports {
+			if port.Int() != ANVIL_CONTAINER_PORT {
+				continue
+			}
+			for _, binding := range bindings {
+				if hostPort, err := strconv.Atoi(binding.HostPort); err == nil {
+					c.HostPorts = appendUniquePort(c.HostPorts, hostPort)
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func (m *dockerContainerManager) Stop(ctx context.Context, ref string) error {
+	if err := m.cli.ContainerStop(ctx, ref, container.StopOptions{}); err != nil {
+		if errdefs.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+		}
+		return fmt.Errorf("failed to stop container %s: %w", ref, err)
+	}
+	return nil
+}
+
+func (m *dockerContainerManager) Remove(ctx context.Context, ref string) error {
+	if err := m.cli.ContainerRemove(ctx, ref, container.RemoveOptions{}); err != nil {
+		if errdefs.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+		}
+		return fmt.Errorf("failed to remove container %s: %w", ref, err)
+	}
+	return nil
+}
+
+func (m *dockerContainerManager) Close() error {
+	return m.cli.Close()
+}
+
+// devnetContainerFromSummary converts a container list entry, reading every published mapping of anvil's port
+func devnetContainerFromSummary(summary container.Summary) DevnetContainer {
+	c := DevnetContainer{
+		ID:            summary.ID,
+		Project:       summary.Labels[DEVNET_LABEL_PROJECT],
+		Chain:         summary.Labels[DEVNET_LABEL_CHAIN],
+		DevkitVersion: summary.Labels[DEVNET_LABEL_VERSION],
+		Running:       summary.State == "running",
+		Created:       time.Unix(summary.Created, 0).UTC(),
+		Backend:       BACKEND_DOCKER,
+	}
+	if len(summary.Names) > 0 {
+		c.Name = strings.TrimPrefix(summary.Names[0], "/")
+	}
+	for _, port := range summary.Ports {
+		if int(port.PrivatePort) == ANVIL_CONTAINER_PORT && port.PublicPort != 0 {
+			c.HostPorts = appendUniquePort(c.HostPorts, int(port.PublicPort))
+		}
+	}
+	return c
+}
+
+// appendUniquePort appends port unless present; Docker reports IPv4 and IPv6 bindings of a port separately
+func appendUniquePort(ports []int, port int) []int {
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+	return append(ports, port)
+}
+
+// SortDevnetContainers orders containers by project, L1 first within a project and the other chains sorted
+func SortDevnetContainers(containers []DevnetContainer) {
+	sort.SliceStable(containers, func(i, j int) bool {
+		a, b := containers[i], containers[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Chain == L1 || b.Chain == L1 {
+			return a.Chain == L1 && b.Chain != L1
+		}
+		return a.Chain < b.Chain
+	})
+}
+
+// StopAndRemoveDevnetContainer stops and removes a container. A container that is already gone is not an error.
+func StopAndRemoveDevnetContainer(ctx context.Context, manager ContainerManager, ref string) error {
+	if err := manager.Stop(ctx, ref); err != nil {
+		if errors.Is(err, ErrContainerNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := manager.Remove(ctx, ref); err != nil && !errors.Is(err, ErrContainerNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeContainerManager is an in-memory ContainerManager for tests that cannot reach a Docker daemon
+type FakeContainerManager struct {
+	mu         sync.Mutex
+	containers []DevnetContainer
+	// Stopped and Removed record the refs passed to Stop and Remove, in call order
+	Stopped []string
+	Removed []string
+}
+
+// NewFakeContainerManager returns a FakeContainerManager that knows the given containers
+func NewFakeContainerManager(containers ...DevnetContainer) *FakeContainerManager {
+	return &FakeContainerManager{containers: append([]DevnetContainer{}, containers...)}
+}
+
+func (f *FakeContainerManager) List(ctx context.Context, filter ContainerFilter) ([]DevnetContainer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := []DevnetContainer{}
+	for _, c := range f.containers {
+		if filter.Project != "" && c.Project != filter.Project {
+			continue
+		}
+		if filter.Chain != "" && c.Chain != filter.Chain {
+			continue
+		}
+		if !filter.All && !c.Running {
+			continue
+		}
+		result = append(result, c)
+	}
+	SortDevnetContainers(result)
+	return result, nil
+}
+
+func (f *FakeContainerManager) Get(ctx context.Context, ref string) (*DevnetContainer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.find(ref)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+	}
+	c := f.containers[i]
+	return &c, nil
+}
+
+func (f *FakeContainerManager) Stop(ctx context.Context, ref string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Stopped = append(f.Stopped, ref)
+	i := f.find(ref)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+	}
+	f.containers[i].Running = false
+	return nil
+}
+
+func (f *FakeContainerManager) Remove(ctx context.Context, ref string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Removed = append(f.Removed, ref)
+	i := f.find(ref)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+	}
+	if f.containers[i].Running {
+		return fmt.Errorf("container %s is running", ref)
+	}
+	f.containers = append(f.containers[:i], f.containers[i+1:]...)
+	return nil
+}
+
+func (f *FakeContainerManager) Close() error {
+	return nil
+}
+
+// find returns the index of the container with the given id or name, or -1
+func (f *FakeContainerManager) find(ref string) int {
+	for i, c := range f.containers {
+		if c.ID == ref || c.Name == ref {
+			return i
+		}
+	}
+	return -1
+}
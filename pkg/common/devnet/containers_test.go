@@ -0,0 +1,70 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevnetContainerFromSummary(t *testing.T) {
+	c := devnetContainerFromSummary(container.Summary{
+		ID:      "abc123",
+		Names:   []string{"/devkit-devnet-my-avs-l2"},
+		Created: 1748779200,
+		State:   "running",
+		Labels: map[string]string{
+			DEVNET_LABEL_PROJECT: "my-avs",
+			DEVNET_LABEL_CHAIN:   "l2",
+			DEVNET_LABEL_VERSION: "v0.1.0",
+		},
+		// IPv4 and IPv6 bindings of the same port, plus an unrelated mapping
+		Ports: []container.Port{
+			{IP: "0.0.0.0", PrivatePort: 8545, PublicPort: 8546, Type: "tcp"},
+			{IP: "::", PrivatePort: 8545, PublicPort: 8546, Type: "tcp"},
+			{IP: "0.0.0.0", PrivatePort: 9000, PublicPort: 9100, Type: "tcp"},
+			{PrivatePort: 8546, Type: "tcp"},
+		},
+	})
+
+	assert.Equal(t, "abc123", c.ID)
+	assert.Equal(t, "devkit-devnet-my-avs-l2", c.Name)
+	assert.Equal(t, "my-avs", c.Project)
+	assert.Equal(t, "l2", c.Chain)
+	assert.Equal(t, "v0.1.0", c.DevkitVersion)
+	assert.Equal(t, []int{8546}, c.HostPorts)
+	assert.Equal(t, 8546, c.HostPort())
+	assert.True(t, c.Running)
+	assert.True(t, c.Created.Equal(time.Unix(1748779200, 0)))
+}
+
+func TestFakeContainerManager(t *testing.T) {
+	ctx := context.Background()
+	manager := NewFakeContainerManager(
+		DevnetContainer{ID: "2", Name: "devkit-devnet-a-l2", Project: "a", Chain: "l2", Running: true},
+		DevnetContainer{ID: "1", Name: "devkit-devnet-a", Project: "a", Chain: L1, Running: true},
+		DevnetContainer{ID: "3", Name: "devkit-devnet-b", Project: "b", Chain: L1},
+	)
+
+	running, err := manager.List(ctx, ContainerFilter{})
+	require.NoError(t, err)
+	require.Len(t, running, 2)
+	assert.Equal(t, L1, running[0].Chain, "L1 is listed first")
+
+	all, err := manager.List(ctx, ContainerFilter{Project: "b", All: true})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	require.NoError(t, StopAndRemoveDevnetContainer(ctx, manager, "devkit-devnet-a"))
+	_, err = manager.Get(ctx, "1")
+	assert.True(t, errors.Is(err, ErrContainerNotFound))
+
+	// A container that is already gone is not an error
+	require.NoError(t, StopAndRemoveDevnetContainer(ctx, manager, "devkit-devnet-missing"))
+	assert.Equal(t, []string{"devkit-devnet-a", "devkit-devnet-missing"}, manager.Stopped)
+	assert.Equal(t, []string{"devkit-devnet-a"}, manager.Removed)
+}
@@ -0,0 +1,102 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// dealMaxSlot bounds the storage slots probed for an ERC20 balances mapping
+const dealMaxSlot = 32
+
+// DealERC20 ensures holder owns at least amount of token on a forked devnet. When short it locates the
+// token's balances mapping and writes the slot directly, the same approach as forge's `deal` cheatcode.
+// Both Solidity (keccak(holder . slot)) and Vyper (keccak(slot . holder)) mapping layouts are probed. A slot is
+// accepted once balanceOf reports at least amount, so tokens that scale stored shares into balances work too.
+func DealERC20(ctx context.Context, rpcURL string, token, holder common.Address, amount *big.Int) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to devnet at %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	balance, err := erc20BalanceOf(ctx, client, token, holder)
+	if err != nil {
+		return fmt.Errorf("failed to read %s balance of %s: %w", token.Hex(), holder.Hex(), err)
+	}
+	if balance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	anvil, err := DialAnvil(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	holderWord := common.LeftPadBytes(holder.Bytes(), 32)
+	value := common.BigToHash(amount)
+
+	for i := int64(0); i < dealMaxSlot; i++ {
+		slotWord := common.LeftPadBytes(big.NewInt(i).Bytes(), 32)
+		for _, key := range []common.Hash{
+			crypto.Keccak256Hash(holderWord, slotWord),
+			crypto.Keccak256Hash(slotWord, holderWord),
+		} {
+			original, err := client.StorageAt(ctx, token, key, nil)
+			if err != nil {
+				return fmt.Errorf("failed to read storage of %s: %w", token.Hex(), err)
+			}
+			if err := anvil.SetStorageAt(ctx, token, key, value); err != nil {
+				return err
+			}
+
+			dealt, err := erc20BalanceOf(ctx, client, token, holder)
+			if err == nil && dealt.Cmp(amount) >= 0 {
+				return nil
+			}
+			// Rebasing and share-based tokens (e.g. stETH) report shares times a rate, so the written value
+			// comes back scaled. Any change means this is the balances slot; write enough shares to cover amount.
+			if err == nil && dealt.Sign() > 0 && dealt.Cmp(balance) != 0 {
+				shares := new(big.Int).Div(new(big.Int).Mul(amount, amount), dealt)
+				shares.Add(shares, big.NewInt(1))
+				if err := anvil.SetStorageAt(ctx, token, key, common.BigToHash(shares)); err != nil {
+					return err
+				}
+				if dealt, err := erc20BalanceOf(ctx, client, token, holder); err == nil && dealt.Cmp(amount) >= 0 {
+					return nil
+				}
+			}
+
+			// Not the balances slot, put it back
+			if err := anvil.SetStorageAt(ctx, token, key, common.BytesToHash(original)); err != nil {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("could not locate the balances mapping of token %s; fund %s with it manually", token.Hex(), holder.Hex())
+}
+
+// erc20BalanceOf reads the token balance of holder
+func erc20BalanceOf(ctx context.Context, client *ethclient.Client, token, holder common.Address) (*big.Int, error) {
+	data, err := devkitcommon.ERC20ABI.Pack("balanceOf", holder)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err := devkitcommon.ERC20ABI.Unpack("balanceOf", out)
+	if err != nil {
+		return nil, err
+	}
+	return values[0].(*big.Int), nil
+}
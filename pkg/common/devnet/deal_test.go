@@ -0,0 +1,79 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startShareToken serves a token whose balances mapping sits at slot 2 and whose balanceOf reports the stored
+// shares times num/den, like a rebasing token
+func startShareToken(t *testing.T, num, den int64) (string, func(common.Hash) *big.Int) {
+	t.Helper()
+	storage := make(map[common.Hash]common.Hash)
+	srv := rpctest.NewServer(t, rpctest.Handlers{
+		"eth_call": func(params []json.RawMessage) (any, error) {
+			var call struct {
+				Input hexutil.Bytes `json:"input"`
+			}
+			require.NoError(t, json.Unmarshal(params[0], &call))
+			holder := common.BytesToAddress(call.Input[len(call.Input)-20:])
+			key := crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), 32), common.LeftPadBytes([]byte{2}, 32))
+			balance := new(big.Int).Mul(storage[key].Big(), big.NewInt(num))
+			balance.Div(balance, big.NewInt(den))
+			return common.BigToHash(balance), nil
+		},
+		"eth_getStorageAt": func(params []json.RawMessage) (any, error) {
+			var key common.Hash
+			require.NoError(t, json.Unmarshal(params[1], &key))
+			return storage[key], nil
+		},
+		"anvil_setStorageAt": func(params []json.RawMessage) (any, error) {
+			var key, value common.Hash
+			require.NoError(t, json.Unmarshal(params[1], &key))
+			require.NoError(t, json.Unmarshal(params[2], &value))
+			storage[key] = value
+			return true, nil
+		},
+	})
+
+	// Handlers have returned by the time DealERC20 does, so the map is read without racing them
+	return srv.URL, func(key common.Hash) *big.Int {
+		return storage[key].Big()
+	}
+}
+
+func TestDealERC20ShareTokens(t *testing.T) {
+	token := common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	holder := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	balancesKey := crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), 32), common.LeftPadBytes([]byte{2}, 32))
+	amount := big.NewInt(1_000_000)
+
+	tests := []struct {
+		name     string
+		num, den int64
+	}{
+		{"plain", 1, 1},
+		{"rate above one", 3, 2},
+		{"rate below one", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, stored := startShareToken(t, tt.num, tt.den)
+			require.NoError(t, DealERC20(context.Background(), url, token, holder, amount))
+
+			balance := new(big.Int).Mul(stored(balancesKey), big.NewInt(tt.num))
+			balance.Div(balance, big.NewInt(tt.den))
+			assert.GreaterOrEqual(t, balance.Cmp(amount), 0, "balance %s covers %s", balance, amount)
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	avsdirectory "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AVSDirectory"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	eigenpodmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/EigenPodManager"
+	pauserregistry "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/PauserRegistry"
+	permissioncontroller "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/PermissionController"
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// EigenLayerCoreAddresses are the core contracts of a locally deployed EigenLayer
+type EigenLayerCoreAddresses struct {
+	PauserRegistry       common.Address
+	PermissionController common.Address
+	DelegationManager    common.Address
+	StrategyManager      common.Address
+	AllocationManager    common.Address
+	AVSDirectory         common.Address
+	EigenPodManager      common.Address
+	RewardsCoordinator   common.Address
+}
+
+// EigenLayerConfig converts the addresses into the context's eigenlayer block
+func (a *EigenLayerCoreAddresses) EigenLayerConfig() *devkitcommon.EigenLayerConfig {
+	return &devkitcommon.EigenLayerConfig{
+		AllocationManager:    a.AllocationManager.Hex(),
+		DelegationManager:    a.DelegationManager.Hex(),
+		StrategyManager:      a.StrategyManager.Hex(),
+		AVSDirectory:         a.AVSDirectory.Hex(),
+		RewardsCoordinator:   a.RewardsCoordinator.Hex(),
+		PermissionController: a.PermissionController.Hex(),
+	}
+}
+
+// SetContextEigenLayer replaces the eigenlayer block of a context mapping node for the current run, keeping its
+// key and comments. The replaced block is recorded as an override made by reason and restored on the next start.
+func SetContextEigenLayer(contextNode *yaml.Node, el *devkitcommon.EigenLayerConfig, reason string) error {
+	valNode, err := devkitcommon.InterfaceToNode(el)
+	if err != nil {
+		return fmt.Errorf("failed to encode eigenlayer addresses: %w", err)
+	}
+	return OverrideContextValue(contextNode, []string{"eigenlayer"}, valNode, reason)
+}
+
+// Rewards parameters match mainnet so rewards submissions behave the same locally
+const (
+	rewardsCalculationIntervalSeconds = 86400
+	rewardsMaxRewardsDuration         = 6048000
+	rewardsMaxRetroactiveLength       = 14515200
+	rewardsMaxFutureLength            = 2592000
+	rewardsGenesisTimestamp           = 1710979200
+	rewardsDefaultSplitBips           = 1000
+)
+
+// eigenLayerCoreDeployCount is the number of contract creations made by DeployEigenLayerCore, in nonce order
+const eigenLayerCoreDeployCount = 8
+
+// PlanEigenLayerCore returns the addresses the core contracts will be created at when the deployer starts
+// deploying at nonce. Knowing them up front resolves the circular constructor references between contracts.
+func PlanEigenLayerCore(deployer common.Address, nonce uint64) *EigenLayerCoreAddresses {
+	at := func(i uint64) common.Address { return crypto.CreateAddress(deployer, nonce+i) }
+	return &EigenLayerCoreAddresses{
+		PauserRegistry:       at(0),
+		PermissionController: at(1),
+		DelegationManager:    at(2),
+		StrategyManager:      at(3),
+		AllocationManager:    at(4),
+		AVSDirectory:         at(5),
+		EigenPodManager:      at(6),
+		RewardsCoordinator:   at(7),
+	}
+}
+
+// DeployEigenLayerCore deploys and initializes the EigenLayer core contracts on a fresh anvil chain using the
+// eigenlayer-contracts bindings. The contracts are deployed without proxies: their initializer lock is cleared
+// with anvil_setStorageAt so they can be initialized directly, owned by the deployer and unpaused. Every
+// transaction is signed by signer.
+func DeployEigenLayerCore(ctx context.Context, logger iface.Logger, rpcURL string, signer devkitcommon.Signer) (*EigenLayerCoreAddresses, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to devnet at %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	anvil, err := DialAnvil(ctx, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer anvil.Close()
+
+	deployer := signer.Address()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain id: %w", err)
+	}
+	auth := devkitcommon.NewTransactOpts(ctx, signer, chainID)
+
+	// A fresh chain only prefunds anvil's own accounts, so make sure a custom deployer can pay for the deployment
+	deployerBalance, _ := new(big.Int).SetString(FUND_VALUE, 10)
+	if err := anvil.SetBalance(ctx, deployer, new(big.Int).Mul(deployerBalance, big.NewInt(100))); err != nil {
+		return nil, err
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, deployer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployer nonce: %w", err)
+	}
+	addrs := PlanEigenLayerCore(deployer, nonce)
+
+	logger.Info("Deploying EigenLayer core contracts (%s) from %s", EIGENLAYER_LOCAL_VERSION, deployer.Hex())
+	if err := deployEigenLayerContracts(ctx, client, auth, nonce, deployer, addrs); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Initializing EigenLayer core contracts")
+	if err := initializeEigenLayerContracts(ctx, client, anvil, auth, deployer, addrs); err != nil {
+		return nil, err
+	}
+
+	logger.Info("EigenLayer core deployed: AllocationManager %s, DelegationManager %s", addrs.AllocationManager.Hex(), addrs.DelegationManager.Hex())
+	return addrs, nil
+}
+
+// deployEigenLayerContracts sends every creation with an explicit nonce so each lands at its planned address,
+// then waits for all of them
+func deployEigenLayerContracts(ctx context.Context, client *ethclient.Client, auth *bind.TransactOpts, nonce uint64, deployer common.Address, addrs *EigenLayerCoreAddresses) error {
+	deploys := []struct {
+		name   string
+		expect common.Address
+		deploy func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error)
+	}{
+		{"PauserRegistry", addrs.PauserRegistry, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := pauserregistry.DeployPauserRegistry(opts, client, []common.Address{deployer}, deployer)
+			return addr, tx, err
+		}},
+		{"PermissionController", addrs.PermissionController, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := permissioncontroller.DeployPermissionController(opts, client, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"DelegationManager", addrs.DelegationManager, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := delegationmanager.DeployDelegationManager(opts, client, addrs.StrategyManager, addrs.EigenPodManager, addrs.AllocationManager, addrs.PauserRegistry, addrs.PermissionController, EIGENLAYER_LOCAL_MIN_WITHDRAWAL_DELAY, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"StrategyManager", addrs.StrategyManager, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := strategymanager.DeployStrategyManager(opts, client, addrs.DelegationManager, addrs.PauserRegistry, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"AllocationManager", addrs.AllocationManager, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := allocationmanager.DeployAllocationManager(opts, client, addrs.DelegationManager, addrs.PauserRegistry, addrs.PermissionController, EIGENLAYER_LOCAL_DEALLOCATION_DELAY, EIGENLAYER_LOCAL_ALLOCATION_CONFIGURATION_DELAY, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"AVSDirectory", addrs.AVSDirectory, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := avsdirectory.DeployAVSDirectory(opts, client, addrs.DelegationManager, addrs.PauserRegistry, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"EigenPodManager", addrs.EigenPodManager, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			// Native restaking cannot be simulated on the devnet, so there is no beacon deposit contract or pod beacon
+			addr, tx, _, err := eigenpodmanager.DeployEigenPodManager(opts, client, common.Address{}, common.Address{}, addrs.DelegationManager, addrs.PauserRegistry, EIGENLAYER_LOCAL_VERSION)
+			return addr, tx, err
+		}},
+		{"RewardsCoordinator", addrs.RewardsCoordinator, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := rewardscoordinator.DeployRewardsCoordinator(opts, client, rewardscoordinator.IRewardsCoordinatorTypesRewardsCoordinatorConstructorParams{
+				DelegationManager:          addrs.DelegationManager,
+				StrategyManager:            addrs.StrategyManager,
+				AllocationManager:          addrs.AllocationManager,
+				PauserRegistry:             addrs.PauserRegistry,
+				PermissionController:       addrs.PermissionController,
+				CALCULATIONINTERVALSECONDS: rewardsCalculationIntervalSeconds,
+				MAXREWARDSDURATION:         rewardsMaxRewardsDuration,
+				MAXRETROACTIVELENGTH:       rewardsMaxRetroactiveLength,
+				MAXFUTURELENGTH:            rewardsMaxFutureLength,
+				GENESISREWARDSTIMESTAMP:    rewardsGenesisTimestamp,
+				Version:                    EIGENLAYER_LOCAL_VERSION,
+			})
+			return addr, tx, err
+		}},
+	}
+	if len(deploys) != eigenLayerCoreDeployCount {
+		return fmt.Errorf("EigenLayer deployment plan has %d contracts, expected %d", len(deploys), eigenLayerCoreDeployCount)
+	}
+
+	txs := make([]*types.Transaction, len(deploys))
+	for i, d := range deploys {
+		opts := *auth
+		opts.Nonce = new(big.Int).SetUint64(nonce + uint64(i))
+		addr, tx, err := d.deploy(&opts)
+		if err != nil {
+			return fmt.Errorf("failed to deploy %s: %w", d.name, err)
+		}
+		if addr != d.expect {
+			return fmt.Errorf("%s deployed at %s, expected %s", d.name, addr.Hex(), d.expect.Hex())
+		}
+		txs[i] = tx
+	}
+	for i, d := range deploys {
+		if _, err := bind.WaitDeployed(ctx, client, txs[i]); err != nil {
+			return fmt.Errorf("failed to deploy %s: %w", d.name, err)
+		}
+	}
+	return nil
+}
+
+// initializeEigenLayerContracts unlocks and initializes every contract that has an initializer
+func initializeEigenLayerContracts(ctx context.Context, client *ethclient.Client, anvil *AnvilClient, auth *bind.TransactOpts, deployer common.Address, addrs *EigenLayerCoreAddresses) error {
+	noPause := big.NewInt(0)
+	inits := []struct {
+		name string
+		addr common.Address
+		init func(opts *bind.TransactOpts) (*types.Transaction, error)
+	}{
+		{"DelegationManager", addrs.DelegationManager, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := delegationmanager.NewDelegationManagerTransactor(addrs.DelegationManager, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, noPause)
+		}},
+		{"StrategyManager", addrs.StrategyManager, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := strategymanager.NewStrategyManagerTransactor(addrs.StrategyManager, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, deployer, noPause)
+		}},
+		{"AllocationManager", addrs.AllocationManager, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := allocationmanager.NewAllocationManagerTransactor(addrs.AllocationManager, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, noPause)
+		}},
+		{"AVSDirectory", addrs.AVSDirectory, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := avsdirectory.NewAVSDirectoryTransactor(addrs.AVSDirectory, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, noPause)
+		}},
+		{"EigenPodManager", addrs.EigenPodManager, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := eigenpodmanager.NewEigenPodManagerTransactor(addrs.EigenPodManager, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, noPause)
+		}},
+		{"RewardsCoordinator", addrs.RewardsCoordinator, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			c, err := rewardscoordinator.NewRewardsCoordinatorTransactor(addrs.RewardsCoordinator, client)
+			if err != nil {
+				return nil, err
+			}
+			return c.Initialize(opts, deployer, noPause, deployer, 0, rewardsDefaultSplitBips)
+		}},
+	}
+
+	for _, in := range inits {
+		if err := clearInitializerLock(ctx, client, anvil, in.addr); err != nil {
+			return fmt.Errorf("failed to unlock %s: %w", in.name, err)
+		}
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, deployer)
+	if err != nil {
+		return fmt.Errorf("failed to get deployer nonce: %w", err)
+	}
+	txs := make([]*types.Transaction, len(inits))
+	for i, in := range inits {
+		opts := *auth
+		opts.Nonce = new(big.Int).SetUint64(nonce + uint64(i))
+		tx, err := in.init(&opts)
+		if err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", in.name, err)
+		}
+		txs[i] = tx
+	}
+	for i, in := range inits {
+		receipt, err := bind.WaitMined(ctx, client, txs[i])
+		if err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", in.name, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("initialize of %s reverted", in.name)
+		}
+	}
+	return nil
+}
+
+// clearInitializerLock resets the OpenZeppelin Initializable flags in storage slot 0, which an implementation's
+// constructor sets to "disabled", so the contract can be initialized without a proxy in front of it
+func clearInitializerLock(ctx context.Context, client *ethclient.Client, anvil *AnvilClient, addr common.Address) error {
+	slot := common.Hash{}
+	value, err := client.StorageAt(ctx, addr, slot, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read initializer slot: %w", err)
+	}
+
+	// _initialized is the lowest byte of the slot and is 0xff once initializers are disabled
+	state := common.BytesToHash(value)
+	switch state[common.HashLength-1] {
+	case 0:
+		return nil
+	case 0xff:
+		return anvil.SetStorageAt(ctx, addr, slot, common.Hash{})
+	default:
+		return fmt.Errorf("unexpected initializer state %s", state.Hex())
+	}
+}
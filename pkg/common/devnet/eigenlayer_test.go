@@ -0,0 +1,184 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+
+	avsdirectory "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AVSDirectory"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	eigenpodmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/EigenPodManager"
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPlanEigenLayerCore(t *testing.T) {
+	deployer := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	addrs := PlanEigenLayerCore(deployer, 3)
+
+	// Creations follow the deployer's nonces in deployment order
+	assert.Equal(t, crypto.CreateAddress(deployer, 3), addrs.PauserRegistry)
+	assert.Equal(t, crypto.CreateAddress(deployer, 5), addrs.DelegationManager)
+	assert.Equal(t, crypto.CreateAddress(deployer, 7), addrs.AllocationManager)
+	assert.Equal(t, crypto.CreateAddress(deployer, 3+eigenLayerCoreDeployCount-1), addrs.RewardsCoordinator)
+
+	seen := map[common.Address]bool{}
+	for _, addr := range []common.Address{
+		addrs.PauserRegistry, addrs.PermissionController, addrs.DelegationManager, addrs.StrategyManager,
+		addrs.AllocationManager, addrs.AVSDirectory, addrs.EigenPodManager, addrs.RewardsCoordinator,
+	} {
+		assert.False(t, seen[addr], "duplicate planned address %s", addr.Hex())
+		seen[addr] = true
+	}
+}
+
+func TestSetContextEigenLayer(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+name: "devnet"
+# Core EigenLayer contract addresses
+eigenlayer:
+  allocation_manager: "0x948a420b8CC1d6BFd0B6087C2E7c344a2CD0bc39"
+  delegation_manager: "0x39053D51B77DC0d36036Fc1fCc8Cb819df8Ef37A"
+`), &doc))
+	contextNode := doc.Content[0]
+
+	addrs := PlanEigenLayerCore(common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), 0)
+	require.NoError(t, SetContextEigenLayer(contextNode, addrs.EigenLayerConfig(), "--no-fork"))
+
+	out, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "# Core EigenLayer contract addresses\neigenlayer:")
+
+	var decoded struct {
+		EigenLayer map[string]string `yaml:"eigenlayer"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &decoded))
+	assert.Equal(t, addrs.AllocationManager.Hex(), decoded.EigenLayer["allocation_manager"])
+	assert.Equal(t, addrs.DelegationManager.Hex(), decoded.EigenLayer["delegation_manager"])
+	assert.Equal(t, addrs.RewardsCoordinator.Hex(), decoded.EigenLayer["rewards_coordinator"])
+
+	// The next start puts the replaced block back
+	restored, err := RestoreContextOverrides(contextNode)
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.Equal(t, "--no-fork", restored[0].Reason)
+	eigenLayerNode := devkitcommon.GetChildByKey(contextNode, "eigenlayer")
+	assert.Equal(t, "0x948a420b8CC1d6BFd0B6087C2E7c344a2CD0bc39", devkitcommon.GetChildByKey(eigenLayerNode, "allocation_manager").Value)
+	assert.Nil(t, devkitcommon.GetChildByKey(eigenLayerNode, "rewards_coordinator"))
+}
+
+// startLocalAnvil runs a fresh anvil for the test, skipping it when anvil is not installed
+func startLocalAnvil(t *testing.T) string {
+	t.Helper()
+	anvilPath, err := exec.LookPath("anvil")
+	if err != nil {
+		t.Skip("anvil not found on PATH")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	cmd := exec.Command(anvilPath, "--port", fmt.Sprint(port), "--chain-id", "31337", "--silent")
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	rpcURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.NoError(t, WaitForDevnetReady(context.Background(), logger.NewNoopLogger(), rpcURL, ReadinessOptions{
+		ExpectedChainID: 31337,
+		Timeout:         30 * time.Second,
+	}))
+	return rpcURL
+}
+
+func TestDeployEigenLayerCore(t *testing.T) {
+	rpcURL := startLocalAnvil(t)
+	ctx := context.Background()
+
+	// A deployer anvil does not prefund, so the deployment has to fund it itself
+	signer, err := devkitcommon.NewPrivateKeySigner("0x8b3a350cf5c34c9194ca85829a2df0ec3153be0318b5e2d3348e872092edffba")
+	require.NoError(t, err)
+	addrs, err := DeployEigenLayerCore(ctx, logger.NewNoopLogger(), rpcURL, signer)
+	require.NoError(t, err)
+	assert.Equal(t, PlanEigenLayerCore(signer.Address(), 0), addrs)
+
+	client, err := ethclient.Dial(rpcURL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	all := map[string]common.Address{
+		"PauserRegistry": addrs.PauserRegistry, "PermissionController": addrs.PermissionController,
+		"DelegationManager": addrs.DelegationManager, "StrategyManager": addrs.StrategyManager,
+		"AllocationManager": addrs.AllocationManager, "AVSDirectory": addrs.AVSDirectory,
+		"EigenPodManager": addrs.EigenPodManager, "RewardsCoordinator": addrs.RewardsCoordinator,
+	}
+	for name, addr := range all {
+		code, err := client.CodeAt(ctx, addr, nil)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, code, "%s has no code at %s", name, addr.Hex())
+	}
+
+	// initialize took effect: each contract is owned by the deployer and unpaused
+	type initialized interface {
+		Owner(opts *bind.CallOpts) (common.Address, error)
+		Paused0(opts *bind.CallOpts) (*big.Int, error)
+	}
+	dm, err := delegationmanager.NewDelegationManagerCaller(addrs.DelegationManager, client)
+	require.NoError(t, err)
+	sm, err := strategymanager.NewStrategyManagerCaller(addrs.StrategyManager, client)
+	require.NoError(t, err)
+	am, err := allocationmanager.NewAllocationManagerCaller(addrs.AllocationManager, client)
+	require.NoError(t, err)
+	ad, err := avsdirectory.NewAVSDirectoryCaller(addrs.AVSDirectory, client)
+	require.NoError(t, err)
+	epm, err := eigenpodmanager.NewEigenPodManagerCaller(addrs.EigenPodManager, client)
+	require.NoError(t, err)
+	rc, err := rewardscoordinator.NewRewardsCoordinatorCaller(addrs.RewardsCoordinator, client)
+	require.NoError(t, err)
+
+	for name, c := range map[string]initialized{
+		"DelegationManager": dm, "StrategyManager": sm, "AllocationManager": am,
+		"AVSDirectory": ad, "EigenPodManager": epm, "RewardsCoordinator": rc,
+	} {
+		owner, err := c.Owner(nil)
+		require.NoError(t, err, name)
+		assert.Equal(t, signer.Address(), owner, "%s owner", name)
+		paused, err := c.Paused0(nil)
+		require.NoError(t, err, name)
+		assert.Zero(t, paused.Sign(), "%s is paused", name)
+	}
+
+	whitelister, err := sm.StrategyWhitelister(nil)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), whitelister)
+	updater, err := rc.RewardsUpdater(nil)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), updater)
+}
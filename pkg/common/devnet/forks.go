@@ -0,0 +1,197 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/config"
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/yaml.v3"
+)
+
+// FORK_PRESETS_VERSION is the fork preset registry layout this build understands
+const FORK_PRESETS_VERSION = "0.0.1"
+
+// Fork block policies accepted by a preset's fork_block besides a fixed block number
+const (
+	FORK_BLOCK_LATEST    = "latest"
+	FORK_BLOCK_FINALIZED = "finalized"
+)
+
+// ForkPreset describes how to fork one named network onto a devnet chain
+type ForkPreset struct {
+	Name       string                         `yaml:"-"`
+	Chain      string                         `yaml:"chain"`
+	ChainID    int                            `yaml:"chain_id"`
+	RPCURLEnv  string                         `yaml:"rpc_url_env"`
+	ForkBlock  string                         `yaml:"fork_block"`
+	EigenLayer *devkitcommon.EigenLayerConfig `yaml:"eigenlayer,omitempty"`
+}
+
+// ForkPresetRegistry is the layout of config/forks.yaml
+type ForkPresetRegistry struct {
+	Version string                `yaml:"version"`
+	Presets map[string]ForkPreset `yaml:"presets"`
+}
+
+// GetProjectForkPresetsPath returns the project file whose presets extend and override the embedded ones
+func GetProjectForkPresetsPath() string {
+	return filepath.Join(devkitcommon.DefaultConfigWithContextConfigPath, "forks.yaml")
+}
+
+// ParseForkPresets parses and validates a fork preset registry
+func ParseForkPresets(data []byte) (*ForkPresetRegistry, error) {
+	var registry ForkPresetRegistry
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse fork presets: %w", err)
+	}
+	if registry.Version != FORK_PRESETS_VERSION {
+		return nil, fmt.Errorf("unsupported fork presets version %q, expected %s", registry.Version, FORK_PRESETS_VERSION)
+	}
+	for name, preset := range registry.Presets {
+		preset.Name = name
+		if err := preset.validate(); err != nil {
+			return nil, err
+		}
+		registry.Presets[name] = preset
+	}
+	return &registry, nil
+}
+
+// LoadForkPresets returns the embedded presets overlaid with the project's config/forks.yaml when present
+func LoadForkPresets() (map[string]ForkPreset, error) {
+	embedded, err := ParseForkPresets([]byte(config.ForkPresetsYaml))
+	if err != nil {
+		return nil, fmt.Errorf("embedded fork presets: %w", err)
+	}
+	presets := embedded.Presets
+
+	data, err := os.ReadFile(GetProjectForkPresetsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return presets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", GetProjectForkPresetsPath(), err)
+	}
+	project, err := ParseForkPresets(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", GetProjectForkPresetsPath(), err)
+	}
+	for name, preset := range project.Presets {
+		presets[name] = preset
+	}
+	return presets, nil
+}
+
+// SelectForkPresets resolves a comma separated list of preset names, e.g. "sepolia,base".
+// Every selected preset must target a different chain.
+func SelectForkPresets(presets map[string]ForkPreset, selection string) ([]ForkPreset, error) {
+	var selected []ForkPreset
+	chains := make(map[string]string)
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		preset, ok := presets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fork preset %q; available presets: %s", name, strings.Join(ForkPresetNames(presets), ", "))
+		}
+		if other, taken := chains[preset.Chain]; taken {
+			return nil, fmt.Errorf("fork presets %s and %s both target chain %s", other, name, preset.Chain)
+		}
+		chains[preset.Chain] = name
+		selected = append(selected, preset)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no fork preset given; available presets: %s", strings.Join(ForkPresetNames(presets), ", "))
+	}
+	return selected, nil
+}
+
+// ForkPresetNames returns the preset names sorted
+func ForkPresetNames(presets map[string]ForkPreset) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RPCURL returns the upstream RPC url from the preset's environment variable
+func (p ForkPreset) RPCURL() (string, error) {
+	url := os.Getenv(p.RPCURLEnv)
+	if url == "" {
+		return "", fmt.Errorf("fork preset %s needs an RPC url; set %s in your environment or .env", p.Name, p.RPCURLEnv)
+	}
+	return url, nil
+}
+
+// ResolveForkBlock turns the preset's fork_block policy into a concrete block number on the upstream chain
+func (p ForkPreset) ResolveForkBlock(ctx context.Context, rpcURL string) (uint64, error) {
+	policy := strings.ToLower(strings.TrimSpace(p.ForkBlock))
+	if n, err := strconv.ParseUint(policy, 10, 64); err == nil {
+		return n, nil
+	}
+
+	var number *big.Int
+	switch policy {
+	case "", FORK_BLOCK_LATEST:
+		number = nil
+	case FORK_BLOCK_FINALIZED:
+		number = big.NewInt(int64(rpc.FinalizedBlockNumber))
+	default:
+		return 0, fmt.Errorf("fork preset %s has invalid fork_block %q", p.Name, p.ForkBlock)
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to %s fork RPC: %w", p.Name, err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, DEVNET_READY_PROBE_TIMEOUT)
+	defer cancel()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get chain id from %s fork RPC: %w", p.Name, err)
+	}
+	if chainID.Uint64() != uint64(p.ChainID) {
+		return 0, fmt.Errorf("%s points at chain %d, but fork preset %s expects chain %d", p.RPCURLEnv, chainID.Uint64(), p.Name, p.ChainID)
+	}
+
+	header, err := client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s block of %s: %w", policy, p.Name, err)
+	}
+	return header.Number.Uint64(), nil
+}
+
+func (p ForkPreset) validate() error {
+	if p.Chain == "" {
+		return fmt.Errorf("fork preset %s: chain is required", p.Name)
+	}
+	if p.ChainID <= 0 {
+		return fmt.Errorf("fork preset %s: chain_id is required", p.Name)
+	}
+	if p.RPCURLEnv == "" {
+		return fmt.Errorf("fork preset %s: rpc_url_env is required", p.Name)
+	}
+	policy := strings.ToLower(strings.TrimSpace(p.ForkBlock))
+	if _, err := strconv.ParseUint(policy, 10, 64); err != nil && policy != "" && policy != FORK_BLOCK_LATEST && policy != FORK_BLOCK_FINALIZED {
+		return fmt.Errorf("fork preset %s: invalid fork_block %q; use latest, finalized or a block number", p.Name, p.ForkBlock)
+	}
+	return nil
+}
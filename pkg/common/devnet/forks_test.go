@@ -0,0 +1,145 @@
+package devnet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedForkPresets(t *testing.T) {
+	registry, err := ParseForkPresets([]byte(config.ForkPresetsYaml))
+	require.NoError(t, err)
+
+	for _, name := range []string{"mainnet", "holesky", "sepolia", "hoodi"} {
+		preset, ok := registry.Presets[name]
+		require.True(t, ok, "missing preset %s", name)
+		assert.Equal(t, L1, preset.Chain)
+		require.NotNil(t, preset.EigenLayer, "preset %s has no eigenlayer addresses", name)
+		assert.NotEmpty(t, preset.EigenLayer.AllocationManager)
+		assert.NotEmpty(t, preset.EigenLayer.DelegationManager)
+	}
+	for _, name := range []string{"base", "optimism"} {
+		preset, ok := registry.Presets[name]
+		require.True(t, ok, "missing preset %s", name)
+		assert.Equal(t, "l2", preset.Chain)
+	}
+	assert.Equal(t, ALLOCATION_MANAGER_ADDRESS, registry.Presets["mainnet"].EigenLayer.AllocationManager)
+	assert.Equal(t, "mainnet", registry.Presets["mainnet"].Name)
+}
+
+func TestParseForkPresetsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		errContains string
+	}{
+		{
+			name:        "unsupported version",
+			data:        "version: 9.9.9\npresets: {}\n",
+			errContains: "unsupported fork presets version",
+		},
+		{
+			name:        "missing env",
+			data:        "version: 0.0.1\npresets:\n  dev:\n    chain: l1\n    chain_id: 5\n",
+			errContains: "rpc_url_env is required",
+		},
+		{
+			name:        "invalid fork block",
+			data:        "version: 0.0.1\npresets:\n  dev:\n    chain: l1\n    chain_id: 5\n    rpc_url_env: DEV_RPC_URL\n    fork_block: yesterday\n",
+			errContains: "invalid fork_block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseForkPresets([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestLoadForkPresetsProjectOverride(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	require.NoError(t, os.MkdirAll("config", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("config", "forks.yaml"), []byte(`version: 0.0.1
+presets:
+  mainnet:
+    chain: l1
+    chain_id: 1
+    rpc_url_env: TEAM_MAINNET_RPC
+    fork_block: 21000000
+  teamnet:
+    chain: l1
+    chain_id: 4242
+    rpc_url_env: TEAMNET_RPC_URL
+`), 0644))
+
+	presets, err := LoadForkPresets()
+	require.NoError(t, err)
+	assert.Equal(t, "TEAM_MAINNET_RPC", presets["mainnet"].RPCURLEnv)
+	assert.Equal(t, 4242, presets["teamnet"].ChainID)
+	assert.Contains(t, presets, "holesky", "embedded presets remain available")
+}
+
+func TestSelectForkPresets(t *testing.T) {
+	registry, err := ParseForkPresets([]byte(config.ForkPresetsYaml))
+	require.NoError(t, err)
+
+	selected, err := SelectForkPresets(registry.Presets, "Sepolia, base")
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	assert.Equal(t, "sepolia", selected[0].Name)
+	assert.Equal(t, "base", selected[1].Name)
+
+	_, err = SelectForkPresets(registry.Presets, "mainnet,holesky")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both target chain l1")
+
+	_, err = SelectForkPresets(registry.Presets, "goerli")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available presets")
+}
+
+func TestForkPresetResolveForkBlock(t *testing.T) {
+	srv := newFakeRPC(t, 17000, 3500000, 0)
+
+	preset := ForkPreset{Name: "holesky", ChainID: 17000, RPCURLEnv: "HOLESKY_RPC_URL", ForkBlock: "finalized"}
+	block, err := preset.ResolveForkBlock(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3500000), block)
+
+	preset.ForkBlock = "1234"
+	block, err = preset.ResolveForkBlock(context.Background(), "http://127.0.0.1:1")
+	require.NoError(t, err, "a fixed block needs no RPC round trip")
+	assert.Equal(t, uint64(1234), block)
+
+	preset.ForkBlock = "latest"
+	preset.ChainID = 1
+	_, err = preset.ResolveForkBlock(context.Background(), srv.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expects chain 1")
+}
+
+func TestForkPresetRPCURL(t *testing.T) {
+	preset := ForkPreset{Name: "hoodi", RPCURLEnv: "DEVKIT_TEST_HOODI_RPC_URL"}
+
+	_, err := preset.RPCURL()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEVKIT_TEST_HOODI_RPC_URL")
+
+	t.Setenv("DEVKIT_TEST_HOODI_RPC_URL", "https://hoodi.example")
+	url, err := preset.RPCURL()
+	require.NoError(t, err)
+	assert.Equal(t, "https://hoodi.example", url)
+}
@@ -1,83 +1,300 @@
 package devnet
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"math/big"
 	"os"
-	"os/exec"
 	"strings"
+	"sync"
 
 	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// FundWallets sends ETH to a list of addresses using `cast send`
-// Only funds wallets with balance < 10 ether.
-func FundWalletsDevnet(cfg *devkitcommon.ConfigWithContextConfig, rpcURL string) error {
-
+// FundWalletsDevnet tops up every wallet the devnet context uses to the configured target balance:
+// operators, the AVS key, the app key and funding.extra_addresses. On anvil balances are set directly
+// with anvil_setBalance; on other nodes the deployer signer sends the difference. Wallets are funded in
+// parallel and the call returns once every balance is observed on-chain.
+func FundWalletsDevnet(ctx context.Context, logger iface.Logger, cfg *devkitcommon.ConfigWithContextConfig, rpcURL string) error {
 	if os.Getenv("SKIP_DEVNET_FUNDING") == "true" {
-		log.Println("🔧 Skipping devnet wallet funding (test mode)")
+		logger.Info("🔧 Skipping devnet wallet funding (test mode)")
 		return nil
 	}
 
-	// All operator keys from [operator]
 	// We only intend to fund for devnet, so hardcoding to `CONTEXT` is fine
-	for _, key := range cfg.Context[CONTEXT].Operators {
-		cleanedKey := strings.TrimPrefix(key.ECDSAKey, "0x")
-		privateKey, err := crypto.HexToECDSA(cleanedKey)
+	envCtx := cfg.Context[CONTEXT]
+
+	target, err := GetFundingTargetBalance(envCtx.Funding)
+	if err != nil {
+		return err
+	}
+	addresses, err := GetFundingAddresses(envCtx)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to devnet at %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	funder, err := newFunder(ctx, client, rpcURL, envCtx)
+	if err != nil {
+		return err
+	}
+	defer funder.close()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, addr := range addresses {
+		wg.Add(1)
+		go func(addr common.Address) {
+			defer wg.Done()
+			if err := funder.fund(ctx, logger, addr, target); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("failed to fund %s: %w", addr.Hex(), err))
+				mu.Unlock()
+			}
+		}(addr)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	// Confirm the balances landed instead of assuming the transfers were mined
+	if err := WaitForBalances(ctx, rpcURL, addresses, target, DEVNET_READY_TIMEOUT); err != nil {
+		return fmt.Errorf("wallet funding not confirmed: %w", err)
+	}
+	return nil
+}
+
+// GetFundingTargetBalance returns the configured target balance in wei, defaulting to FUND_VALUE
+func GetFundingTargetBalance(funding *devkitcommon.FundingConfig) (*big.Int, error) {
+	if funding == nil || funding.TargetBalance == "" {
+		target, _ := new(big.Int).SetString(FUND_VALUE, 10)
+		return target, nil
+	}
+
+	// Same unit syntax as operator stake, e.g. "10ETH", "5000000gwei"
+	amount, err := ParseStake(funding.TargetBalance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid funding.target_balance: %w", err)
+	}
+	return amount.BaseUnits(18)
+}
+
+// GetFundingAddresses returns the deduplicated wallets to fund: operators, the AVS and app keys and
+// the extra addresses from the funding config
+func GetFundingAddresses(envCtx devkitcommon.ChainContextConfig) ([]common.Address, error) {
+	seen := make(map[common.Address]bool)
+	var addresses []common.Address
+	add := func(addr common.Address) {
+		if !seen[addr] {
+			seen[addr] = true
+			addresses = append(addresses, addr)
+		}
+	}
+
+	for _, op := range envCtx.Operators {
+		// A signer reference has to sign for the operator's address
+		if op.Signer != nil {
+			if !common.IsHexAddress(op.Address) {
+				return nil, fmt.Errorf("invalid address %q for operator with a signer", op.Address)
+			}
+			add(common.HexToAddress(op.Address))
+			continue
+		}
+		addr, err := addressFromKey(op.ECDSAKey)
 		if err != nil {
-			log.Fatalf("invalid private key %q: %v", key.ECDSAKey, err)
+			return nil, fmt.Errorf("invalid ecdsa_key for operator %s: %w", op.Address, err)
+		}
+		add(addr)
+	}
+	// avs.avs_signer and app_signer take the place of avs.avs_private_key and app_private_key
+	keys := []struct {
+		name, key      string
+		signerName     string
+		signer         *devkitcommon.SignerConfig
+		defaultAddress string
+	}{
+		{"avs.avs_private_key", envCtx.Avs.AVSPrivateKey, "avs.avs_signer", envCtx.Avs.AVSSigner, envCtx.Avs.Address},
+		{"app_private_key", envCtx.AppDeployerPrivateKey, "app_signer", envCtx.AppSigner, ""},
+	}
+	for _, k := range keys {
+		if k.signer != nil {
+			addr, err := devkitcommon.SignerAddress(*k.signer, k.defaultAddress)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", k.signerName, err)
+			}
+			add(addr)
+			continue
+		}
+		if k.key == "" {
+			continue
 		}
-		err = fundIfNeeded(crypto.PubkeyToAddress(privateKey.PublicKey), key.ECDSAKey, rpcURL)
+		addr, err := addressFromKey(k.key)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid %s: %w", k.name, err)
 		}
+		add(addr)
 	}
-	return nil
+	if envCtx.Funding != nil {
+		for _, extra := range envCtx.Funding.ExtraAddresses {
+			if !common.IsHexAddress(extra) {
+				return nil, fmt.Errorf("invalid address %q in funding.extra_addresses", extra)
+			}
+			add(common.HexToAddress(extra))
+		}
+	}
+	return addresses, nil
 }
 
-func fundIfNeeded(to common.Address, fromKey string, rpcURL string) error {
-	balanceCmd := exec.Command("cast", "balance", to.String(), "--rpc-url", rpcURL)
-	balanceCmd.Env = append(os.Environ(), "FOUNDRY_DISABLE_NIGHTLY_WARNING=1")
-	output, err := balanceCmd.CombinedOutput()
+// addressFromKey derives the address of a hex encoded private key
+func addressFromKey(keyHex string) (common.Address, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(keyHex, "0x"))
 	if err != nil {
-		if strings.Contains(string(output), "Error: error sending request for url") {
-			log.Printf(" Please check if your mainnet fork rpc url is up")
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// funder tops up balances either through anvil cheatcodes or by transfers from the deployer
+type funder struct {
+	client *ethclient.Client
+	anvil  *AnvilClient
+
+	// Transfer fallback state; nonces are handed out under mu so parallel transfers don't collide
+	deployer    devkitcommon.Signer
+	chainID     *big.Int
+	mu          sync.Mutex
+	nonce       uint64
+	nonceLoaded bool
+}
+
+// newFunder prefers anvil_setBalance when the node is anvil and otherwise prepares transfers signed by the
+// context's deployer_signer or deployer_private_key
+func newFunder(ctx context.Context, client *ethclient.Client, rpcURL string, envCtx devkitcommon.ChainContextConfig) (*funder, error) {
+	f := &funder{client: client}
+
+	if anvil, err := DialAnvil(ctx, rpcURL); err == nil {
+		if anvil.IsAnvil(ctx) {
+			f.anvil = anvil
+			return f, nil
 		}
-		return fmt.Errorf("failed to get balance for account %s", to.String())
+		anvil.Close()
 	}
-	threshold := new(big.Int)
-	threshold.SetString(FUND_VALUE, 10)
 
-	balanceStr := strings.TrimSpace(string(output))
-	balance := new(big.Int)
-	if _, ok := balance.SetString(balanceStr, 10); !ok {
-		return fmt.Errorf("failed to parse balance from cast output: %s", balanceStr)
+	deployer, err := devkitcommon.DeployerSigner(envCtx)
+	if err != nil {
+		return nil, err
 	}
-	balance.SetString(string(output), 10)
-	if balance.Cmp(threshold) >= 0 {
-		log.Printf("✅ %s already has sufficient balance (%s wei)", to, balance.String())
+	if deployer == nil {
+		return nil, fmt.Errorf("node at %s is not anvil and neither deployer_signer nor deployer_private_key is set to fund wallets from", rpcURL)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain id: %w", err)
+	}
+	f.deployer = deployer
+	f.chainID = chainID
+	return f, nil
+}
+
+func (f *funder) close() {
+	if f.anvil != nil {
+		f.anvil.Close()
+	}
+}
+
+// fund raises addr's balance to at least target
+func (f *funder) fund(ctx context.Context, logger iface.Logger, addr common.Address, target *big.Int) error {
+	balance, err := f.client.BalanceAt(ctx, addr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+	if balance.Cmp(target) >= 0 {
+		logger.Info("✅ %s already has sufficient balance (%s wei)", addr.Hex(), balance)
 		return nil
 	}
 
-	log.Printf("💸 Funding %s with %s from %s", to, FUND_VALUE, fromKey)
-	cmd := exec.Command("cast", "send",
-		to.String(),
-		"--value", FUND_VALUE,
-		"--rpc-url", rpcURL,
-		"--private-key", fromKey,
-	)
+	if f.anvil != nil {
+		if err := f.anvil.SetBalance(ctx, addr, target); err != nil {
+			return err
+		}
+		logger.Info("✅ Set balance of %s to %s wei", addr.Hex(), target)
+		return nil
+	}
 
-	_, err = cmd.CombinedOutput()
+	value := new(big.Int).Sub(target, balance)
+	logger.Info("💸 Funding %s with %s wei from the deployer", addr.Hex(), value)
+	if err := f.transfer(ctx, addr, value); err != nil {
+		return err
+	}
+	logger.Info("✅ Funded %s", addr.Hex())
+	return nil
+}
+
+// transfer sends value wei from the deployer to addr and waits for it to be mined
+func (f *funder) transfer(ctx context.Context, to common.Address, value *big.Int) error {
+	from := f.deployer.Address()
 
+	gasPrice, err := f.client.SuggestGasPrice(ctx)
 	if err != nil {
-		log.Printf("❌ Failed to fund %s: %v", to, err)
-		return err
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	f.mu.Lock()
+	if !f.nonceLoaded {
+		nonce, err := f.client.PendingNonceAt(ctx, from)
+		if err != nil {
+			f.mu.Unlock()
+			return fmt.Errorf("failed to get deployer nonce: %w", err)
+		}
+		f.nonce = nonce
+		f.nonceLoaded = true
+	}
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    f.nonce,
+		To:       &to,
+		Value:    value,
+		Gas:      21000,
+		GasPrice: gasPrice,
+	})
+	signed, err := f.deployer.SignTx(ctx, tx, f.chainID)
+	if err == nil {
+		err = f.client.SendTransaction(ctx, signed)
+	}
+	// The nonce only moves past a transaction the node accepted. After a failed send the node may still have
+	// taken it, so the next transfer asks for the pending nonce again instead of guessing.
+	if err == nil {
+		f.nonce++
 	} else {
-		log.Printf("✅ Funded %s", to)
+		f.nonceLoaded = false
+	}
+	f.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to send transfer: %w", err)
+	}
+
+	receipt, err := bind.WaitMined(ctx, f.client, signed)
+	if err != nil {
+		return fmt.Errorf("waiting for transfer %s: %w", signed.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transfer %s reverted", signed.Hash().Hex())
 	}
 	return nil
 }
@@ -0,0 +1,224 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOperatorKey = "0x2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6"
+	testAvsKey      = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testAppKey      = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+)
+
+// newFakeAnvil serves web3_clientVersion, eth_getBalance and anvil_setBalance backed by an in-memory balance map
+func newFakeAnvil(t *testing.T, balances map[common.Address]*big.Int) *httptest.Server {
+	return rpctest.NewServer(t, rpctest.Handlers{
+		"web3_clientVersion": rpctest.Result("anvil/v1.0.0"),
+		"eth_getBalance": func(params []json.RawMessage) (any, error) {
+			var addr common.Address
+			require.NoError(t, json.Unmarshal(params[0], &addr))
+			balance := balances[addr]
+			if balance == nil {
+				balance = new(big.Int)
+			}
+			return (*hexutil.Big)(balance), nil
+		},
+		"anvil_setBalance": func(params []json.RawMessage) (any, error) {
+			var addr common.Address
+			var balance hexutil.Big
+			require.NoError(t, json.Unmarshal(params[0], &addr))
+			require.NoError(t, json.Unmarshal(params[1], &balance))
+			balances[addr] = balance.ToInt()
+			return nil, nil
+		},
+	})
+}
+
+func TestGetFundingTargetBalance(t *testing.T) {
+	defaultTarget, _ := new(big.Int).SetString(FUND_VALUE, 10)
+
+	tests := []struct {
+		name        string
+		funding     *devkitcommon.FundingConfig
+		want        *big.Int
+		errContains string
+	}{
+		{name: "no funding config", want: defaultTarget},
+		{name: "empty target", funding: &devkitcommon.FundingConfig{}, want: defaultTarget},
+		{name: "ether", funding: &devkitcommon.FundingConfig{TargetBalance: "100ETH"}, want: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+		{name: "gwei", funding: &devkitcommon.FundingConfig{TargetBalance: "5gwei"}, want: big.NewInt(5e9)},
+		{name: "invalid", funding: &devkitcommon.FundingConfig{TargetBalance: "lots"}, errContains: "funding.target_balance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFundingTargetBalance(tt.funding)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 0, tt.want.Cmp(got), "got %s", got)
+		})
+	}
+}
+
+func TestGetFundingAddresses(t *testing.T) {
+	operator, err := addressFromKey(testOperatorKey)
+	require.NoError(t, err)
+	avs, err := addressFromKey(testAvsKey)
+	require.NoError(t, err)
+	app, err := addressFromKey(testAppKey)
+	require.NoError(t, err)
+	extra := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	envCtx := devkitcommon.ChainContextConfig{
+		Operators:             []devkitcommon.OperatorSpec{{ECDSAKey: testOperatorKey}},
+		Avs:                   devkitcommon.AvsConfig{AVSPrivateKey: testAvsKey},
+		AppDeployerPrivateKey: testAppKey,
+		Funding: &devkitcommon.FundingConfig{
+			// The AVS address repeated in the extra list is only funded once
+			ExtraAddresses: []string{extra.Hex(), strings.ToLower(avs.Hex())},
+		},
+	}
+
+	addresses, err := GetFundingAddresses(envCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{operator, avs, app, extra}, addresses)
+
+	envCtx.Funding.ExtraAddresses = []string{"not-an-address"}
+	_, err = GetFundingAddresses(envCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "funding.extra_addresses")
+
+	envCtx.Funding = nil
+	envCtx.Operators[0].ECDSAKey = "0x1234"
+	_, err = GetFundingAddresses(envCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ecdsa_key")
+}
+
+func TestGetFundingAddressesWithSigners(t *testing.T) {
+	operator := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	avs := common.HexToAddress("0x00000000000000000000000000000000000000a0")
+
+	envCtx := devkitcommon.ChainContextConfig{
+		Operators: []devkitcommon.OperatorSpec{{
+			Address: operator.Hex(),
+			Signer:  &devkitcommon.SignerConfig{Type: devkitcommon.SignerTypeKeystore, Path: "operator.json"},
+		}},
+		Avs: devkitcommon.AvsConfig{
+			Address:       avs.Hex(),
+			AVSPrivateKey: testAvsKey,
+			AVSSigner:     &devkitcommon.SignerConfig{Type: devkitcommon.SignerTypeWeb3Signer, URL: "http://localhost:9000"},
+		},
+	}
+
+	// Signer references are funded at the address they sign for; the AVS signer replaces avs_private_key
+	addresses, err := GetFundingAddresses(envCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{operator, avs}, addresses)
+
+	// app_signer replaces app_private_key
+	app := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	envCtx.AppDeployerPrivateKey = testAppKey
+	envCtx.AppSigner = &devkitcommon.SignerConfig{Type: devkitcommon.SignerTypeWeb3Signer, URL: "http://localhost:9000", Address: app.Hex()}
+	addresses, err = GetFundingAddresses(envCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{operator, avs, app}, addresses)
+
+	envCtx.AppSigner.Address = ""
+	_, err = GetFundingAddresses(envCtx)
+	assert.ErrorContains(t, err, "app_signer")
+}
+
+func TestFundWalletsDevnetAnvil(t *testing.T) {
+	operator, err := addressFromKey(testOperatorKey)
+	require.NoError(t, err)
+	avs, err := addressFromKey(testAvsKey)
+	require.NoError(t, err)
+
+	target := new(big.Int).Mul(big.NewInt(50), big.NewInt(1e18))
+	rich := new(big.Int).Mul(target, big.NewInt(2))
+	balances := map[common.Address]*big.Int{avs: rich}
+	srv := newFakeAnvil(t, balances)
+
+	cfg := &devkitcommon.ConfigWithContextConfig{
+		Context: map[string]devkitcommon.ChainContextConfig{
+			CONTEXT: {
+				Operators: []devkitcommon.OperatorSpec{{ECDSAKey: testOperatorKey}},
+				Avs:       devkitcommon.AvsConfig{AVSPrivateKey: testAvsKey},
+				Funding:   &devkitcommon.FundingConfig{TargetBalance: "50ETH"},
+			},
+		},
+	}
+
+	require.NoError(t, FundWalletsDevnet(context.Background(), logger.NewNoopLogger(), cfg, srv.URL))
+	assert.Equal(t, 0, target.Cmp(balances[operator]), "operator topped up to target")
+	assert.Equal(t, 0, rich.Cmp(balances[avs]), "wallet above target left untouched")
+}
+
+func TestFunderTransferNonce(t *testing.T) {
+	var (
+		pending uint64 = 7
+		sent    []uint64
+		reject  = true
+	)
+	srv := rpctest.NewServer(t, rpctest.Handlers{
+		"web3_clientVersion": rpctest.Result("Geth/v1.15.9"),
+		"eth_chainId":        rpctest.Result(hexutil.Uint64(0x4268)),
+		"eth_gasPrice":       rpctest.Result(hexutil.Uint64(1)),
+		"eth_getTransactionCount": func([]json.RawMessage) (any, error) {
+			return hexutil.Uint64(pending), nil
+		},
+		"eth_sendRawTransaction": func(params []json.RawMessage) (any, error) {
+			var raw hexutil.Bytes
+			require.NoError(t, json.Unmarshal(params[0], &raw))
+			tx := new(types.Transaction)
+			require.NoError(t, tx.UnmarshalBinary(raw))
+			if reject {
+				reject = false
+				return nil, errors.New("replacement transaction underpriced")
+			}
+			sent = append(sent, tx.Nonce())
+			pending = tx.Nonce() + 1
+			return tx.Hash(), nil
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (any, error) {
+			return json.RawMessage(`{"status":"0x1","cumulativeGasUsed":"0x5208","logs":[],"logsBloom":"0x` + strings.Repeat("00", 256) + `","transactionHash":` + string(params[0]) + `,"gasUsed":"0x5208","blockNumber":"0x1","transactionIndex":"0x0","type":"0x0"}`), nil
+		},
+	})
+
+	client, err := ethclient.Dial(srv.URL)
+	require.NoError(t, err)
+	defer client.Close()
+	f, err := newFunder(context.Background(), client, srv.URL, devkitcommon.ChainContextConfig{DeployerPrivateKey: testAvsKey})
+	require.NoError(t, err)
+	defer f.close()
+
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.Error(t, f.transfer(context.Background(), to, big.NewInt(1)))
+
+	// The rejected transfer did not use up nonce 7; later transfers continue from the node's pending nonce
+	require.NoError(t, f.transfer(context.Background(), to, big.NewInt(1)))
+	require.NoError(t, f.transfer(context.Background(), to, big.NewInt(1)))
+	assert.Equal(t, []uint64{7, 8}, sent)
+}
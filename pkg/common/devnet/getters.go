@@ -90,6 +90,17 @@ func GetDevnetBlockTimeOrDefault(cfg *common.ConfigWithContextConfig, chainName
 }
 
 func GetDevnetForkUrlDefault(cfg *common.ConfigWithContextConfig, chainName string) (string, error) {
+	// A fork preset recorded by `devnet start --fork` names the env var holding its url
+	if chainConfig, found := cfg.Context[CONTEXT].Chains[chainName]; found && chainConfig.Fork != nil && chainConfig.Fork.Preset != "" {
+		if presets, err := LoadForkPresets(); err == nil {
+			if preset, ok := presets[chainConfig.Fork.Preset]; ok {
+				if url, err := preset.RPCURL(); err == nil {
+					return url, nil
+				}
+			}
+		}
+	}
+
 	// Check in env first for L1 fork url
 	l1ForkUrl := os.Getenv("L1_FORK_URL")
 	if chainName == "l1" && l1ForkUrl != "" {
@@ -107,7 +118,7 @@ func GetDevnetForkUrlDefault(cfg *common.ConfigWithContextConfig, chainName stri
 	if !found {
 		return "", fmt.Errorf("failed to get chainConfig for chainName : %s", chainName)
 	}
-	if chainConfig.Fork.Url == "" {
+	if chainConfig.Fork == nil || chainConfig.Fork.Url == "" {
 		return "", fmt.Errorf("fork-url not set for %s; set fork-url in ./config/context/devnet.yaml or .env and consult README for guidance", chainName)
 	}
 	return chainConfig.Fork.Url, nil
@@ -116,24 +127,43 @@ func GetDevnetForkUrlDefault(cfg *common.ConfigWithContextConfig, chainName stri
 // GetEigenLayerAddresses returns EigenLayer addresses from the context config
 // Falls back to constants if not found in context
 func GetEigenLayerAddresses(cfg *common.ConfigWithContextConfig) (allocationManager, delegationManager string) {
+	return GetContextEigenLayerAddresses(cfg, CONTEXT)
+}
+
+// GetContextEigenLayerAddresses returns EigenLayer addresses from the named context
+// Falls back to constants if not found in context
+func GetContextEigenLayerAddresses(cfg *common.ConfigWithContextConfig, contextName string) (allocationManager, delegationManager string) {
 	if cfg == nil || cfg.Context == nil {
 		return ALLOCATION_MANAGER_ADDRESS, DELEGATION_MANAGER_ADDRESS
 	}
 
-	devnetCtx, found := cfg.Context[CONTEXT]
-	if !found || devnetCtx.EigenLayer == nil {
+	envCtx, found := cfg.Context[contextName]
+	if !found || envCtx.EigenLayer == nil {
 		return ALLOCATION_MANAGER_ADDRESS, DELEGATION_MANAGER_ADDRESS
 	}
 
-	allocationManager = devnetCtx.EigenLayer.AllocationManager
+	allocationManager = envCtx.EigenLayer.AllocationManager
 	if allocationManager == "" {
 		allocationManager = ALLOCATION_MANAGER_ADDRESS
 	}
 
-	delegationManager = devnetCtx.EigenLayer.DelegationManager
+	delegationManager = envCtx.EigenLayer.DelegationManager
 	if delegationManager == "" {
 		delegationManager = DELEGATION_MANAGER_ADDRESS
 	}
 
 	return allocationManager, delegationManager
 }
+
+// GetContextRewardsCoordinatorAddress returns the RewardsCoordinator address from the named context
+// Falls back to the constant if not found in context
+func GetContextRewardsCoordinatorAddress(cfg *common.ConfigWithContextConfig, contextName string) string {
+	if cfg == nil || cfg.Context == nil {
+		return REWARDS_COORDINATOR_ADDRESS
+	}
+	envCtx, found := cfg.Context[contextName]
+	if !found || envCtx.EigenLayer == nil || envCtx.EigenLayer.RewardsCoordinator == "" {
+		return REWARDS_COORDINATOR_ADDRESS
+	}
+	return envCtx.EigenLayer.RewardsCoordinator
+}
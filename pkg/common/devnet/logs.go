@@ -0,0 +1,229 @@
+package devnet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RUN_LOG_COMPONENT is the component name AVSRun writes the run script's combined output under
+const RUN_LOG_COMPONENT = "run"
+
+// logFollowInterval is how often a followed log file is checked for new output
+const logFollowInterval = 250 * time.Millisecond
+
+// GetLogsDir returns the project-relative directory holding the captured offchain AVS logs
+func GetLogsDir() string {
+	return filepath.Join(".devkit", "logs")
+}
+
+// logNameRe restricts log names to file names inside the logs dir
+var logNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateLogName rejects names that would resolve to a file outside the logs dir
+func ValidateLogName(name string) error {
+	if !logNameRe.MatchString(name) {
+		return fmt.Errorf("invalid log name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// GetComponentLogPath returns the log file of the given component under the logs dir, run.log for the run
+// script's combined output
+func GetComponentLogPath(component string) string {
+	return filepath.Join(GetLogsDir(), component+".log")
+}
+
+// ListComponentLogs returns the names of the log files under the logs dir
+func ListComponentLogs() ([]string, error) {
+	entries, err := os.ReadDir(GetLogsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read logs dir: %w", err)
+	}
+
+	var components []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".log")
+		if !e.IsDir() && name != e.Name() && ValidateLogName(name) == nil {
+			components = append(components, name)
+		}
+	}
+	sort.Strings(components)
+	return components, nil
+}
+
+// componentPrefixRe matches the component tag a line of the run script's output starts with: "[aggregator] ..." or
+// "aggregator | ..." as printed by docker compose and most process supervisors
+var componentPrefixRe = regexp.MustCompile(`^(?:\[([A-Za-z0-9][A-Za-z0-9._-]*)\]|([A-Za-z0-9][A-Za-z0-9._-]*)\s+\|)\s?`)
+
+// SplitComponentLine returns the component a line of output is tagged with and the line without its tag
+func SplitComponentLine(line string) (component, rest string, ok bool) {
+	m := componentPrefixRe.FindStringSubmatchIndex(line)
+	if m == nil {
+		return "", line, false
+	}
+	if m[2] >= 0 {
+		component = line[m[2]:m[3]]
+	} else {
+		component = line[m[4]:m[5]]
+	}
+	return component, line[m[1]:], true
+}
+
+// ComponentLogWriter captures the run script's output under the logs dir. Every line goes to run.log, and a line
+// tagged with a component (see SplitComponentLine) also goes, without its tag, to <component>.log. Logs from an
+// earlier run are truncated the first time this run writes to them.
+type ComponentLogWriter struct {
+	mu      sync.Mutex
+	dir     string
+	files   map[string]*os.File
+	partial []byte
+}
+
+// NewComponentLogWriter creates the logs dir and starts a fresh run.log in it
+func NewComponentLogWriter(dir string) (*ComponentLogWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create logs dir: %w", err)
+	}
+	w := &ComponentLogWriter{dir: dir, files: make(map[string]*os.File)}
+	if _, err := w.file(RUN_LOG_COMPONENT); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write splits p into lines and routes each complete line; a trailing partial line waits for the next write
+func (w *ComponentLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.partial[:i+1])
+		w.partial = w.partial[i+1:]
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close flushes a trailing partial line and closes every log file
+func (w *ComponentLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var errs []error
+	if len(w.partial) > 0 {
+		errs = append(errs, w.writeLine(string(w.partial)+"\n"))
+		w.partial = nil
+	}
+	for _, f := range w.files {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (w *ComponentLogWriter) writeLine(line string) error {
+	run, err := w.file(RUN_LOG_COMPONENT)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(run, line); err != nil {
+		return fmt.Errorf("write %s: %w", run.Name(), err)
+	}
+
+	component, rest, ok := SplitComponentLine(line)
+	if !ok || component == RUN_LOG_COMPONENT {
+		return nil
+	}
+	f, err := w.file(component)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, rest); err != nil {
+		return fmt.Errorf("write %s: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// file returns the open log of component, creating or truncating it on first use
+func (w *ComponentLogWriter) file(component string) (*os.File, error) {
+	if f, ok := w.files[component]; ok {
+		return f, nil
+	}
+	f, err := os.Create(filepath.Join(w.dir, component+".log"))
+	if err != nil {
+		return nil, fmt.Errorf("create %s log: %w", component, err)
+	}
+	w.files[component] = f
+	return f, nil
+}
+
+// TailFile writes the last `lines` lines of path to w (all lines when lines < 0).
+// With follow it keeps writing appended output until ctx is cancelled.
+func TailFile(ctx context.Context, path string, lines int, follow bool, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	// Keep a ring of the last `lines` lines while reading to the end
+	var ring []string
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			ring = append(ring, line)
+			if lines >= 0 && len(ring) > lines {
+				ring = ring[1:]
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+	}
+	for _, line := range ring {
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := io.Copy(w, reader); err != nil {
+				return fmt.Errorf("follow %s: %w", path, err)
+			}
+		}
+	}
+}
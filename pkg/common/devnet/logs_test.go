@@ -0,0 +1,146 @@
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aggregator.log")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0o644))
+
+	tests := []struct {
+		name     string
+		lines    int
+		expected string
+	}{
+		{name: "all lines", lines: -1, expected: "one\ntwo\nthree\n"},
+		{name: "last two", lines: 2, expected: "two\nthree\n"},
+		{name: "more than available", lines: 10, expected: "one\ntwo\nthree\n"},
+		{name: "none", lines: 0, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, TailFile(context.Background(), path, tt.lines, false, &out))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestTailFile_Follow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	require.NoError(t, os.WriteFile(path, []byte("started\n"), 0o644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error)
+	go func() { done <- TailFile(ctx, path, -1, true, &out) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString("task received\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool { return out.String() == "started\ntask received\n" }, 2*time.Second, 20*time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestListComponentLogs(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	components, err := ListComponentLogs()
+	require.NoError(t, err)
+	assert.Empty(t, components)
+
+	require.NoError(t, os.MkdirAll(GetLogsDir(), 0o755))
+	for _, name := range []string{"run", "aggregator", "executor"} {
+		require.NoError(t, os.WriteFile(GetComponentLogPath(name), nil, 0o644))
+	}
+	components, err = ListComponentLogs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aggregator", "executor", "run"}, components)
+}
+
+func TestValidateLogName(t *testing.T) {
+	for _, name := range []string{"run", "aggregator", "executor-1", "avs.v2_log"} {
+		assert.NoError(t, ValidateLogName(name), name)
+	}
+	for _, name := range []string{"", "../config/contexts/devnet", "/etc/passwd", "logs/run", ".hidden", "-flag"} {
+		assert.Error(t, ValidateLogName(name), name)
+	}
+}
+
+func TestSplitComponentLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		component string
+		rest      string
+		ok        bool
+	}{
+		{line: "[aggregator] task received\n", component: "aggregator", rest: "task received\n", ok: true},
+		{line: "executor-1  | signed task 3\n", component: "executor-1", rest: "signed task 3\n", ok: true},
+		{line: "building contracts...\n", rest: "building contracts...\n"},
+		{line: "[../etc] escape\n", rest: "[../etc] escape\n"},
+	}
+	for _, tt := range tests {
+		component, rest, ok := SplitComponentLine(tt.line)
+		assert.Equal(t, tt.ok, ok, tt.line)
+		assert.Equal(t, tt.component, component, tt.line)
+		assert.Equal(t, tt.rest, rest, tt.line)
+	}
+}
+
+func TestComponentLogWriter(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aggregator.log"), []byte("from the last run\n"), 0o644))
+
+	w, err := NewComponentLogWriter(dir)
+	require.NoError(t, err)
+	// Lines may arrive split across writes
+	for _, chunk := range []string{"starting\n[aggre", "gator] listening on :8081\nexecutor | ready\n", "[aggregator] task 1\n", "[executor] done"} {
+		_, err := w.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name+".log"))
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "starting\n[aggregator] listening on :8081\nexecutor | ready\n[aggregator] task 1\n[executor] done\n", read("run"))
+	assert.Equal(t, "listening on :8081\ntask 1\n", read("aggregator"))
+	assert.Equal(t, "ready\ndone\n", read("executor"))
+}
+
+// syncBuffer is a bytes.Buffer safe for one writer and one reader goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,413 @@
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/internal/version"
+)
+
+const (
+	// nativeStartGrace is how long a freshly started anvil is watched for an immediate exit, such as a bad argument
+	nativeStartGrace = time.Second
+	// nativeStopTimeout is how long anvil gets to dump its state and exit before it is killed
+	nativeStopTimeout = 10 * time.Second
+	// nativeLogTailLines is how much of an anvil log is quoted when it exits during startup
+	nativeLogTailLines = 20
+)
+
+// nativeInstance is what the anvil backend records about a chain it started, next to the chain's PID file
+type nativeInstance struct {
+	PID           int       `json:"pid"`
+	Project       string    `json:"project"`
+	Chain         string    `json:"chain"`
+	Port          int       `json:"port"`
+	DevkitVersion string    `json:"devkit_version"`
+	StartedAt     time.Time `json:"started_at"`
+
+	// dir is the .devkit/devnet directory the instance was read from
+	dir string
+}
+
+// container describes the instance the way the docker backend describes its containers
+func (i nativeInstance) container() DevnetContainer {
+	return DevnetContainer{
+		ID:            strconv.Itoa(i.PID),
+		Name:          GetDevnetContainerName(i.Project, i.Chain),
+		Project:       i.Project,
+		Chain:         i.Chain,
+		DevkitVersion: i.DevkitVersion,
+		HostPorts:     []int{i.Port},
+		Running:       i.alive(),
+		Created:       i.StartedAt,
+		Backend:       BACKEND_ANVIL,
+	}
+}
+
+// GetNativeDevnetDir returns the project directory the anvil backend keeps its PID, instance and log files in
+func GetNativeDevnetDir() string {
+	return filepath.Join(".devkit", "devnet")
+}
+
+// GetNativeChainPIDPath returns the PID file of a chain run by the anvil backend
+func GetNativeChainPIDPath(chainName string) string {
+	return nativePIDPath(GetNativeDevnetDir(), chainName)
+}
+
+// GetNativeChainLogPath returns the file a chain run by the anvil backend logs to
+func GetNativeChainLogPath(chainName string) string {
+	return filepath.Join(GetNativeDevnetDir(), chainName+".log")
+}
+
+func nativeInstancePath(dir, chainName string) string {
+	return filepath.Join(dir, chainName+".json")
+}
+
+func nativePIDPath(dir, chainName string) string {
+	return filepath.Join(dir, chainName+".pid")
+}
+
+// nativeBackend runs anvil as a child process of devkit. The process is put in its own process group so it
+// outlives the devkit command that started it and is found again through the files under .devkit/devnet.
+type nativeBackend struct {
+	// projectDirs returns the project directories whose natively run chains are discovered
+	projectDirs func() []string
+}
+
+// NewNativeBackend returns the backend running every chain as an anvil process on the host
+func NewNativeBackend() Backend {
+	return &nativeBackend{projectDirs: nativeProjectDirs}
+}
+
+// nativeProjectDirs returns the working directory and the directories of natively run devnets in the registry
+func nativeProjectDirs() []string {
+	dirs := []string{}
+	if wd, err := os.Getwd(); err == nil {
+		dirs = append(dirs, wd)
+	}
+	if registry, err := LoadDevnetRegistry(); err == nil {
+		for _, entry := range registry.Devnets {
+			if entry.Backend == BACKEND_ANVIL && entry.ProjectDir != "" {
+				dirs = append(dirs, entry.ProjectDir)
+			}
+		}
+	}
+	return dirs
+}
+
+func (b *nativeBackend) Name() string {
+	return BACKEND_ANVIL
+}
+
+func (b *nativeBackend) StartChain(ctx context.Context, launch ChainLaunch) error {
+	chain := launch.Chain
+	anvilPath, err := exec.LookPath("anvil")
+	if err != nil {
+		return fmt.Errorf("anvil not found on PATH; install Foundry (https://getfoundry.sh) or use --backend %s: %w", BACKEND_DOCKER, err)
+	}
+
+	dir, err := filepath.Abs(GetNativeDevnetDir())
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", GetNativeDevnetDir(), err)
+	}
+	if existing, err := readNativeInstance(nativeInstancePath(dir, chain.Name)); err == nil && existing.alive() {
+		return fmt.Errorf("chain %s is already running as pid %d; stop it with `devkit avs devnet stop`", chain.Name, existing.PID)
+	}
+	if err := ensureDevnetStateDir(); err != nil {
+		return err
+	}
+
+	logPath := GetNativeChainLogPath(chain.Name)
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to create anvil log %s: %w", logPath, err)
+	}
+	defer logFile.Close()
+
+	// Anvil writes its state on exit so a stopped chain can be resumed
+	args := strings.Fields(chain.HostAnvilArgs(launch.BaseArgs) + " " + StateArgs(GetDevnetChainStatePath(chain.Name), launch.Resume))
+
+	// Not bound to ctx: the chain keeps running after the starting command returns
+	cmd := exec.Command(anvilPath, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start anvil: %w", err)
+	}
+	// Reap the process if it exits while devkit is still running
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	instance := nativeInstance{
+		PID:           cmd.Process.Pid,
+		Project:       launch.ProjectName,
+		Chain:         chain.Name,
+		Port:          chain.Port,
+		DevkitVersion: version.GetVersion(),
+		StartedAt:     time.Now().UTC(),
+		dir:           dir,
+	}
+	if err := writeNativeInstance(instance); err != nil {
+		_ = syscall.Kill(-instance.PID, syscall.SIGKILL)
+		return err
+	}
+
+	select {
+	case <-exited:
+		removeNativeInstance(instance)
+		var tail bytes.Buffer
+		_ = TailFile(ctx, logPath, nativeLogTailLines, false, &tail)
+		return fmt.Errorf("anvil exited during startup; see %s:\n%s", logPath, strings.TrimSpace(tail.String()))
+	case <-time.After(nativeStartGrace):
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (b *nativeBackend) DestroyChain(ctx context.Context, projectName, chainName string) error {
+	dir, err := filepath.Abs(GetNativeDevnetDir())
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", GetNativeDevnetDir(), err)
+	}
+	instance, err := readNativeInstance(nativeInstancePath(dir, chainName))
+	if err == nil {
+		if err := stopNativeInstance(ctx, instance); err != nil {
+			return err
+		}
+		removeNativeInstance(instance)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, path := range []string{GetDevnetChainStatePath(chainName), GetNativeChainLogPath(chainName)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (b *nativeBackend) List(ctx context.Context, filter ContainerFilter) ([]DevnetContainer, error) {
+	instances, err := b.instances()
+	if err != nil {
+		return nil, err
+	}
+	containers := []DevnetContainer{}
+	for _, instance := range instances {
+		c := instance.container()
+		if filter.Project != "" && c.Project != filter.Project {
+			continue
+		}
+		if filter.Chain != "" && c.Chain != filter.Chain {
+			continue
+		}
+		if !filter.All && !c.Running {
+			continue
+		}
+		containers = append(containers, c)
+	}
+	SortDevnetContainers(containers)
+	return containers, nil
+}
+
+func (b *nativeBackend) Get(ctx context.Context, ref string) (*DevnetContainer, error) {
+	instance, err := b.find(ref)
+	if err != nil {
+		return nil, err
+	}
+	c := instance.container()
+	return &c, nil
+}
+
+func (b *nativeBackend) Stop(ctx context.Context, ref string) error {
+	instance, err := b.find(ref)
+	if err != nil {
+		return err
+	}
+	return stopNativeInstance(ctx, instance)
+}
+
+func (b *nativeBackend) Remove(ctx context.Context, ref string) error {
+	instance, err := b.find(ref)
+	if err != nil {
+		return err
+	}
+	if instance.alive() {
+		return fmt.Errorf("anvil for %s is running as pid %d", ref, instance.PID)
+	}
+	removeNativeInstance(instance)
+	return nil
+}
+
+func (b *nativeBackend) Close() error {
+	return nil
+}
+
+// instances reads the instance file of every chain in the known project directories
+func (b *nativeBackend) instances() ([]nativeInstance, error) {
+	instances := []nativeInstance{}
+	seen := map[string]bool{}
+	for _, projectDir := range b.projectDirs() {
+		dir, err := filepath.Abs(filepath.Join(projectDir, GetNativeDevnetDir()))
+		if err != nil || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+		for _, path := range paths {
+			instance, err := readNativeInstance(path)
+			if err != nil {
+				continue
+			}
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
+}
+
+// find returns the instance with the given pid or container name
+func (b *nativeBackend) find(ref string) (nativeInstance, error) {
+	instances, err := b.instances()
+	if err != nil {
+		return nativeInstance{}, err
+	}
+	for _, instance := range instances {
+		c := instance.container()
+		if c.ID == ref || c.Name == ref {
+			return instance, nil
+		}
+	}
+	return nativeInstance{}, fmt.Errorf("%w: %s", ErrContainerNotFound, ref)
+}
+
+func readNativeInstance(path string) (nativeInstance, error) {
+	var instance nativeInstance
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return instance, err
+	}
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return instance, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if instance.PID <= 0 || instance.Chain == "" {
+		return instance, fmt.Errorf("%s is not a devnet instance file", path)
+	}
+	instance.dir = filepath.Dir(path)
+	return instance, nil
+}
+
+// writeNativeInstance writes the chain's PID file and the instance file describing it
+func writeNativeInstance(instance nativeInstance) error {
+	data, err := json.MarshalIndent(instance, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal devnet instance: %w", err)
+	}
+	if err := os.WriteFile(nativeInstancePath(instance.dir, instance.Chain), data, 0644); err != nil {
+		return fmt.Errorf("failed to write devnet instance file: %w", err)
+	}
+	pidPath := nativePIDPath(instance.dir, instance.Chain)
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(instance.PID)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write pid file %s: %w", pidPath, err)
+	}
+	return nil
+}
+
+// removeNativeInstance deletes the chain's PID and instance files; its log and dumped state are kept
+func removeNativeInstance(instance nativeInstance) {
+	_ = os.Remove(nativeInstancePath(instance.dir, instance.Chain))
+	_ = os.Remove(nativePIDPath(instance.dir, instance.Chain))
+}
+
+// stopNativeInstance asks anvil to exit so it can dump its state, killing it if it does not within nativeStopTimeout.
+// A PID that no longer belongs to anvil is left alone; the instance is only stale.
+func stopNativeInstance(ctx context.Context, instance nativeInstance) error {
+	if !instance.alive() {
+		return nil
+	}
+	if err := signalNativeProcess(instance.PID, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to stop anvil (pid %d): %w", instance.PID, err)
+	}
+
+	deadline := time.Now().Add(nativeStopTimeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(instance.PID) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	if !instance.alive() {
+		return nil
+	}
+	if err := signalNativeProcess(instance.PID, syscall.SIGKILL); err != nil {
+		return fmt.Errorf("failed to kill anvil (pid %d): %w", instance.PID, err)
+	}
+	return nil
+}
+
+// signalNativeProcess signals anvil's process group, falling back to the process itself
+func signalNativeProcess(pid int, sig syscall.Signal) error {
+	if err := syscall.Kill(-pid, sig); err == nil || (errors.Is(err, syscall.ESRCH) && !processAlive(pid)) {
+		return nil
+	}
+	if err := syscall.Kill(pid, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
+
+// anvilProcessName is the executable name of the processes the anvil backend starts
+var anvilProcessName = "anvil"
+
+// alive reports whether the instance's anvil is still running. Once anvil exits its PID can be reused by an
+// unrelated process, so the process behind the PID must still be anvil.
+func (i nativeInstance) alive() bool {
+	if !processAlive(i.PID) {
+		return false
+	}
+	name, err := processName(i.PID)
+	return err == nil && name == anvilProcessName
+}
+
+// processName returns the executable name of pid, from /proc where there is one and otherwise from ps
+func processName(pid int) (string, error) {
+	if cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid)); err == nil {
+		argv0, _, _ := strings.Cut(string(cmdline), "\x00")
+		return filepath.Base(argv0), nil
+	}
+	out, err := exec.Command("ps", "-o", "comm=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to look up process %d: %w", pid, err)
+	}
+	return filepath.Base(strings.TrimSpace(string(out))), nil
+}
+
+// processAlive reports whether a process with pid exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,125 @@
+package devnet
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSleeper starts a process in its own group the way the anvil backend starts anvil, and has the backend
+// take sleep for anvil
+func startSleeper(t *testing.T) *exec.Cmd {
+	t.Helper()
+	setAnvilProcessName(t, "sleep")
+	cmd := exec.Command("sleep", "30")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		<-exited
+	})
+	return cmd
+}
+
+func setAnvilProcessName(t *testing.T, name string) {
+	t.Helper()
+	original := anvilProcessName
+	anvilProcessName = name
+	t.Cleanup(func() { anvilProcessName = original })
+}
+
+func TestNativeBackendDiscoversInstances(t *testing.T) {
+	ctx := context.Background()
+	projectDir := t.TempDir()
+	dir := filepath.Join(projectDir, GetNativeDevnetDir())
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	running := startSleeper(t)
+	require.NoError(t, writeNativeInstance(nativeInstance{PID: running.Process.Pid, Project: "alpha", Chain: L1, Port: 8545, StartedAt: time.Now(), dir: dir}))
+
+	// A process that already exited stands in for an anvil that was stopped or crashed
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+	require.NoError(t, writeNativeInstance(nativeInstance{PID: exited.Process.Pid, Project: "alpha", Chain: "l2", Port: 8546, dir: dir}))
+
+	pid, err := os.ReadFile(filepath.Join(dir, L1+".pid"))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(running.Process.Pid)+"\n", string(pid))
+
+	backend := &nativeBackend{projectDirs: func() []string { return []string{projectDir, projectDir} }}
+
+	containers, err := backend.List(ctx, ContainerFilter{})
+	require.NoError(t, err)
+	require.Len(t, containers, 1, "the stopped chain is only listed with All")
+	assert.Equal(t, "devkit-devnet-alpha", containers[0].Name)
+	assert.Equal(t, 8545, containers[0].HostPort())
+	assert.Equal(t, BACKEND_ANVIL, containers[0].Backend)
+
+	all, err := backend.List(ctx, ContainerFilter{Project: "alpha", All: true})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	c, err := backend.Get(ctx, strconv.Itoa(running.Process.Pid))
+	require.NoError(t, err)
+	assert.True(t, c.Running)
+	_, err = backend.Get(ctx, "devkit-devnet-beta")
+	assert.ErrorIs(t, err, ErrContainerNotFound)
+
+	assert.Error(t, backend.Remove(ctx, "devkit-devnet-alpha"), "a running chain cannot be removed")
+	require.NoError(t, backend.Remove(ctx, "devkit-devnet-alpha-l2"))
+	assert.NoFileExists(t, filepath.Join(dir, "l2.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "l2.pid"))
+}
+
+func TestNativeBackendStop(t *testing.T) {
+	ctx := context.Background()
+	projectDir := t.TempDir()
+	dir := filepath.Join(projectDir, GetNativeDevnetDir())
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	cmd := startSleeper(t)
+	require.NoError(t, writeNativeInstance(nativeInstance{PID: cmd.Process.Pid, Project: "alpha", Chain: L1, Port: 8545, dir: dir}))
+	backend := &nativeBackend{projectDirs: func() []string { return []string{projectDir} }}
+
+	require.NoError(t, StopAndRemoveDevnetContainer(ctx, backend, "devkit-devnet-alpha"))
+	assert.False(t, processAlive(cmd.Process.Pid))
+
+	containers, err := backend.List(ctx, ContainerFilter{All: true})
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+}
+
+func TestNativeBackendIgnoresReusedPID(t *testing.T) {
+	ctx := context.Background()
+	projectDir := t.TempDir()
+	dir := filepath.Join(projectDir, GetNativeDevnetDir())
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	// The recorded PID now belongs to a process that is not anvil
+	cmd := startSleeper(t)
+	setAnvilProcessName(t, "anvil")
+	require.NoError(t, writeNativeInstance(nativeInstance{PID: cmd.Process.Pid, Project: "alpha", Chain: L1, Port: 8545, dir: dir}))
+	backend := &nativeBackend{projectDirs: func() []string { return []string{projectDir} }}
+
+	containers, err := backend.List(ctx, ContainerFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, containers, "a reused PID is not a running chain")
+
+	require.NoError(t, StopAndRemoveDevnetContainer(ctx, backend, "devkit-devnet-alpha"))
+	assert.True(t, processAlive(cmd.Process.Pid), "the unrelated process is not signalled")
+	assert.NoFileExists(t, filepath.Join(dir, L1+".json"))
+	assert.NoFileExists(t, filepath.Join(dir, L1+".pid"))
+}
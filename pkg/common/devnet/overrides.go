@@ -0,0 +1,169 @@
+package devnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DEVNET_OVERRIDES_FILE records the devnet context values a `devnet start` replaced for that run only
+const DEVNET_OVERRIDES_FILE = "context-overrides.yaml"
+
+// ContextOverride is a devnet context value replaced for one run, together with the value it replaced
+type ContextOverride struct {
+	Path []string `yaml:"path"`
+	// Reason names the flag that made the change
+	Reason string `yaml:"reason"`
+	// Previous is the replaced value; Absent is set when the key did not exist before
+	Previous yaml.Node `yaml:"previous,omitempty"`
+	Absent   bool      `yaml:"absent,omitempty"`
+}
+
+// Key returns the dotted context path of the override, e.g. chains.l1.fork
+func (o ContextOverride) Key() string {
+	return strings.Join(o.Path, ".")
+}
+
+type contextOverrides struct {
+	Overrides []ContextOverride `yaml:"overrides"`
+}
+
+// GetContextOverridesPath returns the project file recording the run-scoped changes to the devnet context
+func GetContextOverridesPath() string {
+	return filepath.Join(GetNativeDevnetDir(), DEVNET_OVERRIDES_FILE)
+}
+
+// OverrideContextValue sets path in the devnet context mapping to value for the current run only. The value it
+// replaces is recorded so RestoreContextOverrides can put it back; when path was already overridden by an
+// earlier run, the value from before that run is kept.
+func OverrideContextValue(contextNode *yaml.Node, path []string, value *yaml.Node, reason string) error {
+	parent, err := contextParent(contextNode, path)
+	if err != nil {
+		return err
+	}
+	key := path[len(path)-1]
+
+	record, err := readContextOverrides(GetContextOverridesPath())
+	if err != nil {
+		return err
+	}
+	override := ContextOverride{Path: path, Reason: reason}
+	recorded := false
+	for i, existing := range record.Overrides {
+		if existing.Key() == override.Key() {
+			record.Overrides[i].Reason = reason
+			recorded = true
+		}
+	}
+	if !recorded {
+		if previous := devkitcommon.GetChildByKey(parent, key); previous != nil {
+			override.Previous = *devkitcommon.CloneNode(previous)
+		} else {
+			override.Absent = true
+		}
+		record.Overrides = append(record.Overrides, override)
+	}
+	// The record is written first so a failure leaves nothing unrecorded in the context
+	if err := writeContextOverrides(GetContextOverridesPath(), record); err != nil {
+		return err
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	devkitcommon.SetMappingValue(parent, keyNode, value)
+	return nil
+}
+
+// RestoreContextOverrides puts back the devnet context values replaced by earlier runs and returns the overrides
+// it undid. The record is kept until ClearContextOverrides, so the caller can save the context first.
+func RestoreContextOverrides(contextNode *yaml.Node) ([]ContextOverride, error) {
+	record, err := readContextOverrides(GetContextOverridesPath())
+	if err != nil {
+		return nil, err
+	}
+	// Latest first, so nested overrides unwind in the order they were made
+	for i := len(record.Overrides) - 1; i >= 0; i-- {
+		override := record.Overrides[i]
+		parent, err := contextParent(contextNode, override.Path)
+		if err != nil {
+			continue
+		}
+		key := override.Path[len(override.Path)-1]
+		if override.Absent {
+			removeMappingKey(parent, key)
+			continue
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+		devkitcommon.SetMappingValue(parent, keyNode, devkitcommon.CloneNode(&override.Previous))
+	}
+	return record.Overrides, nil
+}
+
+// ClearContextOverrides forgets the recorded overrides once the context they were restored in is saved
+func ClearContextOverrides() error {
+	if err := os.Remove(GetContextOverridesPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", GetContextOverridesPath(), err)
+	}
+	return nil
+}
+
+// contextParent returns the mapping holding the last key of path
+func contextParent(contextNode *yaml.Node, path []string) (*yaml.Node, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty devnet context path")
+	}
+	node := contextNode
+	for i, key := range path[:len(path)-1] {
+		node = devkitcommon.GetChildByKey(node, key)
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("devnet context has no %s mapping", strings.Join(path[:i+1], "."))
+		}
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("devnet context is not a mapping")
+	}
+	return node, nil
+}
+
+// removeMappingKey drops key and its value from a mapping node
+func removeMappingKey(mapNode *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapNode.Content); i += 2 {
+		if mapNode.Content[i].Value == key {
+			mapNode.Content = append(mapNode.Content[:i], mapNode.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func readContextOverrides(path string) (*contextOverrides, error) {
+	record := &contextOverrides{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return record, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return record, nil
+}
+
+func writeContextOverrides(path string, record *contextOverrides) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal devnet context overrides: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
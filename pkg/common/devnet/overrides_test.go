@@ -0,0 +1,84 @@
+package devnet
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const overridesTestContext = `
+context:
+  name: devnet
+  chains:
+    l1:
+      chain_id: 31337
+      fork:
+        url: ""
+        block: 100
+  eigenlayer:
+    l1:
+      allocation_manager: "0x00000000000000000000000000000000000000a1"
+`
+
+func loadOverridesTestContext(t *testing.T) (*yaml.Node, *yaml.Node) {
+	t.Helper()
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(overridesTestContext), &root))
+	return &root, common.GetChildByKey(root.Content[0], "context")
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+func TestContextOverrides(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	root, contextNode := loadOverridesTestContext(t)
+	original, err := yaml.Marshal(root)
+	require.NoError(t, err)
+
+	// Nothing recorded leaves the context untouched
+	restored, err := RestoreContextOverrides(contextNode)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	local := &yaml.Node{Kind: yaml.MappingNode}
+	common.SetMappingValue(local, scalarNode("l1"), scalarNode("local"))
+	require.NoError(t, OverrideContextValue(contextNode, []string{"eigenlayer"}, local, "--no-fork"))
+	require.NoError(t, OverrideContextValue(contextNode, []string{"chains", "l1", "chain_id"}, scalarNode("1"), "--fork mainnet"))
+	require.NoError(t, OverrideContextValue(contextNode, []string{"chains", "l1", "fork", "preset"}, scalarNode("mainnet"), "--fork mainnet"))
+	assert.Equal(t, "local", common.GetChildByKey(common.GetChildByKey(contextNode, "eigenlayer"), "l1").Value)
+
+	// A second run overriding the same value keeps the value from before the first
+	require.NoError(t, OverrideContextValue(contextNode, []string{"chains", "l1", "chain_id"}, scalarNode("17000"), "--fork holesky"))
+
+	require.NoError(t, DiscardDevnetState())
+	_, err = os.Stat(GetContextOverridesPath())
+	require.NoError(t, err, "overrides are recorded outside the state dir")
+
+	restored, err = RestoreContextOverrides(contextNode)
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+	assert.Equal(t, "chains.l1.chain_id", restored[1].Key())
+	assert.Equal(t, "--fork holesky", restored[1].Reason)
+
+	got, err := yaml.Marshal(root)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(got), "previous values restored and added keys removed")
+
+	require.NoError(t, ClearContextOverrides())
+	restored, err = RestoreContextOverrides(contextNode)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	assert.ErrorContains(t, OverrideContextValue(contextNode, []string{"chains", "l2", "chain_id"}, scalarNode("1"), "--fork"), "chains.l2")
+}
@@ -0,0 +1,206 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ReadinessOptions controls how WaitForDevnetReady probes a freshly started chain
+type ReadinessOptions struct {
+	// ExpectedChainID is the chain id the node must report (0 skips the check)
+	ExpectedChainID uint64
+	// ForkBlock is the block the node must report it forked at through anvil_nodeInfo (0 skips the check)
+	ForkBlock uint64
+	// Timeout bounds the total time spent waiting
+	Timeout time.Duration
+	// PollInterval is the delay between probes
+	PollInterval time.Duration
+	// LogsCommand is included in diagnostics so users know where to look for the chain's logs
+	LogsCommand string
+}
+
+// errNotReady marks a probe failure that should be retried
+var errNotReady = errors.New("devnet not ready")
+
+// WaitForDevnetReady polls the JSON-RPC endpoint at rpcURL until it answers eth_chainId and
+// eth_blockNumber, reports the expected chain id and was forked at the expected fork block.
+// A chain id or fork block mismatch fails immediately, every other failure is retried until opts.Timeout.
+func WaitForDevnetReady(ctx context.Context, logger iface.Logger, rpcURL string, opts ReadinessOptions) error {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DEVNET_READY_TIMEOUT
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DEVNET_READY_POLL_INTERVAL
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc client for %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	deadline := time.NewTimer(opts.Timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	attempts := 0
+	var lastErr error
+	for {
+		attempts++
+		head, err := probeDevnet(ctx, client, opts)
+		if err == nil {
+			logger.Info("Devnet ready at block %d after %s", head, time.Since(start).Round(time.Millisecond))
+			return nil
+		}
+		if !errors.Is(err, errNotReady) {
+			return err
+		}
+		if lastErr == nil || lastErr.Error() != err.Error() {
+			logger.Debug("Devnet not ready yet: %v", err)
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return readinessTimeoutError(rpcURL, opts, attempts, time.Since(start), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeDevnet runs a single readiness probe and returns the current head on success
+func probeDevnet(ctx context.Context, client *ethclient.Client, opts ReadinessOptions) (uint64, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, opts.PollInterval+DEVNET_READY_PROBE_TIMEOUT)
+	defer cancel()
+
+	chainID, err := client.ChainID(probeCtx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: eth_chainId: %v", errNotReady, err)
+	}
+	if opts.ExpectedChainID != 0 && chainID.Uint64() != opts.ExpectedChainID {
+		return 0, fmt.Errorf("devnet reports chain id %d but %d is configured; check chain_id in ./config/contexts/devnet.yaml", chainID.Uint64(), opts.ExpectedChainID)
+	}
+
+	head, err := client.BlockNumber(probeCtx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: eth_blockNumber: %v", errNotReady, err)
+	}
+	if opts.ForkBlock != 0 {
+		// Ask the node where it forked; a fork at latest or at another block would otherwise pass unnoticed
+		forkBlock, forked, err := (&AnvilClient{rpc: client.Client()}).ForkBlock(probeCtx)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", errNotReady, err)
+		}
+		if !forked {
+			return 0, fmt.Errorf("devnet is not forked but fork block %d is configured; check that the chain was started with the fork url in ./config/contexts/devnet.yaml", opts.ForkBlock)
+		}
+		if forkBlock != opts.ForkBlock {
+			return 0, fmt.Errorf("devnet forked at block %d but fork block %d is configured; check fork.block in ./config/contexts/devnet.yaml or restart with --reset", forkBlock, opts.ForkBlock)
+		}
+	}
+
+	return head, nil
+}
+
+// readinessTimeoutError builds an error that explains what was last observed and where to look next
+func readinessTimeoutError(rpcURL string, opts ReadinessOptions, attempts int, elapsed time.Duration, lastErr error) error {
+	msg := fmt.Sprintf("devnet at %s was not ready after %s (%d probes)", rpcURL, elapsed.Round(time.Millisecond), attempts)
+	if lastErr != nil {
+		msg = fmt.Sprintf("%s: last error: %v", msg, lastErr)
+	}
+	if opts.ForkBlock != 0 {
+		msg = fmt.Sprintf("%s\n  - check that the fork url is reachable and can serve state at block %d (an archive node may be required)", msg, opts.ForkBlock)
+	}
+	if opts.LogsCommand != "" {
+		msg = fmt.Sprintf("%s\n  - inspect the chain logs with `%s`", msg, opts.LogsCommand)
+	}
+	msg = fmt.Sprintf("%s\n  - increase the wait with --ready-timeout if the fork rpc is slow", msg)
+	return errors.New(msg)
+}
+
+// WaitForBalances polls until every address holds at least minBalance wei
+func WaitForBalances(ctx context.Context, rpcURL string, addresses []common.Address, minBalance *big.Int, timeout time.Duration) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc client for %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	return pollUntil(ctx, timeout, func(ctx context.Context) error {
+		for _, addr := range addresses {
+			balance, err := client.BalanceAt(ctx, addr, nil)
+			if err != nil {
+				return fmt.Errorf("failed to get balance for %s: %w", addr.Hex(), err)
+			}
+			if balance.Cmp(minBalance) < 0 {
+				return fmt.Errorf("%s has %s wei, expected at least %s", addr.Hex(), balance, minBalance)
+			}
+		}
+		return nil
+	})
+}
+
+// WaitForContractCode polls until every address has non-empty code
+func WaitForContractCode(ctx context.Context, rpcURL string, addresses []common.Address, timeout time.Duration) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc client for %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	return pollUntil(ctx, timeout, func(ctx context.Context) error {
+		for _, addr := range addresses {
+			code, err := client.CodeAt(ctx, addr, nil)
+			if err != nil {
+				return fmt.Errorf("failed to get code at %s: %w", addr.Hex(), err)
+			}
+			if len(code) == 0 {
+				return fmt.Errorf("no contract code at %s", addr.Hex())
+			}
+		}
+		return nil
+	})
+}
+
+// pollUntil calls check every DEVNET_READY_POLL_INTERVAL until it succeeds or timeout elapses
+func pollUntil(ctx context.Context, timeout time.Duration, check func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		timeout = DEVNET_READY_TIMEOUT
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(DEVNET_READY_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		err := check(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("timed out after %s: %w", timeout, err)
+		case <-ticker.C:
+		}
+	}
+}
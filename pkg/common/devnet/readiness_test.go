@@ -0,0 +1,132 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeRPC serves a chain forked at its head block, see newFakeForkedRPC
+func newFakeRPC(t *testing.T, chainID, head uint64, failures int32) *httptest.Server {
+	return newFakeForkedRPC(t, chainID, head, &head, failures)
+}
+
+// newFakeForkedRPC serves eth_chainId, eth_blockNumber, the latest header, anvil_nodeInfo reporting forkBlock (nil for
+// a chain that is not forked) and the anvil state methods, failing the first `failures` chain id probes
+func newFakeForkedRPC(t *testing.T, chainID, head uint64, forkBlock *uint64, failures int32) *httptest.Server {
+	var calls int32
+	nodeInfo := map[string]any{"forkConfig": map[string]any{"forkBlockNumber": forkBlock}}
+	return rpctest.NewServer(t, rpctest.Handlers{
+		"eth_chainId": func([]json.RawMessage) (any, error) {
+			if calls++; calls <= failures {
+				return nil, errors.New("starting")
+			}
+			return hexutil.Uint64(chainID), nil
+		},
+		"eth_blockNumber":      rpctest.Result(hexutil.Uint64(head)),
+		"eth_getBlockByNumber": rpctest.Result(json.RawMessage(fmt.Sprintf(`{"number":"0x%x","timestamp":"0x6553f100","parentHash":"0x%064x","sha3Uncles":"0x%064x","miner":"0x%040x","stateRoot":"0x%064x","transactionsRoot":"0x%064x","receiptsRoot":"0x%064x","logsBloom":"0x%0512x","difficulty":"0x0","gasLimit":"0x0","gasUsed":"0x0","extraData":"0x","mixHash":"0x%064x","nonce":"0x0000000000000000"}`, head, 0, 0, 0, 0, 0, 0, 0, 0))),
+		"anvil_nodeInfo":       rpctest.Result(nodeInfo),
+		"anvil_dumpState":      rpctest.Result("0x1f8b"),
+		"anvil_loadState":      rpctest.Result(true),
+	})
+}
+
+func TestWaitForDevnetReady(t *testing.T) {
+	log := logger.NewNoopLogger()
+
+	tests := []struct {
+		name        string
+		chainID     uint64
+		head        uint64
+		forkedAt    *uint64
+		failures    int32
+		opts        ReadinessOptions
+		errContains string
+	}{
+		{
+			name:     "ready immediately",
+			chainID:  31337,
+			head:     110,
+			forkedAt: uint64Ptr(100),
+			opts:     ReadinessOptions{ExpectedChainID: 31337, ForkBlock: 100},
+		},
+		{
+			name:     "ready after transient failures",
+			chainID:  31337,
+			head:     100,
+			forkedAt: uint64Ptr(100),
+			failures: 3,
+			opts:     ReadinessOptions{ExpectedChainID: 31337, ForkBlock: 100},
+		},
+		{
+			name:        "chain id mismatch fails fast",
+			chainID:     1,
+			head:        100,
+			opts:        ReadinessOptions{ExpectedChainID: 31337, Timeout: time.Minute},
+			errContains: "chain id 1",
+		},
+		{
+			name:        "fork block mismatch fails fast",
+			chainID:     31337,
+			head:        120,
+			forkedAt:    uint64Ptr(120),
+			opts:        ReadinessOptions{ExpectedChainID: 31337, ForkBlock: 100, Timeout: time.Minute},
+			errContains: "devnet forked at block 120 but fork block 100 is configured",
+		},
+		{
+			name:        "not forked fails fast",
+			chainID:     31337,
+			head:        120,
+			opts:        ReadinessOptions{ExpectedChainID: 31337, ForkBlock: 100, Timeout: time.Minute},
+			errContains: "devnet is not forked",
+		},
+		{
+			name:        "unreachable node times out",
+			chainID:     31337,
+			head:        100,
+			failures:    1 << 30,
+			opts:        ReadinessOptions{ExpectedChainID: 31337, ForkBlock: 100, Timeout: 200 * time.Millisecond, LogsCommand: "devkit avs devnet logs l1"},
+			errContains: "devkit avs devnet logs l1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeForkedRPC(t, tt.chainID, tt.head, tt.forkedAt, tt.failures)
+			tt.opts.PollInterval = 10 * time.Millisecond
+
+			err := WaitForDevnetReady(context.Background(), log, srv.URL, tt.opts)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestWaitForDevnetReady_Cancelled(t *testing.T) {
+	srv := newFakeRPC(t, 31337, 100, 1<<30)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WaitForDevnetReady(ctx, logger.NewNoopLogger(), srv.URL, ReadinessOptions{PollInterval: 10 * time.Millisecond})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,292 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DEVNET_REGISTRY_FILE is the file under the global config dir that records every running devnet chain
+const DEVNET_REGISTRY_FILE = "devnets.yaml"
+
+// portSearchLimit bounds how many base ports are tried when allocating ports automatically
+const portSearchLimit = 200
+
+// DevnetRegistryEntry records one running devnet chain container
+type DevnetRegistryEntry struct {
+	ProjectName   string    `yaml:"project_name"`
+	ProjectDir    string    `yaml:"project_dir"`
+	Chain         string    `yaml:"chain"`
+	Port          int       `yaml:"port"`
+	ChainID       int       `yaml:"chain_id"`
+	ContainerID   string    `yaml:"container_id"`
+	ContainerName string    `yaml:"container_name"`
+	StartedAt     time.Time `yaml:"started_at"`
+	// Backend is the devnet backend running the chain; entries written before backends existed are docker
+	Backend string `yaml:"backend,omitempty"`
+}
+
+// DevnetRegistry is the set of devnet chains started by devkit on this machine, across all projects
+type DevnetRegistry struct {
+	Devnets []DevnetRegistryEntry `yaml:"devnets"`
+}
+
+// GetDevnetRegistryPath returns the path of the devnet registry in the global config dir
+func GetDevnetRegistryPath() (string, error) {
+	dir, err := devkitcommon.GetGlobalConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DEVNET_REGISTRY_FILE), nil
+}
+
+// LoadDevnetRegistry reads the devnet registry; a missing file is an empty registry
+func LoadDevnetRegistry() (*DevnetRegistry, error) {
+	path, err := GetDevnetRegistryPath()
+	if err != nil {
+		return nil, err
+	}
+	return readDevnetRegistry(path)
+}
+
+// UpdateDevnetRegistry applies fn to the registry and saves the result. The registry is locked for the
+// duration so devkit processes started from different projects do not overwrite each other's entries.
+func UpdateDevnetRegistry(fn func(r *DevnetRegistry) error) error {
+	path, err := GetDevnetRegistryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open devnet registry lock: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock devnet registry: %w", err)
+	}
+	defer func() { _ = syscall.Flock(int(lock.Fd()), syscall.LOCK_UN) }()
+
+	registry, err := readDevnetRegistry(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(registry); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal devnet registry: %w", err)
+	}
+	// Write to a temp file first so readers never see a partial registry
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write devnet registry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write devnet registry: %w", err)
+	}
+	return nil
+}
+
+func readDevnetRegistry(path string) (*DevnetRegistry, error) {
+	registry := &DevnetRegistry{Devnets: []DevnetRegistryEntry{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read devnet registry: %w", err)
+	}
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse devnet registry %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// Upsert records entry, replacing any earlier entry for the same container
+func (r *DevnetRegistry) Upsert(entry DevnetRegistryEntry) {
+	for i, existing := range r.Devnets {
+		if existing.ContainerName == entry.ContainerName {
+			r.Devnets[i] = entry
+			return
+		}
+	}
+	r.Devnets = append(r.Devnets, entry)
+}
+
+// RemoveContainers drops the entries of the named containers
+func (r *DevnetRegistry) RemoveContainers(names ...string) {
+	r.filter(func(entry DevnetRegistryEntry) bool {
+		for _, name := range names {
+			if entry.ContainerName == name {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ProjectEntries returns the entries of a project's devnet, L1 first and the rest sorted by chain
+func (r *DevnetRegistry) ProjectEntries(projectName string) []DevnetRegistryEntry {
+	entries := []DevnetRegistryEntry{}
+	for _, entry := range r.Devnets {
+		if entry.ProjectName == projectName {
+			entries = append(entries, entry)
+		}
+	}
+	sortRegistryEntries(entries)
+	return entries
+}
+
+// CheckProjectStopped returns an error when a devnet of projectName is still running. Starting it again would
+// bring its chains up a second time under the same names and discard the state the running ones use.
+func (r *DevnetRegistry) CheckProjectStopped(projectName string) error {
+	entries := r.ProjectEntries(projectName)
+	if len(entries) == 0 {
+		return nil
+	}
+	return fmt.Errorf("devnet for %s already running on :%d; stop it first", projectName, entries[0].Port)
+}
+
+// FindPort returns the entry listening on port
+func (r *DevnetRegistry) FindPort(port int) (DevnetRegistryEntry, bool) {
+	for _, entry := range r.Devnets {
+		if entry.Port == port {
+			return entry, true
+		}
+	}
+	return DevnetRegistryEntry{}, false
+}
+
+// Ports returns every port claimed by a registered devnet
+func (r *DevnetRegistry) Ports() map[int]bool {
+	ports := make(map[int]bool, len(r.Devnets))
+	for _, entry := range r.Devnets {
+		ports[entry.Port] = true
+	}
+	return ports
+}
+
+// Sorted returns the entries grouped by project, L1 first within each project
+func (r *DevnetRegistry) Sorted() []DevnetRegistryEntry {
+	entries := append([]DevnetRegistryEntry{}, r.Devnets...)
+	sortRegistryEntries(entries)
+	return entries
+}
+
+// Reconcile brings the registry in line with the devnet chains running under any backend. Entries whose
+// container is gone or was replaced are dropped and returned; running containers the registry does not
+// know, such as ones started by an older devkit, are adopted.
+func (r *DevnetRegistry) Reconcile(running []DevnetContainer) []DevnetRegistryEntry {
+	byName := make(map[string]DevnetContainer, len(running))
+	for _, c := range running {
+		byName[c.Name] = c
+	}
+
+	stale := []DevnetRegistryEntry{}
+	known := make(map[string]bool, len(r.Devnets))
+	r.filter(func(entry DevnetRegistryEntry) bool {
+		c, ok := byName[entry.ContainerName]
+		if !ok || (entry.ContainerID != "" && entry.ContainerID != c.ID) {
+			stale = append(stale, entry)
+			return false
+		}
+		known[entry.ContainerName] = true
+		return true
+	})
+
+	for _, c := range running {
+		if known[c.Name] {
+			continue
+		}
+		r.Devnets = append(r.Devnets, DevnetRegistryEntry{
+			ProjectName:   c.Project,
+			Chain:         c.Chain,
+			Port:          c.HostPort(),
+			ContainerID:   c.ID,
+			ContainerName: c.Name,
+			StartedAt:     c.Created,
+			Backend:       c.Backend,
+		})
+	}
+	return stale
+}
+
+func (r *DevnetRegistry) filter(keep func(entry DevnetRegistryEntry) bool) {
+	kept := r.Devnets[:0]
+	for _, entry := range r.Devnets {
+		if keep(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	r.Devnets = kept
+}
+
+func sortRegistryEntries(entries []DevnetRegistryEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		if a.Chain == L1 || b.Chain == L1 {
+			return a.Chain == L1 && b.Chain != L1
+		}
+		return a.Chain < b.Chain
+	})
+}
+
+// ReconcileDevnetRegistry reconciles the registry against the devnet containers manager reports running
+// and returns the registry that remains
+func ReconcileDevnetRegistry(ctx context.Context, manager ContainerManager) (*DevnetRegistry, error) {
+	running, err := manager.List(ctx, ContainerFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciled *DevnetRegistry
+	err = UpdateDevnetRegistry(func(r *DevnetRegistry) error {
+		r.Reconcile(running)
+		reconciled = &DevnetRegistry{Devnets: append([]DevnetRegistryEntry{}, r.Devnets...)}
+		return nil
+	})
+	return reconciled, err
+}
+
+// UnregisterDevnetContainers removes the named containers from the devnet registry
+func UnregisterDevnetContainers(names ...string) error {
+	return UpdateDevnetRegistry(func(r *DevnetRegistry) error {
+		r.RemoveContainers(names...)
+		return nil
+	})
+}
+
+// FindFreePortRange returns the first port from start on where count consecutive ports are neither
+// claimed in reserved nor reported busy by available
+func FindFreePortRange(start, count int, reserved map[int]bool, available func(port int) bool) (int, error) {
+	for base := start; base < start+portSearchLimit && base+count-1 <= 65535; base++ {
+		free := true
+		for port := base; port < base+count; port++ {
+			if reserved[port] || !available(port) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return base, nil
+		}
+	}
+	return 0, fmt.Errorf("no %d consecutive free ports found between %d and %d", count, start, start+portSearchLimit-1)
+}
@@ -0,0 +1,139 @@
+package devnet
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevnetRegistryRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	registry, err := LoadDevnetRegistry()
+	require.NoError(t, err)
+	assert.Empty(t, registry.Devnets, "a missing registry file is empty")
+
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, UpdateDevnetRegistry(func(r *DevnetRegistry) error {
+		r.Upsert(DevnetRegistryEntry{ProjectName: "beta", Chain: L1, Port: 8547, ContainerName: "devkit-devnet-beta"})
+		r.Upsert(DevnetRegistryEntry{ProjectName: "alpha", Chain: "l2", Port: 8546, ContainerName: "devkit-devnet-alpha-l2"})
+		r.Upsert(DevnetRegistryEntry{ProjectName: "alpha", Chain: L1, Port: 8545, ChainID: 31337, ContainerName: "devkit-devnet-alpha", StartedAt: startedAt})
+		return nil
+	}))
+
+	// Re-registering a container replaces its entry
+	require.NoError(t, UpdateDevnetRegistry(func(r *DevnetRegistry) error {
+		r.Upsert(DevnetRegistryEntry{ProjectName: "beta", Chain: L1, Port: 9000, ContainerName: "devkit-devnet-beta"})
+		return nil
+	}))
+
+	registry, err = LoadDevnetRegistry()
+	require.NoError(t, err)
+	require.Len(t, registry.Devnets, 3)
+
+	path, err := GetDevnetRegistryPath()
+	require.NoError(t, err)
+	assert.Equal(t, DEVNET_REGISTRY_FILE, filepath.Base(path))
+
+	alpha := registry.ProjectEntries("alpha")
+	require.Len(t, alpha, 2)
+	assert.Equal(t, L1, alpha[0].Chain)
+	assert.Equal(t, 31337, alpha[0].ChainID)
+	assert.True(t, alpha[0].StartedAt.Equal(startedAt))
+
+	assert.EqualError(t, registry.CheckProjectStopped("alpha"), "devnet for alpha already running on :8545; stop it first")
+	assert.NoError(t, registry.CheckProjectStopped("gamma"))
+
+	entry, ok := registry.FindPort(9000)
+	require.True(t, ok)
+	assert.Equal(t, "beta", entry.ProjectName)
+	assert.Equal(t, map[int]bool{8545: true, 8546: true, 9000: true}, registry.Ports())
+
+	sorted := registry.Sorted()
+	assert.Equal(t, []string{"devkit-devnet-alpha", "devkit-devnet-alpha-l2", "devkit-devnet-beta"},
+		[]string{sorted[0].ContainerName, sorted[1].ContainerName, sorted[2].ContainerName})
+
+	require.NoError(t, UnregisterDevnetContainers("devkit-devnet-alpha-l2"))
+	registry, err = LoadDevnetRegistry()
+	require.NoError(t, err)
+	assert.Len(t, registry.Devnets, 2)
+}
+
+func TestDevnetRegistryReconcile(t *testing.T) {
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	registry := &DevnetRegistry{Devnets: []DevnetRegistryEntry{
+		{ContainerName: "devkit-devnet-running", ContainerID: "abc"},
+		{ContainerName: "devkit-devnet-gone", ContainerID: "def"},
+		{ContainerName: "devkit-devnet-replaced", ContainerID: "old"},
+	}}
+
+	stale := registry.Reconcile([]DevnetContainer{
+		{ID: "abc", Name: "devkit-devnet-running", Project: "running", Chain: L1, Running: true},
+		{ID: "new", Name: "devkit-devnet-replaced", Project: "replaced", Chain: L1, Running: true},
+		{ID: "ghi", Name: "devkit-devnet-legacy", Project: "legacy", Chain: L1, HostPorts: []int{8600}, Running: true, Created: created},
+	})
+
+	require.Len(t, stale, 2)
+	assert.Equal(t, "devkit-devnet-gone", stale[0].ContainerName)
+	assert.Equal(t, "devkit-devnet-replaced", stale[1].ContainerName)
+
+	require.Len(t, registry.Devnets, 3)
+	assert.Equal(t, "devkit-devnet-running", registry.Devnets[0].ContainerName)
+
+	// Running containers the registry did not know are adopted from their labels
+	adopted, ok := registry.FindPort(8600)
+	require.True(t, ok)
+	assert.Equal(t, "legacy", adopted.ProjectName)
+	assert.Equal(t, "ghi", adopted.ContainerID)
+	assert.True(t, adopted.StartedAt.Equal(created))
+}
+
+func TestReconcileDevnetRegistry(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, UpdateDevnetRegistry(func(r *DevnetRegistry) error {
+		r.Upsert(DevnetRegistryEntry{ProjectName: "alpha", Chain: L1, Port: 8545, ContainerID: "a1", ContainerName: "devkit-devnet-alpha"})
+		r.Upsert(DevnetRegistryEntry{ProjectName: "beta", Chain: L1, Port: 8546, ContainerID: "b1", ContainerName: "devkit-devnet-beta"})
+		return nil
+	}))
+
+	manager := NewFakeContainerManager(
+		DevnetContainer{ID: "a1", Name: "devkit-devnet-alpha", Project: "alpha", Chain: L1, HostPorts: []int{8545}, Running: true},
+		DevnetContainer{ID: "b1", Name: "devkit-devnet-beta", Project: "beta", Chain: L1, HostPorts: []int{8546}},
+	)
+
+	registry, err := ReconcileDevnetRegistry(context.Background(), manager)
+	require.NoError(t, err)
+	require.Len(t, registry.Devnets, 1, "the stopped beta container is dropped")
+	assert.Equal(t, "alpha", registry.Devnets[0].ProjectName)
+
+	saved, err := LoadDevnetRegistry()
+	require.NoError(t, err)
+	assert.Equal(t, registry.Devnets, saved.Devnets)
+}
+
+func TestFindFreePortRange(t *testing.T) {
+	busy := map[int]bool{8546: true}
+	available := func(port int) bool { return !busy[port] }
+
+	port, err := FindFreePortRange(8545, 1, nil, available)
+	require.NoError(t, err)
+	assert.Equal(t, 8545, port)
+
+	// Two chains need two consecutive ports, so 8545 (next to the busy 8546) is skipped
+	port, err = FindFreePortRange(8545, 2, nil, available)
+	require.NoError(t, err)
+	assert.Equal(t, 8547, port)
+
+	// Ports claimed by registered devnets are skipped even if nothing answers on them yet
+	port, err = FindFreePortRange(8545, 2, map[int]bool{8547: true}, available)
+	require.NoError(t, err)
+	assert.Equal(t, 8548, port)
+
+	_, err = FindFreePortRange(8545, 1, nil, func(int) bool { return false })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no 1 consecutive free ports")
+}
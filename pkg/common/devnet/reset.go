@@ -0,0 +1,56 @@
+package devnet
+
+import (
+	"fmt"
+	"sort"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// resetContextLists are the context entries earlier devnet runs write and a reset empties
+var resetContextLists = []string{"deployed_contracts", "operator_sets", "operator_registrations"}
+
+// ResetDevnetContext clears what earlier runs wrote into the devnet context mapping: deployed contracts,
+// operator sets and operator registrations are emptied and every chain's rpc_url points back at the port
+// it is assigned when the devnet starts on basePort. Comments on the cleared keys are kept.
+func ResetDevnetContext(contextNode *yaml.Node, basePort int) error {
+	if contextNode == nil || contextNode.Kind != yaml.MappingNode {
+		return fmt.Errorf("devnet context is not a mapping")
+	}
+
+	for _, key := range resetContextLists {
+		if devkitcommon.GetChildByKey(contextNode, key) == nil {
+			continue
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+		emptyNode := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		devkitcommon.SetMappingValue(contextNode, keyNode, emptyNode)
+	}
+
+	chainsNode := devkitcommon.GetChildByKey(contextNode, "chains")
+	if chainsNode == nil || chainsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	// Same order ResolveDevnetChains assigns ports in: l1 first, the rest sorted
+	names := make([]string, 0, len(chainsNode.Content)/2)
+	for i := 0; i+1 < len(chainsNode.Content); i += 2 {
+		if name := chainsNode.Content[i].Value; name != L1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if devkitcommon.GetChildByKey(chainsNode, L1) != nil {
+		names = append([]string{L1}, names...)
+	}
+
+	for i, name := range names {
+		chainNode := devkitcommon.GetChildByKey(chainsNode, name)
+		if rpcUrlNode := devkitcommon.GetChildByKey(chainNode, "rpc_url"); rpcUrlNode != nil {
+			rpcUrlNode.Value = GetRPCURL(basePort + i)
+		}
+	}
+	return nil
+}
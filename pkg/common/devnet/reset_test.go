@@ -0,0 +1,62 @@
+package devnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResetDevnetContext(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+name: "devnet"
+chains:
+  l2:
+    chain_id: 31338
+    rpc_url: "http://localhost:9100"
+  l1:
+    chain_id: 31337
+    rpc_url: "http://localhost:9099"
+# Contracts deployed on `+"`devnet start`"+`
+deployed_contracts:
+  - name: "TaskMailbox"
+    address: "0x0000000000000000000000000000000000000001"
+operator_sets:
+  - operator_set_id: 0
+    strategies: []
+operator_registrations:
+  - address: "0x0000000000000000000000000000000000000002"
+    operator_set_id: 0
+    payload: "0x"
+`), &doc))
+	contextNode := doc.Content[0]
+
+	require.NoError(t, ResetDevnetContext(contextNode, 8545))
+
+	var ctx struct {
+		Chains map[string]struct {
+			RPCURL string `yaml:"rpc_url"`
+		} `yaml:"chains"`
+		DeployedContracts     []interface{} `yaml:"deployed_contracts"`
+		OperatorSets          []interface{} `yaml:"operator_sets"`
+		OperatorRegistrations []interface{} `yaml:"operator_registrations"`
+	}
+	require.NoError(t, contextNode.Decode(&ctx))
+
+	assert.Empty(t, ctx.DeployedContracts)
+	assert.Empty(t, ctx.OperatorSets)
+	assert.Empty(t, ctx.OperatorRegistrations)
+	assert.Equal(t, "http://localhost:8545", ctx.Chains["l1"].RPCURL)
+	assert.Equal(t, "http://localhost:8546", ctx.Chains["l2"].RPCURL)
+
+	out, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "# Contracts deployed on `devnet start`\ndeployed_contracts: []")
+}
+
+func TestResetDevnetContextRejectsNonMapping(t *testing.T) {
+	err := ResetDevnetContext(&yaml.Node{Kind: yaml.SequenceNode}, 8545)
+	require.Error(t, err)
+}
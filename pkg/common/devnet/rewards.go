@@ -0,0 +1,348 @@
+package devnet
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// DEFAULT_REWARDS_MULTIPLIER weighs a strategy's shares 1:1 when a spec leaves its multiplier out
+const DEFAULT_REWARDS_MULTIPLIER = "1000000000000000000"
+
+// RewardsSpec is the YAML/JSON file `devkit avs rewards submit` reads its submissions from
+type RewardsSpec struct {
+	RewardsSubmissions                 []RewardsSubmissionSpec                 `json:"rewards_submissions" yaml:"rewards_submissions"`
+	OperatorDirectedRewardsSubmissions []OperatorDirectedRewardsSubmissionSpec `json:"operator_directed_rewards_submissions" yaml:"operator_directed_rewards_submissions"`
+}
+
+// RewardsStrategySpec weighs a strategy's shares in a rewards submission
+type RewardsStrategySpec struct {
+	Strategy string `json:"strategy" yaml:"strategy"`
+	// Multiplier is an integer where 1e18 weighs shares 1:1; it defaults to DEFAULT_REWARDS_MULTIPLIER
+	Multiplier string `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+}
+
+// RewardsSubmissionSpec rewards every operator and staker of the AVS in proportion to their weighted stake
+type RewardsSubmissionSpec struct {
+	Token string `json:"token" yaml:"token"`
+	// Amount is a whole token amount, e.g. "1000" or "0.5"; wei and gwei amounts are accepted too
+	Amount string `json:"amount" yaml:"amount"`
+	// StartTimestamp is a unix timestamp or RFC3339 time; it defaults to the start of the current calculation interval
+	StartTimestamp string `json:"start_timestamp,omitempty" yaml:"start_timestamp,omitempty"`
+	// Duration is in seconds or a duration such as "7d"
+	Duration   string                `json:"duration" yaml:"duration"`
+	Strategies []RewardsStrategySpec `json:"strategies" yaml:"strategies"`
+}
+
+// OperatorRewardSpec is what one operator receives from an operator-directed submission
+type OperatorRewardSpec struct {
+	Operator string `json:"operator" yaml:"operator"`
+	Amount   string `json:"amount" yaml:"amount"`
+}
+
+// OperatorDirectedRewardsSubmissionSpec pays fixed amounts to operators for a period that has ended
+type OperatorDirectedRewardsSubmissionSpec struct {
+	Token string `json:"token" yaml:"token"`
+	// OperatorSetID rewards the operators of one operator set instead of the AVS as a whole
+	OperatorSetID *uint32 `json:"operator_set_id,omitempty" yaml:"operator_set_id,omitempty"`
+	// StartTimestamp defaults to the latest start whose period ends by the current calculation interval
+	StartTimestamp  string                `json:"start_timestamp,omitempty" yaml:"start_timestamp,omitempty"`
+	Duration        string                `json:"duration" yaml:"duration"`
+	Description     string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Strategies      []RewardsStrategySpec `json:"strategies" yaml:"strategies"`
+	OperatorRewards []OperatorRewardSpec  `json:"operator_rewards" yaml:"operator_rewards"`
+}
+
+// OperatorDirectedSubmission is an operator-directed submission ready to send, for the AVS or one of its operator sets
+type OperatorDirectedSubmission struct {
+	OperatorSetID *uint32
+	Submission    rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission
+}
+
+// RewardsSubmissions are the submissions built from a RewardsSpec
+type RewardsSubmissions struct {
+	AVS              []rewardscoordinator.IRewardsCoordinatorTypesRewardsSubmission
+	OperatorDirected []OperatorDirectedSubmission
+}
+
+// LoadRewardsSpec reads a rewards spec from a YAML or JSON file
+func LoadRewardsSpec(path string) (*RewardsSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rewards spec: %w", err)
+	}
+	var spec RewardsSpec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse rewards spec %s: %w", path, err)
+	}
+	if len(spec.RewardsSubmissions) == 0 && len(spec.OperatorDirectedRewardsSubmissions) == 0 {
+		return nil, fmt.Errorf("rewards spec %s has no rewards_submissions or operator_directed_rewards_submissions", path)
+	}
+	return &spec, nil
+}
+
+// Build converts the spec into RewardsCoordinator submissions, checking them against params at chain time now.
+// decimals returns the decimals of a token so whole token amounts can be converted.
+func (s *RewardsSpec) Build(params devkitcommon.RewardsCoordinatorParams, now uint64, decimals func(token common.Address) (uint8, error)) (*RewardsSubmissions, error) {
+	if params.CalculationInterval == 0 {
+		return nil, fmt.Errorf("rewards calculation interval is zero")
+	}
+	interval := uint64(params.CalculationInterval)
+	currentInterval := now / interval * interval
+	out := &RewardsSubmissions{}
+
+	for i, sub := range s.RewardsSubmissions {
+		name := fmt.Sprintf("rewards_submissions[%d]", i)
+		token, err := parseRewardsAddress(name+".token", sub.Token)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := parseRewardsAmount(name+".amount", sub.Amount, token, decimals)
+		if err != nil {
+			return nil, err
+		}
+		strategies, err := buildStrategiesAndMultipliers(name, sub.Strategies)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := parseRewardsDuration(name, sub.Duration, params)
+		if err != nil {
+			return nil, err
+		}
+		start, err := parseRewardsStart(name, sub.StartTimestamp, currentInterval, params)
+		if err != nil {
+			return nil, err
+		}
+		if start+uint64(params.MaxRetroactiveLength) < now {
+			return nil, fmt.Errorf("%s: start %d is more than %ds in the past", name, start, params.MaxRetroactiveLength)
+		}
+		if start > now+uint64(params.MaxFutureLength) {
+			return nil, fmt.Errorf("%s: start %d is more than %ds in the future", name, start, params.MaxFutureLength)
+		}
+
+		out.AVS = append(out.AVS, rewardscoordinator.IRewardsCoordinatorTypesRewardsSubmission{
+			StrategiesAndMultipliers: strategies,
+			Token:                    token,
+			Amount:                   amount,
+			StartTimestamp:           uint32(start),
+			Duration:                 duration,
+		})
+	}
+
+	for i, sub := range s.OperatorDirectedRewardsSubmissions {
+		name := fmt.Sprintf("operator_directed_rewards_submissions[%d]", i)
+		token, err := parseRewardsAddress(name+".token", sub.Token)
+		if err != nil {
+			return nil, err
+		}
+		strategies, err := buildStrategiesAndMultipliers(name, sub.Strategies)
+		if err != nil {
+			return nil, err
+		}
+		operatorRewards, err := buildOperatorRewards(name, sub.OperatorRewards, token, decimals)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := parseRewardsDuration(name, sub.Duration, params)
+		if err != nil {
+			return nil, err
+		}
+		// Operator-directed rewards pay for a period that has already ended
+		defaultStart := uint64(0)
+		if currentInterval >= uint64(duration) {
+			defaultStart = currentInterval - uint64(duration)
+		}
+		start, err := parseRewardsStart(name, sub.StartTimestamp, defaultStart, params)
+		if err != nil {
+			return nil, err
+		}
+		if start+uint64(duration) >= now {
+			return nil, fmt.Errorf("%s: operator-directed rewards must end before the current chain time %d", name, now)
+		}
+		if start+uint64(params.MaxRetroactiveLength) < now {
+			return nil, fmt.Errorf("%s: start %d is more than %ds in the past", name, start, params.MaxRetroactiveLength)
+		}
+
+		out.OperatorDirected = append(out.OperatorDirected, OperatorDirectedSubmission{
+			OperatorSetID: sub.OperatorSetID,
+			Submission: rewardscoordinator.IRewardsCoordinatorTypesOperatorDirectedRewardsSubmission{
+				StrategiesAndMultipliers: strategies,
+				Token:                    token,
+				OperatorRewards:          operatorRewards,
+				StartTimestamp:           uint32(start),
+				Duration:                 duration,
+				Description:              sub.Description,
+			},
+		})
+	}
+	return out, nil
+}
+
+// TokenTotals returns the amount of each token the submissions transfer from the submitter
+func (s *RewardsSubmissions) TokenTotals() map[common.Address]*big.Int {
+	totals := map[common.Address]*big.Int{}
+	add := func(token common.Address, amount *big.Int) {
+		if totals[token] == nil {
+			totals[token] = new(big.Int)
+		}
+		totals[token].Add(totals[token], amount)
+	}
+	for _, sub := range s.AVS {
+		add(sub.Token, sub.Amount)
+	}
+	for _, sub := range s.OperatorDirected {
+		for _, reward := range sub.Submission.OperatorRewards {
+			add(sub.Submission.Token, reward.Amount)
+		}
+	}
+	return totals
+}
+
+func parseRewardsAddress(field, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("%s: invalid address %q", field, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func parseRewardsAmount(field, value string, token common.Address, decimals func(common.Address) (uint8, error)) (*big.Int, error) {
+	stake, err := ParseStake(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	if stake.IsZero() {
+		return nil, fmt.Errorf("%s: amount must be greater than zero", field)
+	}
+	tokenDecimals, err := decimals(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get decimals of %s: %w", field, token.Hex(), err)
+	}
+	amount, err := stake.BaseUnits(tokenDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return amount, nil
+}
+
+// buildStrategiesAndMultipliers sorts the strategies by address, which the RewardsCoordinator requires
+func buildStrategiesAndMultipliers(name string, specs []RewardsStrategySpec) ([]rewardscoordinator.IRewardsCoordinatorTypesStrategyAndMultiplier, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%s: at least one strategy is required", name)
+	}
+	maxMultiplier := new(big.Int).Lsh(big.NewInt(1), 96)
+	strategies := make([]rewardscoordinator.IRewardsCoordinatorTypesStrategyAndMultiplier, 0, len(specs))
+	for i, spec := range specs {
+		field := fmt.Sprintf("%s.strategies[%d]", name, i)
+		strategy, err := parseRewardsAddress(field+".strategy", spec.Strategy)
+		if err != nil {
+			return nil, err
+		}
+		raw := strings.TrimSpace(spec.Multiplier)
+		if raw == "" {
+			raw = DEFAULT_REWARDS_MULTIPLIER
+		}
+		multiplier, ok := new(big.Int).SetString(raw, 10)
+		if !ok || multiplier.Sign() <= 0 || multiplier.Cmp(maxMultiplier) >= 0 {
+			return nil, fmt.Errorf("%s.multiplier: %q is not a positive uint96", field, spec.Multiplier)
+		}
+		strategies = append(strategies, rewardscoordinator.IRewardsCoordinatorTypesStrategyAndMultiplier{Strategy: strategy, Multiplier: multiplier})
+	}
+	sort.Slice(strategies, func(i, j int) bool {
+		return bytes.Compare(strategies[i].Strategy.Bytes(), strategies[j].Strategy.Bytes()) < 0
+	})
+	for i := 1; i < len(strategies); i++ {
+		if strategies[i].Strategy == strategies[i-1].Strategy {
+			return nil, fmt.Errorf("%s: strategy %s is listed twice", name, strategies[i].Strategy.Hex())
+		}
+	}
+	return strategies, nil
+}
+
+// buildOperatorRewards sorts the rewards by operator address, which the RewardsCoordinator requires
+func buildOperatorRewards(name string, specs []OperatorRewardSpec, token common.Address, decimals func(common.Address) (uint8, error)) ([]rewardscoordinator.IRewardsCoordinatorTypesOperatorReward, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%s: at least one operator reward is required", name)
+	}
+	rewards := make([]rewardscoordinator.IRewardsCoordinatorTypesOperatorReward, 0, len(specs))
+	for i, spec := range specs {
+		field := fmt.Sprintf("%s.operator_rewards[%d]", name, i)
+		operator, err := parseRewardsAddress(field+".operator", spec.Operator)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := parseRewardsAmount(field+".amount", spec.Amount, token, decimals)
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, rewardscoordinator.IRewardsCoordinatorTypesOperatorReward{Operator: operator, Amount: amount})
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return bytes.Compare(rewards[i].Operator.Bytes(), rewards[j].Operator.Bytes()) < 0
+	})
+	for i := 1; i < len(rewards); i++ {
+		if rewards[i].Operator == rewards[i-1].Operator {
+			return nil, fmt.Errorf("%s: operator %s is listed twice", name, rewards[i].Operator.Hex())
+		}
+	}
+	return rewards, nil
+}
+
+// parseRewardsDuration accepts seconds ("604800") or a duration ("7d", "168h")
+func parseRewardsDuration(name, value string, params devkitcommon.RewardsCoordinatorParams) (uint32, error) {
+	value = strings.TrimSpace(value)
+	var seconds uint64
+	if n, err := strconv.ParseUint(value, 10, 32); err == nil {
+		seconds = n
+	} else {
+		d, err := parseDuration(value)
+		if err != nil || d%time.Second != 0 || d < 0 {
+			return 0, fmt.Errorf("%s.duration: invalid duration %q: use seconds or a duration such as 7d", name, value)
+		}
+		seconds = uint64(d / time.Second)
+	}
+	if seconds == 0 || seconds%uint64(params.CalculationInterval) != 0 {
+		return 0, fmt.Errorf("%s.duration: %ds must be a positive multiple of the calculation interval (%ds)", name, seconds, params.CalculationInterval)
+	}
+	if seconds > uint64(params.MaxRewardsDuration) {
+		return 0, fmt.Errorf("%s.duration: %ds exceeds the maximum rewards duration (%ds)", name, seconds, params.MaxRewardsDuration)
+	}
+	return uint32(seconds), nil
+}
+
+// parseRewardsStart accepts a unix timestamp or RFC3339 time, using defaultStart when value is empty
+func parseRewardsStart(name, value string, defaultStart uint64, params devkitcommon.RewardsCoordinatorParams) (uint64, error) {
+	value = strings.TrimSpace(value)
+	start := defaultStart
+	if value != "" {
+		if ts, err := strconv.ParseUint(value, 10, 32); err == nil {
+			start = ts
+		} else if t, err := time.Parse(time.RFC3339, value); err == nil && t.Unix() >= 0 {
+			start = uint64(t.Unix())
+		} else {
+			return 0, fmt.Errorf("%s.start_timestamp: invalid time %q: use a unix timestamp or RFC3339 time", name, value)
+		}
+	}
+	if start%uint64(params.CalculationInterval) != 0 {
+		return 0, fmt.Errorf("%s.start_timestamp: %d must be a multiple of the calculation interval (%ds)", name, start, params.CalculationInterval)
+	}
+	if start < uint64(params.GenesisTimestamp) {
+		return 0, fmt.Errorf("%s.start_timestamp: %d is before the rewards genesis %d", name, start, params.GenesisTimestamp)
+	}
+	if start > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("%s.start_timestamp: %d does not fit in uint32", name, start)
+	}
+	return start, nil
+}
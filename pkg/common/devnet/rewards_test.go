@@ -0,0 +1,257 @@
+package devnet
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRewardsParams = devkitcommon.RewardsCoordinatorParams{
+	CalculationInterval:  86400,
+	MaxRewardsDuration:   6048000,
+	MaxRetroactiveLength: 7776000,
+	MaxFutureLength:      2592000,
+	GenesisTimestamp:     1710979200,
+}
+
+// testRewardsNow is partway through the interval starting at 1749945600
+const testRewardsNow = uint64(1750000000)
+
+func testDecimals(decimals uint8) func(common.Address) (uint8, error) {
+	return func(common.Address) (uint8, error) { return decimals, nil }
+}
+
+func writeRewardsSpec(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadRewardsSpec(t *testing.T) {
+	yamlPath := writeRewardsSpec(t, "rewards.yaml", `
+rewards_submissions:
+  - token: "0x0000000000000000000000000000000000000aaa"
+    amount: 1000
+    duration: 7d
+    strategies:
+      - strategy: "0x0000000000000000000000000000000000000002"
+        multiplier: "2000000000000000000"
+operator_directed_rewards_submissions:
+  - token: "0x0000000000000000000000000000000000000aaa"
+    operator_set_id: 0
+    duration: 86400
+    description: "week one"
+    strategies:
+      - strategy: "0x0000000000000000000000000000000000000002"
+    operator_rewards:
+      - operator: "0x0000000000000000000000000000000000000b0b"
+        amount: 5
+`)
+	spec, err := LoadRewardsSpec(yamlPath)
+	require.NoError(t, err)
+	require.Len(t, spec.RewardsSubmissions, 1)
+	assert.Equal(t, "1000", spec.RewardsSubmissions[0].Amount)
+	assert.Equal(t, "7d", spec.RewardsSubmissions[0].Duration)
+	require.Len(t, spec.OperatorDirectedRewardsSubmissions, 1)
+	require.NotNil(t, spec.OperatorDirectedRewardsSubmissions[0].OperatorSetID)
+	assert.Equal(t, uint32(0), *spec.OperatorDirectedRewardsSubmissions[0].OperatorSetID)
+	assert.Equal(t, "86400", spec.OperatorDirectedRewardsSubmissions[0].Duration)
+
+	jsonPath := writeRewardsSpec(t, "rewards.json", `{
+  "rewards_submissions": [
+    {
+      "token": "0x0000000000000000000000000000000000000aaa",
+      "amount": "1.5",
+      "duration": "86400",
+      "strategies": [{"strategy": "0x0000000000000000000000000000000000000002"}]
+    }
+  ]
+}`)
+	spec, err = LoadRewardsSpec(jsonPath)
+	require.NoError(t, err)
+	require.Len(t, spec.RewardsSubmissions, 1)
+	assert.Equal(t, "1.5", spec.RewardsSubmissions[0].Amount)
+	assert.Empty(t, spec.OperatorDirectedRewardsSubmissions)
+}
+
+func TestLoadRewardsSpec_Invalid(t *testing.T) {
+	_, err := LoadRewardsSpec(writeRewardsSpec(t, "empty.yaml", "rewards_submissions: []\n"))
+	assert.ErrorContains(t, err, "has no rewards_submissions")
+
+	_, err = LoadRewardsSpec(writeRewardsSpec(t, "typo.yaml", "reward_submissions: []\n"))
+	assert.ErrorContains(t, err, "reward_submissions")
+
+	_, err = LoadRewardsSpec(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read rewards spec")
+}
+
+func TestRewardsSpecBuild(t *testing.T) {
+	setID := uint32(1)
+	spec := &RewardsSpec{
+		RewardsSubmissions: []RewardsSubmissionSpec{{
+			Token:    "0x0000000000000000000000000000000000000aaa",
+			Amount:   "1000",
+			Duration: "7d",
+			Strategies: []RewardsStrategySpec{
+				{Strategy: "0x0000000000000000000000000000000000000003", Multiplier: "500000000000000000"},
+				{Strategy: "0x0000000000000000000000000000000000000002"},
+			},
+		}},
+		OperatorDirectedRewardsSubmissions: []OperatorDirectedRewardsSubmissionSpec{{
+			Token:         "0x0000000000000000000000000000000000000aaa",
+			OperatorSetID: &setID,
+			Duration:      "86400",
+			Description:   "last day",
+			Strategies:    []RewardsStrategySpec{{Strategy: "0x0000000000000000000000000000000000000002"}},
+			OperatorRewards: []OperatorRewardSpec{
+				{Operator: "0x0000000000000000000000000000000000000c0c", Amount: "2"},
+				{Operator: "0x0000000000000000000000000000000000000b0b", Amount: "3"},
+			},
+		}},
+	}
+
+	subs, err := spec.Build(testRewardsParams, testRewardsNow, testDecimals(6))
+	require.NoError(t, err)
+
+	require.Len(t, subs.AVS, 1)
+	avsSub := subs.AVS[0]
+	assert.Equal(t, uint32(1749945600), avsSub.StartTimestamp)
+	assert.Equal(t, uint32(7*86400), avsSub.Duration)
+	assert.Equal(t, big.NewInt(1000_000000), avsSub.Amount)
+	require.Len(t, avsSub.StrategiesAndMultipliers, 2)
+	assert.Equal(t, common.HexToAddress("0x2"), avsSub.StrategiesAndMultipliers[0].Strategy)
+	assert.Equal(t, DEFAULT_REWARDS_MULTIPLIER, avsSub.StrategiesAndMultipliers[0].Multiplier.String())
+	assert.Equal(t, common.HexToAddress("0x3"), avsSub.StrategiesAndMultipliers[1].Strategy)
+
+	require.Len(t, subs.OperatorDirected, 1)
+	directed := subs.OperatorDirected[0]
+	require.NotNil(t, directed.OperatorSetID)
+	assert.Equal(t, uint32(1), *directed.OperatorSetID)
+	assert.Equal(t, uint32(1749945600-86400), directed.Submission.StartTimestamp)
+	assert.Equal(t, "last day", directed.Submission.Description)
+	require.Len(t, directed.Submission.OperatorRewards, 2)
+	assert.Equal(t, common.HexToAddress("0xb0b"), directed.Submission.OperatorRewards[0].Operator)
+	assert.Equal(t, big.NewInt(3_000000), directed.Submission.OperatorRewards[0].Amount)
+
+	totals := subs.TokenTotals()
+	require.Len(t, totals, 1)
+	assert.Equal(t, big.NewInt(1005_000000), totals[common.HexToAddress("0xaaa")])
+}
+
+func TestRewardsSpecBuild_Invalid(t *testing.T) {
+	validStrategies := []RewardsStrategySpec{{Strategy: "0x0000000000000000000000000000000000000002"}}
+	avsSpec := func(mutate func(*RewardsSubmissionSpec)) *RewardsSpec {
+		sub := RewardsSubmissionSpec{
+			Token:      "0x0000000000000000000000000000000000000aaa",
+			Amount:     "10",
+			Duration:   "86400",
+			Strategies: validStrategies,
+		}
+		mutate(&sub)
+		return &RewardsSpec{RewardsSubmissions: []RewardsSubmissionSpec{sub}}
+	}
+	directedSpec := func(mutate func(*OperatorDirectedRewardsSubmissionSpec)) *RewardsSpec {
+		sub := OperatorDirectedRewardsSubmissionSpec{
+			Token:           "0x0000000000000000000000000000000000000aaa",
+			Duration:        "86400",
+			Strategies:      validStrategies,
+			OperatorRewards: []OperatorRewardSpec{{Operator: "0x0000000000000000000000000000000000000b0b", Amount: "1"}},
+		}
+		mutate(&sub)
+		return &RewardsSpec{OperatorDirectedRewardsSubmissions: []OperatorDirectedRewardsSubmissionSpec{sub}}
+	}
+
+	tests := []struct {
+		name    string
+		spec    *RewardsSpec
+		wantErr string
+	}{
+		{
+			name:    "invalid token",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.Token = "usdc" }),
+			wantErr: "rewards_submissions[0].token: invalid address",
+		},
+		{
+			name:    "zero amount",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.Amount = "0" }),
+			wantErr: "amount must be greater than zero",
+		},
+		{
+			name:    "no strategies",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.Strategies = nil }),
+			wantErr: "at least one strategy is required",
+		},
+		{
+			name: "duplicate strategy",
+			spec: avsSpec(func(s *RewardsSubmissionSpec) {
+				s.Strategies = append(s.Strategies, RewardsStrategySpec{Strategy: "0x0000000000000000000000000000000000000002"})
+			}),
+			wantErr: "is listed twice",
+		},
+		{
+			name: "multiplier overflows uint96",
+			spec: avsSpec(func(s *RewardsSubmissionSpec) {
+				s.Strategies = []RewardsStrategySpec{{Strategy: "0x0000000000000000000000000000000000000002", Multiplier: new(big.Int).Lsh(big.NewInt(1), 96).String()}}
+			}),
+			wantErr: "is not a positive uint96",
+		},
+		{
+			name:    "duration not a multiple of the interval",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.Duration = "1h" }),
+			wantErr: "must be a positive multiple of the calculation interval",
+		},
+		{
+			name:    "duration too long",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.Duration = "71d" }),
+			wantErr: "exceeds the maximum rewards duration",
+		},
+		{
+			name:    "unaligned start",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.StartTimestamp = "1749945601" }),
+			wantErr: "must be a multiple of the calculation interval",
+		},
+		{
+			name:    "start before genesis",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.StartTimestamp = "1710892800" }),
+			wantErr: "is before the rewards genesis",
+		},
+		{
+			name:    "start too far back",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.StartTimestamp = "2024-06-01T00:00:00Z" }),
+			wantErr: "in the past",
+		},
+		{
+			name:    "start too far ahead",
+			spec:    avsSpec(func(s *RewardsSubmissionSpec) { s.StartTimestamp = fmt.Sprint(1749945600 + 31*86400) }),
+			wantErr: "in the future",
+		},
+		{
+			name:    "operator-directed period not ended",
+			spec:    directedSpec(func(s *OperatorDirectedRewardsSubmissionSpec) { s.StartTimestamp = "1749945600" }),
+			wantErr: "must end before the current chain time",
+		},
+		{
+			name: "duplicate operator",
+			spec: directedSpec(func(s *OperatorDirectedRewardsSubmissionSpec) {
+				s.OperatorRewards = append(s.OperatorRewards, OperatorRewardSpec{Operator: "0x0000000000000000000000000000000000000B0B", Amount: "2"})
+			}),
+			wantErr: "operator_directed_rewards_submissions[0]: operator 0x0000000000000000000000000000000000000B0b is listed twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.spec.Build(testRewardsParams, testRewardsNow, testDecimals(18))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
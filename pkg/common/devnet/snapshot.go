@@ -0,0 +1,277 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// Files written inside each .devkit/snapshots/<name> directory; chain state is stored as state-<chain>.hex
+const (
+	SnapshotContextFile  = "devnet.yaml"
+	SnapshotMetadataFile = "snapshot.yaml"
+	SnapshotOutputsDir   = "outputs"
+	// SnapshotOverridesFile holds the run-scoped context overrides in force when the snapshot was taken
+	SnapshotOverridesFile = DEVNET_OVERRIDES_FILE
+)
+
+// snapshotNameRe restricts snapshot names to safe directory names
+var snapshotNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// SnapshotChain records the state of one devnet chain when the snapshot was taken
+type SnapshotChain struct {
+	ChainID     uint64 `yaml:"chain_id"`
+	BlockNumber uint64 `yaml:"block_number"`
+}
+
+// SnapshotMetadata describes a saved devnet snapshot
+type SnapshotMetadata struct {
+	Name      string                   `yaml:"name"`
+	CreatedAt time.Time                `yaml:"created_at"`
+	Chains    map[string]SnapshotChain `yaml:"chains"`
+}
+
+// GetSnapshotsDir returns the project-relative directory holding all snapshots
+func GetSnapshotsDir() string {
+	return filepath.Join(".devkit", "snapshots")
+}
+
+// GetSnapshotDir returns the directory for the named snapshot
+func GetSnapshotDir(name string) string {
+	return filepath.Join(GetSnapshotsDir(), name)
+}
+
+// GetSnapshotStatePath returns the path of a chain's state dump within the named snapshot
+func GetSnapshotStatePath(name, chainName string) string {
+	return filepath.Join(GetSnapshotDir(name), fmt.Sprintf("state-%s.hex", chainName))
+}
+
+// ValidateSnapshotName rejects names that cannot safely be used as a directory
+func ValidateSnapshotName(name string) error {
+	if !snapshotNameRe.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// SaveSnapshot dumps the state of every chain in rpcURLs (keyed by chain name) and stores it next
+// to a copy of the context file and contract outputs taken at the same moment.
+func SaveSnapshot(ctx context.Context, rpcURLs map[string]string, name, contextPath, outputsDir string) (*SnapshotMetadata, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return nil, err
+	}
+
+	meta := &SnapshotMetadata{
+		Name:      name,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Chains:    make(map[string]SnapshotChain, len(rpcURLs)),
+	}
+	states := make(map[string]string, len(rpcURLs))
+	for chainName, rpcURL := range rpcURLs {
+		chain, state, err := dumpChain(ctx, rpcURL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", chainName, err)
+		}
+		meta.Chains[chainName] = *chain
+		states[chainName] = state
+	}
+
+	// Write into a temp dir first so a failed save never clobbers an existing snapshot
+	dir := GetSnapshotDir(name)
+	if err := os.MkdirAll(GetSnapshotsDir(), 0o755); err != nil {
+		return nil, fmt.Errorf("create snapshots dir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(GetSnapshotsDir(), "."+name+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for chainName, state := range states {
+		statePath := filepath.Join(tmpDir, filepath.Base(GetSnapshotStatePath(name, chainName)))
+		if err := os.WriteFile(statePath, []byte(state), 0o644); err != nil {
+			return nil, fmt.Errorf("write %s state: %w", chainName, err)
+		}
+	}
+	if err := copyFile(contextPath, filepath.Join(tmpDir, SnapshotContextFile)); err != nil {
+		return nil, fmt.Errorf("copy context: %w", err)
+	}
+	if _, err := os.Stat(outputsDir); err == nil {
+		if err := copyDir(outputsDir, filepath.Join(tmpDir, SnapshotOutputsDir)); err != nil {
+			return nil, fmt.Errorf("copy contract outputs: %w", err)
+		}
+	}
+	if _, err := os.Stat(GetContextOverridesPath()); err == nil {
+		if err := copyFile(GetContextOverridesPath(), filepath.Join(tmpDir, SnapshotOverridesFile)); err != nil {
+			return nil, fmt.Errorf("copy context overrides: %w", err)
+		}
+	}
+
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, SnapshotMetadataFile), data, 0o644); err != nil {
+		return nil, fmt.Errorf("write snapshot metadata: %w", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("remove previous snapshot %s: %w", name, err)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return nil, fmt.Errorf("finalise snapshot %s: %w", name, err)
+	}
+	return meta, nil
+}
+
+// dumpChain reads the chain id, head and full state of the anvil node at rpcURL
+func dumpChain(ctx context.Context, rpcURL string) (*SnapshotChain, string, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to connect to devnet at %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get chain id: %w", err)
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	anvil, err := DialAnvil(ctx, rpcURL)
+	if err != nil {
+		return nil, "", err
+	}
+	defer anvil.Close()
+
+	state, err := anvil.DumpState(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return &SnapshotChain{ChainID: chainID.Uint64(), BlockNumber: head}, state, nil
+}
+
+// LoadSnapshotState loads the named snapshot's state for chainName into the devnet chain at rpcURL
+func LoadSnapshotState(ctx context.Context, rpcURL, name, chainName string) error {
+	state, err := os.ReadFile(GetSnapshotStatePath(name, chainName))
+	if err != nil {
+		return fmt.Errorf("read %s snapshot state: %w", chainName, err)
+	}
+
+	anvil, err := DialAnvil(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer anvil.Close()
+
+	return anvil.LoadState(ctx, string(state))
+}
+
+// RestoreSnapshotOutputs replaces outputsDir with the contract outputs saved in the snapshot
+func RestoreSnapshotOutputs(name, outputsDir string) error {
+	src := filepath.Join(GetSnapshotDir(name), SnapshotOutputsDir)
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.RemoveAll(outputsDir); err != nil {
+		return fmt.Errorf("remove %s: %w", outputsDir, err)
+	}
+	return copyDir(src, outputsDir)
+}
+
+// RestoreSnapshotContextOverrides replaces the recorded context overrides with those saved in the snapshot, so the
+// values its context copy carries for that run are undone by the next fresh start just the same
+func RestoreSnapshotContextOverrides(name string) error {
+	src := filepath.Join(GetSnapshotDir(name), SnapshotOverridesFile)
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return ClearContextOverrides()
+	}
+	if err := os.MkdirAll(GetNativeDevnetDir(), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", GetNativeDevnetDir(), err)
+	}
+	return copyFile(src, GetContextOverridesPath())
+}
+
+// ReadSnapshotMetadata returns the metadata of a saved snapshot
+func ReadSnapshotMetadata(name string) (*SnapshotMetadata, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(GetSnapshotDir(name), SnapshotMetadataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q not found; run `devkit avs devnet snapshot list` to see available snapshots", name)
+		}
+		return nil, fmt.Errorf("read snapshot metadata: %w", err)
+	}
+	var meta SnapshotMetadata
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parse snapshot metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// ListSnapshots returns the metadata of every saved snapshot, oldest first
+func ListSnapshots() ([]SnapshotMetadata, error) {
+	entries, err := os.ReadDir(GetSnapshotsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read snapshots dir: %w", err)
+	}
+
+	var snapshots []SnapshotMetadata
+	for _, e := range entries {
+		if !e.IsDir() || ValidateSnapshotName(e.Name()) != nil {
+			continue
+		}
+		meta, err := ReadSnapshotMetadata(e.Name())
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, *meta)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// copyFile copies a single regular file
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// copyDir recursively copies the directory tree at src into dst
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(path, target)
+	})
+}
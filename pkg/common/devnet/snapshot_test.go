@@ -0,0 +1,75 @@
+package devnet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSnapshotName(t *testing.T) {
+	for _, name := range []string{"base", "after-setup", "v1.2_ops"} {
+		assert.NoError(t, ValidateSnapshotName(name), name)
+	}
+	for _, name := range []string{"", "../escape", ".hidden", "with space", "a/b"} {
+		assert.Error(t, ValidateSnapshotName(name), name)
+	}
+}
+
+func TestSaveListAndRestoreSnapshot(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	// Project files captured by the snapshot
+	contextPath := filepath.Join("config", "contexts", "devnet.yaml")
+	outputsDir := filepath.Join("contracts", "outputs", "devnet")
+	require.NoError(t, os.MkdirAll(filepath.Dir(contextPath), 0o755))
+	require.NoError(t, os.MkdirAll(outputsDir, 0o755))
+	require.NoError(t, os.WriteFile(contextPath, []byte("version: 0.0.5\ncontext:\n  name: devnet\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputsDir, "Registrar.json"), []byte(`{"name":"Registrar"}`), 0o644))
+	require.NoError(t, os.MkdirAll(GetNativeDevnetDir(), 0o755))
+	require.NoError(t, os.WriteFile(GetContextOverridesPath(), []byte("overrides: []\n"), 0o644))
+
+	srv := newFakeRPC(t, 31337, 42, 0)
+
+	rpcURLs := map[string]string{"l1": srv.URL, "l2": srv.URL}
+	meta, err := SaveSnapshot(context.Background(), rpcURLs, "base", contextPath, outputsDir)
+	require.NoError(t, err)
+	require.Len(t, meta.Chains, 2)
+	assert.Equal(t, SnapshotChain{ChainID: 31337, BlockNumber: 42}, meta.Chains["l1"])
+
+	for chainName := range rpcURLs {
+		state, err := os.ReadFile(GetSnapshotStatePath("base", chainName))
+		require.NoError(t, err)
+		assert.Equal(t, "0x1f8b", string(state))
+	}
+
+	snapshots, err := ListSnapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "base", snapshots[0].Name)
+	assert.Equal(t, meta.Chains, snapshots[0].Chains)
+
+	// Outputs written after the snapshot should be replaced on restore
+	require.NoError(t, os.WriteFile(filepath.Join(outputsDir, "Stale.json"), []byte(`{}`), 0o644))
+	require.NoError(t, LoadSnapshotState(context.Background(), srv.URL, "base", "l2"))
+	require.NoError(t, RestoreSnapshotOutputs("base", outputsDir))
+	assert.FileExists(t, filepath.Join(outputsDir, "Registrar.json"))
+	assert.NoFileExists(t, filepath.Join(outputsDir, "Stale.json"))
+
+	// The context overrides in force at save time come back with the snapshot's context copy
+	require.NoError(t, ClearContextOverrides())
+	require.NoError(t, RestoreSnapshotContextOverrides("base"))
+	assert.FileExists(t, GetContextOverridesPath())
+	require.NoError(t, os.Remove(filepath.Join(GetSnapshotDir("base"), SnapshotOverridesFile)))
+	require.NoError(t, RestoreSnapshotContextOverrides("base"))
+	assert.NoFileExists(t, GetContextOverridesPath())
+
+	_, err = ReadSnapshotMetadata("missing")
+	assert.ErrorContains(t, err, "not found")
+}
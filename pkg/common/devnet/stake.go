@@ -0,0 +1,62 @@
+package devnet
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// stakeRe matches an amount followed by an optional unit or token symbol, e.g. "1000ETH", "2.5 gwei", "500 stETH"
+var stakeRe = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([A-Za-z][A-Za-z0-9]*)?$`)
+
+// sciNotationRe catches exponents like "1e18" that would otherwise be read as a token symbol
+var sciNotationRe = regexp.MustCompile(`^[eE][0-9]+$`)
+
+// Stake is an amount parsed from an operator's `stake` field
+type Stake struct {
+	// whole is the amount expressed in whole tokens
+	whole *big.Rat
+	// Raw is the value as written in the context
+	Raw string
+}
+
+// ParseStake parses values such as "1000ETH", "1000 ether", "5gwei", "100wei" or "250.5" / "250.5 stETH".
+// ETH, gwei and wei denominate an 18-decimal amount; a bare number or any other symbol is a whole token amount.
+func ParseStake(value string) (*Stake, error) {
+	raw := strings.TrimSpace(value)
+	m := stakeRe.FindStringSubmatch(raw)
+	if m == nil || sciNotationRe.MatchString(m[2]) {
+		return nil, fmt.Errorf("invalid stake %q: expected an amount with an optional unit, e.g. 1000ETH, 5gwei or 250 stETH", value)
+	}
+
+	amount, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return nil, fmt.Errorf("invalid stake amount %q", m[1])
+	}
+
+	// ETH, ether and token amounts are already whole units; gwei and wei are fractions of an 18-decimal unit
+	switch strings.ToLower(m[2]) {
+	case "gwei":
+		amount.Quo(amount, new(big.Rat).SetInt(big.NewInt(1e9)))
+	case "wei":
+		amount.Quo(amount, new(big.Rat).SetInt(big.NewInt(1e18)))
+	}
+
+	return &Stake{whole: amount, Raw: raw}, nil
+}
+
+// IsZero reports whether the stake amounts to nothing
+func (s *Stake) IsZero() bool {
+	return s.whole.Sign() == 0
+}
+
+// BaseUnits converts the stake into the smallest unit of a token with the given decimals
+func (s *Stake) BaseUnits(decimals uint8) (*big.Int, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	units := new(big.Rat).Mul(s.whole, new(big.Rat).SetInt(scale))
+	if !units.IsInt() {
+		return nil, fmt.Errorf("stake %q is more precise than the token's %d decimals", s.Raw, decimals)
+	}
+	return new(big.Int).Set(units.Num()), nil
+}
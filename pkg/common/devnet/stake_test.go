@@ -0,0 +1,51 @@
+package devnet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStake(t *testing.T) {
+	tests := []struct {
+		input    string
+		decimals uint8
+		expected string
+	}{
+		{input: "1000ETH", decimals: 18, expected: "1000000000000000000000"},
+		{input: "1.5 ether", decimals: 18, expected: "1500000000000000000"},
+		{input: "5gwei", decimals: 18, expected: "5000000000"},
+		{input: "100wei", decimals: 18, expected: "100"},
+		{input: "250", decimals: 6, expected: "250000000"},
+		{input: "250.25 USDC", decimals: 6, expected: "250250000"},
+		{input: "32 stETH", decimals: 18, expected: "32000000000000000000"},
+		{input: "0", decimals: 18, expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			stake, err := ParseStake(tt.input)
+			require.NoError(t, err)
+
+			units, err := stake.BaseUnits(tt.decimals)
+			require.NoError(t, err)
+			expected, _ := new(big.Int).SetString(tt.expected, 10)
+			assert.Equal(t, expected, units)
+		})
+	}
+}
+
+func TestParseStake_Invalid(t *testing.T) {
+	for _, input := range []string{"", "ETH", "-5ETH", "1e18", "10 ETH extra"} {
+		_, err := ParseStake(input)
+		assert.Error(t, err, input)
+	}
+
+	// wei cannot be represented by a 6 decimal token
+	stake, err := ParseStake("1wei")
+	require.NoError(t, err)
+	_, err = stake.BaseUnits(6)
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// DEVNET_STATE_FILE describes the chains whose state anvil dumps into the devnet state dir
+const DEVNET_STATE_FILE = "state.yaml"
+
+// DOCKER_STATE_DIR is where the docker backend mounts the project's devnet state dir inside each chain container
+const DOCKER_STATE_DIR = "/devkit/state"
+
+// ErrNoSavedDevnetState is returned by ReadSavedDevnetState when no devnet was stopped with --keep-state
+var ErrNoSavedDevnetState = errors.New("no saved devnet state; stop the devnet with `devkit avs devnet stop --keep-state` first")
+
+// SavedDevnetChain records how a chain was started so it can be started again on top of its dumped state
+type SavedDevnetChain struct {
+	ChainID int `yaml:"chain_id"`
+	// Forked chains are resumed against the context's fork url at ForkBlock; the url itself is not stored
+	Forked    bool `yaml:"forked"`
+	ForkBlock int  `yaml:"fork_block"`
+}
+
+// SavedDevnetState describes the devnet whose chain state is kept in the devnet state dir
+type SavedDevnetState struct {
+	Project   string                      `yaml:"project"`
+	StartedAt time.Time                   `yaml:"started_at"`
+	Chains    map[string]SavedDevnetChain `yaml:"chains"`
+	// Kept is set by `devnet stop --keep-state`; only kept state is resumed
+	Kept    bool      `yaml:"kept"`
+	SavedAt time.Time `yaml:"saved_at,omitempty"`
+}
+
+// GetDevnetStateDir returns the project directory anvil dumps each chain's state into, for either backend
+func GetDevnetStateDir() string {
+	return filepath.Join(GetNativeDevnetDir(), "state")
+}
+
+// GetDevnetChainStatePath returns the file anvil dumps a chain's state to when it exits
+func GetDevnetChainStatePath(chainName string) string {
+	return filepath.Join(GetDevnetStateDir(), chainName+".json")
+}
+
+// StateArgs returns the anvil arguments persisting a chain's state to statePath on exit; resume also loads it
+func StateArgs(statePath string, resume bool) string {
+	if resume {
+		return "--state " + statePath
+	}
+	return "--dump-state " + statePath
+}
+
+// RecordDevnetState writes the description of the chains just started, replacing any earlier one
+func RecordDevnetState(projectName string, chains []ChainSpec) error {
+	state := &SavedDevnetState{
+		Project:   projectName,
+		StartedAt: time.Now().UTC().Truncate(time.Second),
+		Chains:    make(map[string]SavedDevnetChain, len(chains)),
+	}
+	for _, chain := range chains {
+		state.Chains[chain.Name] = SavedDevnetChain{ChainID: chain.ChainID, Forked: chain.ForkURL != "", ForkBlock: chain.ForkBlock}
+	}
+	return writeDevnetState(state)
+}
+
+// KeepDevnetState marks the recorded chains as kept for `devnet resume`. Chains whose anvil did not dump
+// its state are dropped; the names of the chains kept are returned.
+func KeepDevnetState() ([]string, error) {
+	state, err := readDevnetState()
+	if err != nil {
+		return nil, err
+	}
+	kept := []string{}
+	for chainName := range state.Chains {
+		if _, err := os.Stat(GetDevnetChainStatePath(chainName)); err != nil {
+			delete(state.Chains, chainName)
+			continue
+		}
+		kept = append(kept, chainName)
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no chain state was dumped to %s", GetDevnetStateDir())
+	}
+	sort.Strings(kept)
+
+	state.Kept = true
+	state.SavedAt = time.Now().UTC().Truncate(time.Second)
+	return kept, writeDevnetState(state)
+}
+
+// IsDevnetStateRecorded reports whether the running devnet was started with its chain state persisted
+func IsDevnetStateRecorded() bool {
+	_, err := readDevnetState()
+	return err == nil
+}
+
+// ReadSavedDevnetState returns the state kept by `devnet stop --keep-state`, or ErrNoSavedDevnetState
+func ReadSavedDevnetState() (*SavedDevnetState, error) {
+	state, err := readDevnetState()
+	if os.IsNotExist(err) {
+		return nil, ErrNoSavedDevnetState
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !state.Kept {
+		return nil, ErrNoSavedDevnetState
+	}
+	return state, nil
+}
+
+// DiscardDevnetState removes the dumped chain state and its description
+func DiscardDevnetState() error {
+	if err := os.RemoveAll(GetDevnetStateDir()); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", GetDevnetStateDir(), err)
+	}
+	return nil
+}
+
+func readDevnetState() (*SavedDevnetState, error) {
+	data, err := os.ReadFile(filepath.Join(GetDevnetStateDir(), DEVNET_STATE_FILE))
+	if err != nil {
+		return nil, err
+	}
+	var state SavedDevnetState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse devnet state: %w", err)
+	}
+	if state.Chains == nil {
+		state.Chains = map[string]SavedDevnetChain{}
+	}
+	return &state, nil
+}
+
+func writeDevnetState(state *SavedDevnetState) error {
+	if err := ensureDevnetStateDir(); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal devnet state: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(GetDevnetStateDir(), DEVNET_STATE_FILE), data, 0644); err != nil {
+		return fmt.Errorf("failed to write devnet state: %w", err)
+	}
+	return nil
+}
+
+// ensureDevnetStateDir creates the state dir writable by the user anvil runs as inside the Foundry image
+func ensureDevnetStateDir() error {
+	dir := GetDevnetStateDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.Chmod(dir, 0777); err != nil {
+		return fmt.Errorf("failed to make %s writable: %w", dir, err)
+	}
+	return nil
+}
+
+// ContractsWithoutCode returns the names of the contracts that have no code on the chain at rpcURL
+func ContractsWithoutCode(ctx context.Context, rpcURL string, contracts []devkitcommon.DeployedContract) ([]string, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	missing := []string{}
+	for _, contract := range contracts {
+		if !common.IsHexAddress(contract.Address) {
+			missing = append(missing, contract.Name)
+			continue
+		}
+		code, err := client.CodeAt(ctx, common.HexToAddress(contract.Address), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code of %s: %w", contract.Name, err)
+		}
+		if len(code) == 0 {
+			missing = append(missing, contract.Name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,83 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepAndResumeDevnetState(t *testing.T) {
+	originalCwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalCwd) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	_, err = ReadSavedDevnetState()
+	assert.ErrorIs(t, err, ErrNoSavedDevnetState)
+	assert.False(t, IsDevnetStateRecorded())
+
+	require.NoError(t, RecordDevnetState("alpha", []ChainSpec{
+		{Name: L1, ChainID: 31337, ForkURL: "https://eth.example", ForkBlock: 100},
+		{Name: "l2", ChainID: 31338},
+	}))
+	assert.True(t, IsDevnetStateRecorded())
+
+	// State that was recorded but not kept by the stop is not resumed
+	_, err = ReadSavedDevnetState()
+	assert.ErrorIs(t, err, ErrNoSavedDevnetState)
+
+	// Only L1's anvil dumped its state
+	require.NoError(t, os.WriteFile(GetDevnetChainStatePath(L1), []byte(`{}`), 0644))
+	kept, err := KeepDevnetState()
+	require.NoError(t, err)
+	assert.Equal(t, []string{L1}, kept)
+
+	saved, err := ReadSavedDevnetState()
+	require.NoError(t, err)
+	assert.Equal(t, "alpha", saved.Project)
+	assert.Equal(t, map[string]SavedDevnetChain{L1: {ChainID: 31337, Forked: true, ForkBlock: 100}}, saved.Chains)
+	assert.False(t, saved.SavedAt.IsZero())
+
+	data, err := os.ReadFile(filepath.Join(GetDevnetStateDir(), DEVNET_STATE_FILE))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "eth.example", "fork urls may carry API keys and are not stored")
+
+	require.NoError(t, DiscardDevnetState())
+	assert.NoDirExists(t, GetDevnetStateDir())
+}
+
+func TestStateArgs(t *testing.T) {
+	assert.Equal(t, "--dump-state /devkit/state/l1.json", StateArgs("/devkit/state/l1.json", false))
+	assert.Equal(t, "--state /devkit/state/l1.json", StateArgs("/devkit/state/l1.json", true))
+}
+
+func TestContractsWithoutCode(t *testing.T) {
+	deployed := "0x0000000000000000000000000000000000000001"
+	srv := rpctest.NewServer(t, rpctest.Handlers{
+		"eth_getCode": func(params []json.RawMessage) (any, error) {
+			var addr string
+			require.NoError(t, json.Unmarshal(params[0], &addr))
+			if strings.EqualFold(addr, deployed) {
+				return "0x6080", nil
+			}
+			return "0x", nil
+		},
+	})
+
+	missing, err := ContractsWithoutCode(context.Background(), srv.URL, []common.DeployedContract{
+		{Name: "Registrar", Address: deployed},
+		{Name: "TaskMailbox", Address: "0x0000000000000000000000000000000000000002"},
+		{Name: "Broken", Address: "not-an-address"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TaskMailbox", "Broken"}, missing)
+}
@@ -0,0 +1,262 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// DevnetStatus is a point-in-time health report of the devnet described by the devnet context
+type DevnetStatus struct {
+	Healthy      bool                `json:"healthy"`
+	Chains       []ChainStatus       `json:"chains"`
+	Contracts    []ContractStatus    `json:"contracts"`
+	OperatorSets []OperatorSetStatus `json:"operator_sets"`
+	Operators    []OperatorStatus    `json:"operators"`
+}
+
+// ChainStatus reports what a chain's RPC answers compared to its configuration
+type ChainStatus struct {
+	Name            string `json:"name"`
+	RPCURL          string `json:"rpc_url"`
+	Reachable       bool   `json:"reachable"`
+	ChainID         uint64 `json:"chain_id"`
+	ExpectedChainID uint64 `json:"expected_chain_id"`
+	BlockNumber     uint64 `json:"block_number"`
+	BlockTimestamp  uint64 `json:"block_timestamp"`
+	// Forked and ForkBlock are what the node reports through anvil_nodeInfo; ExpectedForkBlock is fork.block
+	Forked            bool   `json:"forked"`
+	ForkBlock         uint64 `json:"fork_block"`
+	ExpectedForkBlock uint64 `json:"expected_fork_block"`
+	ForkHost          string `json:"fork_host,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+// ContractStatus reports whether a deployed contract recorded in the context has code on L1
+type ContractStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	HasCode bool   `json:"has_code"`
+	Error   string `json:"error,omitempty"`
+}
+
+// OperatorSetStatus reports whether an operator set from the context exists in the AllocationManager
+type OperatorSetStatus struct {
+	ID         uint64   `json:"id"`
+	Exists     bool     `json:"exists"`
+	Strategies []string `json:"strategies"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// OperatorStatus reports an operator's EigenLayer registration and operator set membership
+type OperatorStatus struct {
+	Address       string                     `json:"address"`
+	IsOperator    bool                       `json:"is_operator"`
+	Registrations []OperatorRegistrationInfo `json:"registrations"`
+	Error         string                     `json:"error,omitempty"`
+}
+
+// OperatorRegistrationInfo reports membership of one operator set expected by the context
+type OperatorRegistrationInfo struct {
+	OperatorSetID uint64 `json:"operator_set_id"`
+	Registered    bool   `json:"registered"`
+}
+
+// statusProbeTimeout bounds each RPC round trip made while collecting status
+const statusProbeTimeout = 5 * time.Second
+
+// CollectDevnetStatus queries every chain of the devnet context, then checks deployed contracts,
+// operator sets and operator registrations on L1. Failures are recorded in the report rather than returned.
+func CollectDevnetStatus(ctx context.Context, cfg *devkitcommon.ConfigWithContextConfig) *DevnetStatus {
+	envCtx := cfg.Context[CONTEXT]
+	status := &DevnetStatus{
+		Healthy:      true,
+		Chains:       []ChainStatus{},
+		Contracts:    []ContractStatus{},
+		OperatorSets: []OperatorSetStatus{},
+		Operators:    []OperatorStatus{},
+	}
+
+	for _, name := range GetDevnetChainNames(cfg) {
+		chainStatus := collectChainStatus(ctx, cfg, name)
+		if !chainStatus.Reachable || chainStatus.Error != "" {
+			status.Healthy = false
+		}
+		status.Chains = append(status.Chains, chainStatus)
+	}
+
+	l1Cfg, ok := envCtx.Chains[L1]
+	if !ok || l1Cfg.RPCURL == "" {
+		status.Healthy = false
+		return status
+	}
+	client, err := ethclient.DialContext(ctx, l1Cfg.RPCURL)
+	if err != nil {
+		status.Healthy = false
+		return status
+	}
+	defer client.Close()
+
+	for _, contract := range envCtx.DeployedContracts {
+		contractStatus := ContractStatus{Name: contract.Name, Address: contract.Address}
+		if err := withProbeTimeout(ctx, func(ctx context.Context) error {
+			code, err := client.CodeAt(ctx, common.HexToAddress(contract.Address), nil)
+			contractStatus.HasCode = len(code) > 0
+			return err
+		}); err != nil {
+			contractStatus.Error = err.Error()
+		}
+		if !contractStatus.HasCode {
+			status.Healthy = false
+		}
+		status.Contracts = append(status.Contracts, contractStatus)
+	}
+
+	allocationManagerAddr, delegationManagerAddr := GetEigenLayerAddresses(cfg)
+	allocationManager, err := allocationmanager.NewAllocationManagerCaller(common.HexToAddress(allocationManagerAddr), client)
+	if err != nil {
+		status.Healthy = false
+		return status
+	}
+	delegationManager, err := delegationmanager.NewDelegationManagerCaller(common.HexToAddress(delegationManagerAddr), client)
+	if err != nil {
+		status.Healthy = false
+		return status
+	}
+	avs := common.HexToAddress(envCtx.Avs.Address)
+
+	for _, set := range envCtx.OperatorSets {
+		setStatus := OperatorSetStatus{ID: set.OperatorSetID, Strategies: []string{}}
+		for _, strategy := range set.Strategies {
+			setStatus.Strategies = append(setStatus.Strategies, strategy.StrategyAddress)
+		}
+		if err := withProbeTimeout(ctx, func(ctx context.Context) error {
+			exists, err := allocationManager.IsOperatorSet(&bind.CallOpts{Context: ctx}, allocationmanager.OperatorSet{Avs: avs, Id: uint32(set.OperatorSetID)})
+			setStatus.Exists = exists
+			return err
+		}); err != nil {
+			setStatus.Error = err.Error()
+		}
+		if !setStatus.Exists {
+			status.Healthy = false
+		}
+		status.OperatorSets = append(status.OperatorSets, setStatus)
+	}
+
+	for _, op := range envCtx.Operators {
+		opStatus := OperatorStatus{Address: op.Address, Registrations: []OperatorRegistrationInfo{}}
+		operator := common.HexToAddress(op.Address)
+		err := withProbeTimeout(ctx, func(ctx context.Context) error {
+			isOperator, err := delegationManager.IsOperator(&bind.CallOpts{Context: ctx}, operator)
+			if err != nil {
+				return err
+			}
+			opStatus.IsOperator = isOperator
+
+			for _, reg := range envCtx.OperatorRegistrations {
+				if !common.IsHexAddress(reg.Address) || common.HexToAddress(reg.Address) != operator {
+					continue
+				}
+				registered, err := allocationManager.IsMemberOfOperatorSet(&bind.CallOpts{Context: ctx}, operator, allocationmanager.OperatorSet{Avs: avs, Id: uint32(reg.OperatorSetID)})
+				if err != nil {
+					return err
+				}
+				if !registered {
+					status.Healthy = false
+				}
+				opStatus.Registrations = append(opStatus.Registrations, OperatorRegistrationInfo{OperatorSetID: reg.OperatorSetID, Registered: registered})
+			}
+			return nil
+		})
+		if err != nil {
+			opStatus.Error = err.Error()
+			status.Healthy = false
+		}
+		status.Operators = append(status.Operators, opStatus)
+	}
+
+	return status
+}
+
+// collectChainStatus probes a single chain's RPC
+func collectChainStatus(ctx context.Context, cfg *devkitcommon.ConfigWithContextConfig, name string) ChainStatus {
+	chainCfg := cfg.Context[CONTEXT].Chains[name]
+	chainStatus := ChainStatus{
+		Name:            name,
+		RPCURL:          chainCfg.RPCURL,
+		ExpectedChainID: uint64(chainCfg.ChainID),
+	}
+	if chainCfg.Fork != nil {
+		chainStatus.ExpectedForkBlock = uint64(chainCfg.Fork.Block)
+	}
+	if forkUrl, err := GetDevnetForkUrlDefault(cfg, name); err == nil {
+		chainStatus.ForkHost = forkHost(forkUrl)
+	}
+
+	client, err := ethclient.DialContext(ctx, chainCfg.RPCURL)
+	if err != nil {
+		chainStatus.Error = err.Error()
+		return chainStatus
+	}
+	defer client.Close()
+
+	err = withProbeTimeout(ctx, func(ctx context.Context) error {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		chainStatus.Reachable = true
+		chainStatus.ChainID = chainID.Uint64()
+
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		chainStatus.BlockNumber = header.Number.Uint64()
+		chainStatus.BlockTimestamp = header.Time
+
+		forkBlock, forked, err := (&AnvilClient{rpc: client.Client()}).ForkBlock(ctx)
+		if err != nil {
+			return err
+		}
+		chainStatus.Forked = forked
+		chainStatus.ForkBlock = forkBlock
+		return nil
+	})
+	switch {
+	case err != nil:
+		chainStatus.Error = err.Error()
+	case chainStatus.ExpectedChainID != 0 && chainStatus.ChainID != chainStatus.ExpectedChainID:
+		chainStatus.Error = fmt.Sprintf("chain id %d does not match configured %d", chainStatus.ChainID, chainStatus.ExpectedChainID)
+	case chainStatus.ExpectedForkBlock != 0 && !chainStatus.Forked:
+		chainStatus.Error = fmt.Sprintf("chain is not forked but fork block %d is configured", chainStatus.ExpectedForkBlock)
+	case chainStatus.ExpectedForkBlock != 0 && chainStatus.ForkBlock != chainStatus.ExpectedForkBlock:
+		chainStatus.Error = fmt.Sprintf("chain forked at block %d does not match configured %d", chainStatus.ForkBlock, chainStatus.ExpectedForkBlock)
+	}
+	return chainStatus
+}
+
+// forkHost returns only the host of a fork url so API keys in the path or query are never printed
+func forkHost(forkUrl string) string {
+	parsed, err := url.Parse(forkUrl)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// withProbeTimeout runs fn with a context bounded by statusProbeTimeout
+func withProbeTimeout(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, statusProbeTimeout)
+	defer cancel()
+	return fn(ctx)
+}
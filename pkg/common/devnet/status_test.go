@@ -0,0 +1,75 @@
+package devnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkHost(t *testing.T) {
+	assert.Equal(t, "eth-mainnet.g.alchemy.com", forkHost("https://eth-mainnet.g.alchemy.com/v2/secret-key"))
+	assert.Equal(t, "localhost", forkHost("http://localhost:8545"))
+	assert.Empty(t, forkHost("not a url"))
+}
+
+func TestCollectDevnetStatus_Chains(t *testing.T) {
+	t.Setenv("L1_FORK_URL", "https://rpc.example.com/key")
+	t.Setenv("L2_FORK_URL", "")
+
+	srv := newFakeForkedRPC(t, 31337, 120, uint64Ptr(100), 0)
+	cfg := &common.ConfigWithContextConfig{
+		Context: map[string]common.ChainContextConfig{
+			CONTEXT: {
+				Chains: map[string]common.ChainConfig{
+					"l1": {ChainID: 31337, RPCURL: srv.URL, Fork: &common.ForkConfig{Block: 100}},
+					"l2": {ChainID: 31338, RPCURL: "http://127.0.0.1:1", Fork: &common.ForkConfig{Block: 5}},
+				},
+			},
+		},
+	}
+
+	status := CollectDevnetStatus(context.Background(), cfg)
+	require.Len(t, status.Chains, 2)
+	assert.False(t, status.Healthy)
+
+	l1 := status.Chains[0]
+	assert.True(t, l1.Reachable)
+	assert.Equal(t, uint64(31337), l1.ChainID)
+	assert.True(t, l1.Forked)
+	assert.Equal(t, uint64(100), l1.ForkBlock)
+	assert.Empty(t, l1.Error)
+	assert.Equal(t, "rpc.example.com", l1.ForkHost)
+
+	l2 := status.Chains[1]
+	assert.Equal(t, "l2", l2.Name)
+	assert.False(t, l2.Reachable)
+	assert.NotEmpty(t, l2.Error)
+}
+
+func TestCollectDevnetStatus_ForkBlockFromNode(t *testing.T) {
+	t.Setenv("L1_FORK_URL", "")
+
+	// The node forked at latest (120) although fork.block says 100; the status reports what the node says
+	srv := newFakeForkedRPC(t, 31337, 125, uint64Ptr(120), 0)
+	cfg := &common.ConfigWithContextConfig{
+		Context: map[string]common.ChainContextConfig{
+			CONTEXT: {
+				Chains: map[string]common.ChainConfig{
+					"l1": {ChainID: 31337, RPCURL: srv.URL, Fork: &common.ForkConfig{Block: 100}},
+				},
+			},
+		},
+	}
+
+	status := CollectDevnetStatus(context.Background(), cfg)
+	require.Len(t, status.Chains, 1)
+	assert.False(t, status.Healthy)
+	l1 := status.Chains[0]
+	assert.Equal(t, uint64(120), l1.ForkBlock)
+	assert.Equal(t, uint64(100), l1.ExpectedForkBlock)
+	assert.Equal(t, "chain forked at block 120 does not match configured 100", l1.Error)
+}
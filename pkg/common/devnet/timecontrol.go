@@ -0,0 +1,85 @@
+package devnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AutomineMode is the mining behaviour requested by `devnet automine`
+type AutomineMode struct {
+	// Automine mines a block for every transaction
+	Automine bool
+	// Interval mines a block every interval when non-zero
+	Interval time.Duration
+}
+
+// WarpTarget is where `devnet warp` moves chain time to: either forward by Duration or to Timestamp
+type WarpTarget struct {
+	Duration  time.Duration
+	Timestamp uint64
+}
+
+// ParseWarpTarget accepts a duration ("90s", "12h", "7d") or an absolute time as a unix
+// timestamp or RFC3339 string ("1735689600", "2025-01-01T00:00:00Z")
+func ParseWarpTarget(value string) (*WarpTarget, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("warp target is required: pass a duration (e.g. 12h, 7d) or a timestamp")
+	}
+
+	if ts, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return &WarpTarget{Timestamp: ts}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if t.Unix() < 0 {
+			return nil, fmt.Errorf("timestamp %s is before the unix epoch", value)
+		}
+		return &WarpTarget{Timestamp: uint64(t.Unix())}, nil
+	}
+
+	d, err := parseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warp target %q: use a duration (e.g. 90s, 12h, 7d), unix timestamp or RFC3339 time", value)
+	}
+	if d < time.Second {
+		return nil, fmt.Errorf("warp duration must be at least 1s, got %s", value)
+	}
+	return &WarpTarget{Duration: d}, nil
+}
+
+// ParseAutomineMode accepts "on", "off" or a mining interval ("3s", or "3" for seconds)
+func ParseAutomineMode(value string) (*AutomineMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on":
+		return &AutomineMode{Automine: true}, nil
+	case "off":
+		return &AutomineMode{}, nil
+	}
+
+	var d time.Duration
+	var err error
+	if secs, convErr := strconv.ParseUint(strings.TrimSpace(value), 10, 64); convErr == nil {
+		d = time.Duration(secs) * time.Second
+	} else {
+		d, err = parseDuration(value)
+	}
+	if err != nil || d < time.Second || d%time.Second != 0 {
+		return nil, fmt.Errorf("invalid automine mode %q: use on, off or a whole-second interval such as 3s", value)
+	}
+	return &AutomineMode{Interval: d}, nil
+}
+
+// parseDuration extends time.ParseDuration with a "d" suffix for days
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,58 @@
+package devnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWarpTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected WarpTarget
+	}{
+		{input: "90s", expected: WarpTarget{Duration: 90 * time.Second}},
+		{input: "12h", expected: WarpTarget{Duration: 12 * time.Hour}},
+		{input: "7d", expected: WarpTarget{Duration: 7 * 24 * time.Hour}},
+		{input: "1735689600", expected: WarpTarget{Timestamp: 1735689600}},
+		{input: "2025-01-01T00:00:00Z", expected: WarpTarget{Timestamp: 1735689600}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			target, err := ParseWarpTarget(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *target)
+		})
+	}
+
+	for _, input := range []string{"", "soon", "500ms", "-1h"} {
+		_, err := ParseWarpTarget(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseAutomineMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AutomineMode
+	}{
+		{input: "on", expected: AutomineMode{Automine: true}},
+		{input: "OFF", expected: AutomineMode{}},
+		{input: "3s", expected: AutomineMode{Interval: 3 * time.Second}},
+		{input: "12", expected: AutomineMode{Interval: 12 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			mode, err := ParseAutomineMode(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *mode)
+		})
+	}
+
+	for _, input := range []string{"", "sometimes", "500ms", "1.5s", "0"} {
+		_, err := ParseAutomineMode(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"net"
 	"net/url"
 	"os"
-	"os/exec"
 	"regexp"
 	"runtime"
 	"strings"
@@ -27,31 +26,17 @@ func IsPortAvailable(port int) bool {
 	return false
 }
 
-// / Stops the container and removes it
-func StopAndRemoveContainer(ctx *cli.Context, containerName string) {
+// StopAndRemoveContainer stops and removes a devnet container and drops it from the devnet registry
+func StopAndRemoveContainer(ctx *cli.Context, manager ContainerManager, containerName string) {
 	logger := common.LoggerFromContext(ctx.Context)
 
-	if err := exec.CommandContext(ctx.Context, "docker", "stop", containerName).Run(); err != nil {
-		logger.Error("⚠️  Failed to stop container %s: %v", containerName, err)
+	if err := StopAndRemoveDevnetContainer(ctx.Context, manager, containerName); err != nil {
+		logger.Error("⚠️  Failed to stop and remove container %s: %v", containerName, err)
 	} else {
-		logger.Info("✅ Stopped container %s", containerName)
+		logger.Info("✅ Stopped and removed container %s", containerName)
 	}
-	if err := exec.CommandContext(ctx.Context, "docker", "rm", containerName).Run(); err != nil {
-		logger.Error("⚠️  Failed to remove container %s: %v", containerName, err)
-	} else {
-		logger.Info("✅ Removed container %s", containerName)
-	}
-}
-
-// GetDockerPsDevnetArgs returns the arguments needed to list all running
-// devkit devnet Docker containers along with their exposed ports.
-// It filters containers by name prefix ("devkit-devnet") and formats
-// the output to show container name and port mappings in a readable form.
-func GetDockerPsDevnetArgs() []string {
-	return []string{
-		"ps",
-		"--filter", "name=devkit-devnet",
-		"--format", "{{.Names}}: {{.Ports}}",
+	if err := UnregisterDevnetContainers(containerName); err != nil {
+		logger.Warn("Failed to remove container %s from the devnet registry: %v", containerName, err)
 	}
 }
 
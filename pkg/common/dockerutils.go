@@ -3,11 +3,14 @@ package common
 import (
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"runtime"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/urfave/cli/v2"
 )
 
@@ -92,6 +95,43 @@ func isDockerRunning(ctx context.Context, pingTimeout time.Duration) error {
 	return err
 }
 
+// ContainerLogOptions selects which part of a container's logs to stream
+type ContainerLogOptions struct {
+	// Since is a relative duration (e.g. "10m") or RFC3339 timestamp; empty shows everything
+	Since string
+	// Tail is the number of lines to show from the end, or "all"
+	Tail string
+	// Follow keeps streaming new output until ctx is cancelled
+	Follow bool
+}
+
+// StreamContainerLogs copies a container's stdout and stderr to the given writers
+func StreamContainerLogs(ctx context.Context, containerName string, opts ContainerLogOptions, stdout, stderr io.Writer) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer cli.Close()
+
+	reader, err := cli.ContainerLogs(ctx, containerName, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
+		Follow:     opts.Follow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read logs of %s: %w", containerName, err)
+	}
+	defer reader.Close()
+
+	// Devnet containers run without a TTY so the stream is multiplexed
+	if _, err := stdcopy.StdCopy(stdout, stderr, reader); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to stream logs of %s: %w", containerName, err)
+	}
+	return nil
+}
+
 // Check if docker is installed
 func isDockerInstalled() bool {
 	_, err := exec.LookPath("docker")
@@ -0,0 +1,250 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// dryRunGasLimit is set on dry-run transactions so building them skips gas estimation; it is never broadcast
+const dryRunGasLimit = 30_000_000
+
+// dryRunContextKey is used to store the dry-run report in the context
+type dryRunContextKey struct{}
+
+// DryRunArg is a decoded argument of a simulated call
+type DryRunArg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// DryRunCall is a transaction that was built and simulated instead of broadcast. A failed call is Unverified when
+// it may depend on an earlier call of the dry run, whose effects the simulation does not include.
+type DryRunCall struct {
+	Description string      `json:"description"`
+	ChainID     uint64      `json:"chain_id"`
+	Target      string      `json:"target"`
+	Method      string      `json:"method,omitempty"`
+	Signature   string      `json:"signature,omitempty"`
+	Args        []DryRunArg `json:"args,omitempty"`
+	Sender      string      `json:"sender"`
+	Value       string      `json:"value,omitempty"`
+	GasEstimate uint64      `json:"gas_estimate,omitempty"`
+	Success     bool        `json:"success"`
+	Unverified  bool        `json:"unverified,omitempty"`
+	Revert      string      `json:"revert,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Data        string      `json:"data"`
+}
+
+// fail records why the simulation failed: the decoded revert, or any other error
+func (c *DryRunCall) fail(err error) {
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		c.Revert = revertErr.Reason
+		return
+	}
+	c.Error = err.Error()
+}
+
+// DryRunReport collects the transactions a command would have sent
+type DryRunReport struct {
+	Calls []DryRunCall `json:"calls"`
+}
+
+// NewDryRunReport returns an empty report
+func NewDryRunReport() *DryRunReport {
+	return &DryRunReport{Calls: []DryRunCall{}}
+}
+
+// WithDryRun stores the dry-run report in the context; transactions built under it are simulated, not sent
+func WithDryRun(ctx context.Context, report *DryRunReport) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, report)
+}
+
+// DryRunFromContext retrieves the dry-run report from the context, or nil outside a dry run
+func DryRunFromContext(ctx context.Context) *DryRunReport {
+	if report, ok := ctx.Value(dryRunContextKey{}).(*DryRunReport); ok {
+		return report
+	}
+	return nil
+}
+
+// IsDryRun reports whether the context belongs to a dry run
+func IsDryRun(ctx context.Context) bool {
+	return DryRunFromContext(ctx) != nil
+}
+
+// Add records a simulated transaction
+func (r *DryRunReport) Add(call DryRunCall) {
+	r.Calls = append(r.Calls, call)
+}
+
+// Failed returns the number of simulated transactions that would revert or could not be simulated; unverified
+// calls are not counted
+func (r *DryRunReport) Failed() int {
+	failed := 0
+	for _, call := range r.Calls {
+		if !call.Success && !call.Unverified {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Unverified returns the number of failed simulations that may only fail for lack of an earlier call's effects
+func (r *DryRunReport) Unverified() int {
+	unverified := 0
+	for _, call := range r.Calls {
+		if call.Unverified {
+			unverified++
+		}
+	}
+	return unverified
+}
+
+// followsSimulatedCall reports whether an earlier call from the same sender on the same chain simulated
+// successfully. Each call is simulated on its own, so one that follows such a call (an approve before a deposit,
+// a registration before joining operator sets) may rely on state the earlier call would have left.
+func (r *DryRunReport) followsSimulatedCall(call DryRunCall) bool {
+	for _, earlier := range r.Calls {
+		if earlier.Success && earlier.ChainID == call.ChainID && earlier.Sender == call.Sender {
+			return true
+		}
+	}
+	return false
+}
+
+// Print logs every simulated transaction with its decoded call and expected outcome
+func (r *DryRunReport) Print(logger iface.Logger) {
+	logger.Title("Dry run: %d transaction(s) simulated, none broadcast", len(r.Calls))
+	for i, call := range r.Calls {
+		logger.Info("%d. %s", i+1, call.Description)
+		logger.Info("   target: %s (chain %d)", call.Target, call.ChainID)
+		if call.Signature != "" {
+			logger.Info("   method: %s", call.Signature)
+		} else {
+			logger.Info("   method: unknown, data %s", call.Data)
+		}
+		for _, arg := range call.Args {
+			logger.Info("     %s %s = %s", arg.Type, arg.Name, arg.Value)
+		}
+		logger.Info("   sender: %s", call.Sender)
+		if call.Value != "" {
+			logger.Info("   value:  %s wei", call.Value)
+		}
+		if call.GasEstimate > 0 {
+			logger.Info("   gas:    %d", call.GasEstimate)
+		}
+		switch {
+		case call.Success:
+			logger.Info("   result: ✅ succeeds")
+		case call.Unverified && call.Revert != "":
+			logger.Warn("   result: ⚠️  unverified: reverts with %s without the effects of the earlier transactions above", call.Revert)
+		case call.Unverified:
+			logger.Warn("   result: ⚠️  unverified: %s without the effects of the earlier transactions above", call.Error)
+		case call.Revert != "":
+			logger.Error("   result: ❌ reverts with %s", call.Revert)
+		default:
+			logger.Error("   result: ❌ %s", call.Error)
+		}
+	}
+}
+
+// WriteJSON writes the report as indented JSON to path, or to stdout when path is "-"
+func (r *DryRunReport) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dry-run report: %w", err)
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write dry-run report: %w", err)
+	}
+	return nil
+}
+
+// DecodeCallData finds the method data calls in abis and decodes its arguments. Calldata matching no method
+// yields an empty method.
+func DecodeCallData(data []byte, abis ...*abi.ABI) (method, signature string, args []DryRunArg) {
+	if len(data) < 4 {
+		return "", "", nil
+	}
+	for _, parsed := range abis {
+		m, err := parsed.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		values, err := m.Inputs.Unpack(data[4:])
+		if err != nil {
+			return m.Name, m.Sig, nil
+		}
+		args = make([]DryRunArg, len(values))
+		for i, value := range values {
+			args[i] = DryRunArg{Name: m.Inputs[i].Name, Type: m.Inputs[i].Type.String(), Value: formatCallValue(reflect.ValueOf(value))}
+		}
+		return m.Name, m.Sig, args
+	}
+	return "", "", nil
+}
+
+// formatCallValue renders a decoded ABI value, showing addresses and bytes as hex and structs with field names
+func formatCallValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	switch value := v.Interface().(type) {
+	case common.Address:
+		return value.Hex()
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return hexutil.Encode(value)
+	case string:
+		return fmt.Sprintf("%q", value)
+	}
+
+	switch v.Kind() {
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			raw := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(raw), v)
+			return hexutil.Encode(raw)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatCallValue(v.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = v.Type().Field(i).Name + ": " + formatCallValue(v.Field(i))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "<nil>"
+		}
+		return formatCallValue(v.Elem())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCallData(t *testing.T) {
+	avs := common.HexToAddress("0x00000000000000000000000000000000000000a0")
+	operator := common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	strategy := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	params := allocationmanager.IAllocationManagerTypesSlashingParams{
+		Operator:      operator,
+		OperatorSetId: 2,
+		Strategies:    []common.Address{strategy},
+		WadsToSlash:   []*big.Int{big.NewInt(250000000000000000)},
+		Description:   "missed task",
+	}
+	data, err := PackSlashOperator(avs, params)
+	require.NoError(t, err)
+
+	method, signature, args := DecodeCallData(data, EigenLayerABIs()...)
+	assert.Equal(t, "slashOperator", method)
+	assert.Equal(t, "slashOperator(address,(address,uint32,address[],uint256[],string))", signature)
+	require.Len(t, args, 2)
+	assert.Equal(t, DryRunArg{Name: "avs", Type: "address", Value: avs.Hex()}, args[0])
+	assert.Equal(t, "params", args[1].Name)
+	assert.Equal(t,
+		`{Operator: `+operator.Hex()+`, OperatorSetId: 2, Strategies: [`+strategy.Hex()+`], WadsToSlash: [250000000000000000], Description: "missed task"}`,
+		args[1].Value)
+
+	approve, err := ERC20ABI.Pack("approve", avs, big.NewInt(5))
+	require.NoError(t, err)
+	method, _, args = DecodeCallData(approve, EigenLayerABIs()...)
+	assert.Empty(t, method, "approve is not an EigenLayer method")
+	assert.Nil(t, args)
+	method, _, args = DecodeCallData(approve, &ERC20ABI)
+	assert.Equal(t, "approve", method)
+	assert.Equal(t, []DryRunArg{
+		{Name: "spender", Type: "address", Value: avs.Hex()},
+		{Name: "amount", Type: "uint256", Value: "5"},
+	}, args)
+}
+
+func TestFormatCallValueBytes(t *testing.T) {
+	data, err := ERC20ABI.Pack("symbol")
+	require.NoError(t, err)
+	_, _, args := DecodeCallData(data, &ERC20ABI)
+	assert.Empty(t, args)
+
+	bytesABI := mustParseABI(`[{"type":"function","name":"f","inputs":[{"name":"payload","type":"bytes"},{"name":"id","type":"bytes32"}],"outputs":[]}]`)
+	data, err = bytesABI.Pack("f", []byte{0xca, 0xfe}, [32]byte{0x01})
+	require.NoError(t, err)
+	_, _, args = DecodeCallData(data, &bytesABI)
+	require.Len(t, args, 2)
+	assert.Equal(t, "0xcafe", args[0].Value)
+	assert.Equal(t, "0x01"+"00000000000000000000000000000000000000000000000000000000000000", args[1].Value)
+}
+
+func TestDryRunReport(t *testing.T) {
+	assert.False(t, IsDryRun(context.Background()))
+
+	report := NewDryRunReport()
+	ctx := WithDryRun(context.Background(), report)
+	assert.True(t, IsDryRun(ctx))
+	assert.Same(t, report, DryRunFromContext(ctx))
+
+	report.Add(DryRunCall{Description: "ok", Success: true})
+	report.Add(DryRunCall{Description: "reverts", Revert: "InvalidOperatorSet()"})
+	report.Add(DryRunCall{Description: "depends on ok", Revert: "InsufficientBalance()", Unverified: true})
+	assert.Equal(t, 1, report.Failed())
+	assert.Equal(t, 1, report.Unverified())
+
+	path := filepath.Join(t.TempDir(), "dry-run.json")
+	require.NoError(t, report.WriteJSON(path))
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Calls []map[string]interface{} `json:"calls"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	require.Len(t, decoded.Calls, 3)
+	assert.Equal(t, true, decoded.Calls[0]["success"])
+	assert.Equal(t, "InvalidOperatorSet()", decoded.Calls[1]["revert"])
+	assert.Equal(t, true, decoded.Calls[2]["unverified"])
+}
+
+func TestContractCallerDryRun(t *testing.T) {
+	amABI, err := allocationmanager.AllocationManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	revertData := packCustomError(t, amABI, "InvalidOperatorSet")
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	allocationManager := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	signTx := func(data []byte) *types.Transaction {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(31337)), &types.DynamicFeeTx{
+			ChainID:   big.NewInt(31337),
+			Gas:       dryRunGasLimit,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			To:        &allocationManager,
+			Data:      data,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	okData, err := amABI.Pack("updateAVSMetadataURI", sender, "https://example.com/avs.json")
+	require.NoError(t, err)
+	revertingData, err := amABI.Pack("setAVSRegistrar", sender, sender)
+	require.NoError(t, err)
+
+	// A dry run may only estimate gas and call; any other method fails the test
+	simulate := func(result any) rpctest.Handler {
+		return func(params []json.RawMessage) (any, error) {
+			var call struct {
+				Input hexutil.Bytes `json:"input"`
+			}
+			require.NoError(t, json.Unmarshal(params[0], &call))
+			if len(call.Input) >= 4 && hexutil.Encode(call.Input[:4]) == hexutil.Encode(revertingData[:4]) {
+				return nil, &rpctest.Error{Code: 3, Message: "execution reverted", Data: hexutil.Encode(revertData)}
+			}
+			return result, nil
+		}
+	}
+	server := rpctest.NewServer(t, rpctest.Handlers{
+		"eth_estimateGas": simulate(hexutil.Uint64(0xb1a0)),
+		"eth_call":        simulate(hexutil.Bytes{}),
+	})
+
+	caller := newTestContractCaller(t, server.URL, newPrivateKeySigner(key))
+	report := NewDryRunReport()
+	ctx := WithDryRun(context.Background(), report)
+
+	require.NoError(t, caller.SendAndWaitForTransaction(ctx, "SetAVSRegistrar", func() (*types.Transaction, error) {
+		return signTx(revertingData), nil
+	}))
+	require.NoError(t, caller.SendAndWaitForTransaction(ctx, "UpdateAVSMetadataURI", func() (*types.Transaction, error) {
+		return signTx(okData), nil
+	}))
+	// After a successful call from the same sender a revert may only be missing that call's effects
+	require.NoError(t, caller.SendAndWaitForTransaction(ctx, "SetAVSRegistrar", func() (*types.Transaction, error) {
+		return signTx(revertingData), nil
+	}))
+
+	require.Len(t, report.Calls, 3)
+	ok := report.Calls[1]
+	assert.Equal(t, "UpdateAVSMetadataURI", ok.Description)
+	assert.Equal(t, uint64(31337), ok.ChainID)
+	assert.Equal(t, allocationManager.Hex(), ok.Target)
+	assert.Equal(t, sender.Hex(), ok.Sender)
+	assert.Equal(t, "updateAVSMetadataURI", ok.Method)
+	assert.Equal(t, `"https://example.com/avs.json"`, ok.Args[1].Value)
+	assert.Equal(t, uint64(0xb1a0), ok.GasEstimate)
+	assert.True(t, ok.Success)
+
+	reverted := report.Calls[0]
+	assert.Equal(t, "setAVSRegistrar", reverted.Method)
+	assert.False(t, reverted.Success)
+	assert.False(t, reverted.Unverified)
+	assert.Equal(t, "InvalidOperatorSet()", reverted.Revert)
+
+	dependent := report.Calls[2]
+	assert.False(t, dependent.Success)
+	assert.True(t, dependent.Unverified)
+	assert.Equal(t, "InvalidOperatorSet()", dependent.Revert)
+	assert.Equal(t, 1, report.Failed())
+	assert.Equal(t, 1, report.Unverified())
+}
+
+func TestBuildTxOptsDryRun(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	caller := &ContractCaller{signer: newPrivateKeySigner(key), chainID: big.NewInt(31337)}
+
+	opts, err := caller.buildTxOpts(context.Background())
+	require.NoError(t, err)
+	assert.False(t, opts.NoSend)
+	assert.Zero(t, opts.GasLimit)
+
+	opts, err = caller.buildTxOpts(WithDryRun(context.Background(), NewDryRunReport()))
+	require.NoError(t, err)
+	assert.True(t, opts.NoSend)
+	assert.Equal(t, uint64(dryRunGasLimit), opts.GasLimit)
+}
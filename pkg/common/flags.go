@@ -18,3 +18,15 @@ var GlobalFlags = []cli.Flag{
 		Usage: "Disable telemetry collection on first run without prompting",
 	},
 }
+
+// DryRunFlags are added to the commands that send transactions through the ContractCaller
+var DryRunFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Build and simulate each transaction against the current chain state without broadcasting it",
+	},
+	&cli.StringFlag{
+		Name:  "dry-run-output",
+		Usage: "Write the dry-run report as JSON to this file ('-' for stdout); implies --dry-run",
+	},
+}
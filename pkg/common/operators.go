@@ -0,0 +1,136 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/yaml.v3"
+)
+
+// GetOperatorSpec returns the context operator with operatorAddress: the address its ecdsa_key derives, or its
+// address when it uses a signer reference
+func GetOperatorSpec(ctx ChainContextConfig, operatorAddress string) (*OperatorSpec, error) {
+	for i, op := range ctx.Operators {
+		if op.Signer != nil {
+			if strings.EqualFold(op.Address, operatorAddress) {
+				return &ctx.Operators[i], nil
+			}
+			continue
+		}
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(op.ECDSAKey, "0x"))
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(crypto.PubkeyToAddress(key.PublicKey).Hex(), operatorAddress) {
+			return &ctx.Operators[i], nil
+		}
+	}
+	return nil, fmt.Errorf("operator with address %s not found in config", operatorAddress)
+}
+
+// RemoveOperatorRegistrations drops the operator's entries for operatorSetIDs from the operator_registrations
+// of a context mapping node and returns how many were removed
+func RemoveOperatorRegistrations(contextNode *yaml.Node, operatorAddress string, operatorSetIDs []uint32) int {
+	registrations := GetChildByKey(contextNode, "operator_registrations")
+	if registrations == nil || registrations.Kind != yaml.SequenceNode {
+		return 0
+	}
+
+	kept := make([]*yaml.Node, 0, len(registrations.Content))
+	for _, entry := range registrations.Content {
+		if isOperatorRegistration(entry, operatorAddress, operatorSetIDs) {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	removed := len(registrations.Content) - len(kept)
+	registrations.Content = kept
+	if len(kept) == 0 {
+		registrations.Style = yaml.FlowStyle
+	}
+	return removed
+}
+
+func isOperatorRegistration(entry *yaml.Node, operatorAddress string, operatorSetIDs []uint32) bool {
+	addressNode := GetChildByKey(entry, "address")
+	if addressNode == nil || !strings.EqualFold(addressNode.Value, operatorAddress) {
+		return false
+	}
+	setIDNode := GetChildByKey(entry, "operator_set_id")
+	if setIDNode == nil {
+		return false
+	}
+	setID, err := strconv.ParseUint(setIDNode.Value, 10, 32)
+	if err != nil {
+		return false
+	}
+	for _, id := range operatorSetIDs {
+		if uint32(setID) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOperatorMetadataURI records uri as the metadata_uri of the operator in a context mapping node and
+// reports whether the operator was found
+func SetOperatorMetadataURI(contextNode *yaml.Node, operatorAddress, uri string) bool {
+	operators := GetChildByKey(contextNode, "operators")
+	if operators == nil || operators.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, entry := range operators.Content {
+		addressNode := GetChildByKey(entry, "address")
+		if addressNode == nil || !strings.EqualFold(addressNode.Value, operatorAddress) {
+			continue
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "metadata_uri"}
+		valNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: uri}
+		SetMappingValue(entry, keyNode, valNode)
+		return true
+	}
+	return false
+}
+
+// SetOperatorSigner replaces the ecdsa_key of the operator in a context mapping node with a signer reference
+// and reports whether the operator was found
+func SetOperatorSigner(contextNode *yaml.Node, operatorAddress string, signer SignerConfig) (bool, error) {
+	operators := GetChildByKey(contextNode, "operators")
+	if operators == nil || operators.Kind != yaml.SequenceNode {
+		return false, nil
+	}
+	var signerNode yaml.Node
+	if err := signerNode.Encode(signer); err != nil {
+		return false, fmt.Errorf("failed to encode signer: %w", err)
+	}
+
+	for _, entry := range operators.Content {
+		addressNode := GetChildByKey(entry, "address")
+		if addressNode == nil || !strings.EqualFold(addressNode.Value, operatorAddress) {
+			continue
+		}
+		// The signer takes the place of ecdsa_key, so the plaintext key is gone from the file
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "signer"}
+		content := make([]*yaml.Node, 0, len(entry.Content))
+		replaced := false
+		for i := 0; i+1 < len(entry.Content); i += 2 {
+			switch entry.Content[i].Value {
+			case "ecdsa_key":
+				keyNode.HeadComment = entry.Content[i].HeadComment
+				content = append(content, keyNode, &signerNode)
+				replaced = true
+			case "signer":
+			default:
+				content = append(content, entry.Content[i], entry.Content[i+1])
+			}
+		}
+		if !replaced {
+			content = append(content, keyNode, &signerNode)
+		}
+		entry.Content = content
+		return true, nil
+	}
+	return false, nil
+}
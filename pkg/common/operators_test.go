@@ -0,0 +1,105 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const operatorsTestContext = `
+context:
+  operators:
+    - address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+      ecdsa_key: "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+    - address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+      ecdsa_key: "0x47e179ec197488593b187f80a00eb0da91f1b9d0b13f8733639f19c30a34926a"
+  operator_registrations:
+    - address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+      operator_set_id: 0
+      payload: "0x1234"
+    - address: "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+      operator_set_id: 1
+      payload: "0x1234"
+    - address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+      operator_set_id: 0
+      payload: "0x1234"
+`
+
+func loadOperatorsTestContext(t *testing.T) *yaml.Node {
+	t.Helper()
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(operatorsTestContext), &root))
+	return GetChildByKey(root.Content[0], "context")
+}
+
+func TestGetOperatorSpec(t *testing.T) {
+	ctx := ChainContextConfig{Operators: []OperatorSpec{
+		{ECDSAKey: "not-a-key"},
+		{ECDSAKey: "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6", MetadataURI: "https://op.example"},
+	}}
+
+	op, err := GetOperatorSpec(ctx, "0x90f79bf6eb2c4f870365e785982e1f101e93b906")
+	require.NoError(t, err)
+	assert.Equal(t, "https://op.example", op.MetadataURI)
+
+	_, err = GetOperatorSpec(ctx, "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65")
+	assert.ErrorContains(t, err, "not found in config")
+
+	// Operators with a signer reference are matched on their address
+	ctx.Operators = append(ctx.Operators, OperatorSpec{
+		Address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65",
+		Signer:  &SignerConfig{Type: SignerTypeKeystore, Path: "operator.json"},
+	})
+	op, err = GetOperatorSpec(ctx, "0x15d34aaf54267db7d7c367839aaf71a00a2c6a65")
+	require.NoError(t, err)
+	assert.Equal(t, "operator.json", op.Signer.Path)
+}
+
+func TestRemoveOperatorRegistrations(t *testing.T) {
+	contextNode := loadOperatorsTestContext(t)
+
+	removed := RemoveOperatorRegistrations(contextNode, "0x90f79bf6eb2c4f870365e785982e1f101e93b906", []uint32{1, 7})
+	assert.Equal(t, 1, removed)
+
+	var registrations []OperatorRegistration
+	require.NoError(t, GetChildByKey(contextNode, "operator_registrations").Decode(&registrations))
+	require.Len(t, registrations, 2)
+	assert.Equal(t, uint64(0), registrations[0].OperatorSetID)
+	assert.Equal(t, "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65", registrations[1].Address)
+
+	assert.Equal(t, 0, RemoveOperatorRegistrations(&yaml.Node{Kind: yaml.MappingNode}, "0x90F79bf6EB2c4f870365E785982E1f101E93b906", []uint32{0}))
+}
+
+func TestSetOperatorMetadataURI(t *testing.T) {
+	contextNode := loadOperatorsTestContext(t)
+
+	assert.True(t, SetOperatorMetadataURI(contextNode, "0x15d34aaf54267db7d7c367839aaf71a00a2c6a65", "https://op.example/v2.json"))
+	assert.False(t, SetOperatorMetadataURI(contextNode, "0x0000000000000000000000000000000000000001", "https://op.example"))
+
+	var operators []OperatorSpec
+	require.NoError(t, GetChildByKey(contextNode, "operators").Decode(&operators))
+	assert.Empty(t, operators[0].MetadataURI)
+	assert.Equal(t, "https://op.example/v2.json", operators[1].MetadataURI)
+}
+
+func TestSetOperatorSigner(t *testing.T) {
+	contextNode := loadOperatorsTestContext(t)
+	ref := SignerConfig{Type: SignerTypeKeystore, Path: "keystores/operator.json", PasswordEnv: "OPERATOR_PASSWORD"}
+
+	found, err := SetOperatorSigner(contextNode, "0x15d34aaf54267db7d7c367839aaf71a00a2c6a65", ref)
+	require.NoError(t, err)
+	assert.True(t, found)
+	found, err = SetOperatorSigner(contextNode, "0x0000000000000000000000000000000000000001", ref)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	var operators []OperatorSpec
+	require.NoError(t, GetChildByKey(contextNode, "operators").Decode(&operators))
+	assert.NotEmpty(t, operators[0].ECDSAKey)
+	assert.Nil(t, operators[0].Signer)
+	assert.Empty(t, operators[1].ECDSAKey, "the plaintext key is removed")
+	require.NotNil(t, operators[1].Signer)
+	assert.Equal(t, ref, *operators[1].Signer)
+}
@@ -0,0 +1,213 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	avsdirectory "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AVSDirectory"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	permissioncontroller "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/PermissionController"
+	rewardscoordinator "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/RewardsCoordinator"
+	strategybase "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyBase"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Selectors of the revert payloads Solidity emits for require/revert strings and for panics
+var (
+	revertStringSelector = [4]byte{0x08, 0xc3, 0x79, 0xa0}
+	revertPanicSelector  = [4]byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// RevertError is a contract revert whose data was decoded against the known ABIs
+type RevertError struct {
+	// Reason is the decoded error, e.g. `InvalidOperatorSet()` or `Error("not allowed")`
+	Reason string
+	// Data is the raw revert data
+	Data []byte
+	err  error
+}
+
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// RevertDecoder maps custom error selectors to their ABI definitions
+type RevertDecoder struct {
+	errors map[[4]byte]abi.Error
+}
+
+// NewRevertDecoder returns a decoder for the custom errors declared in abis
+func NewRevertDecoder(abis ...*abi.ABI) *RevertDecoder {
+	d := &RevertDecoder{errors: map[[4]byte]abi.Error{}}
+	for _, parsed := range abis {
+		d.Add(parsed)
+	}
+	return d
+}
+
+// Add registers the custom errors of parsed; errors already known by selector are kept
+func (d *RevertDecoder) Add(parsed *abi.ABI) {
+	if parsed == nil {
+		return
+	}
+	for _, abiErr := range parsed.Errors {
+		var selector [4]byte
+		copy(selector[:], abiErr.ID[:4])
+		if _, known := d.errors[selector]; !known {
+			d.errors[selector] = abiErr
+		}
+	}
+}
+
+// Known reports whether the revert data starts with a selector the decoder can decode
+func (d *RevertDecoder) Known(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	if selector == revertStringSelector || selector == revertPanicSelector {
+		return true
+	}
+	_, ok := d.errors[selector]
+	return ok
+}
+
+// Decode renders revert data as `Name(arg: value, ...)`, `Error("reason")` or `Panic(reason)`
+func (d *RevertDecoder) Decode(data []byte) string {
+	if len(data) == 0 {
+		return "no revert data"
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("malformed revert data %s", hexutil.Encode(data))
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	switch selector {
+	case revertStringSelector:
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			return fmt.Sprintf("Error(%q)", reason)
+		}
+	case revertPanicSelector:
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			return fmt.Sprintf("Panic(%s)", reason)
+		}
+	}
+
+	abiErr, ok := d.errors[selector]
+	if !ok {
+		return fmt.Sprintf("unknown custom error %s (data %s)", hexutil.Encode(selector[:]), hexutil.Encode(data))
+	}
+	values, err := abiErr.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Sprintf("%s (undecodable arguments %s)", abiErr.Sig, hexutil.Encode(data[4:]))
+	}
+	args := make([]string, len(values))
+	for i, value := range values {
+		name := abiErr.Inputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		if s, isString := value.(string); isString {
+			args[i] = fmt.Sprintf("%s: %q", name, s)
+		} else {
+			args[i] = fmt.Sprintf("%s: %v", name, value)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(args, ", "))
+}
+
+// RevertData extracts the revert data an RPC node attached to a failed call or gas estimation
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		return decoded, true
+	case []byte:
+		return data, true
+	}
+	return nil, false
+}
+
+var (
+	eigenLayerABIsOnce sync.Once
+	eigenLayerABIs     []*abi.ABI
+)
+
+// EigenLayerABIs returns the parsed ABIs of the EigenLayer core contracts devkit sends transactions to
+func EigenLayerABIs() []*abi.ABI {
+	eigenLayerABIsOnce.Do(func() {
+		for _, metadata := range []*bind.MetaData{
+			allocationmanager.AllocationManagerMetaData,
+			delegationmanager.DelegationManagerMetaData,
+			strategymanager.StrategyManagerMetaData,
+			rewardscoordinator.RewardsCoordinatorMetaData,
+			avsdirectory.AVSDirectoryMetaData,
+			permissioncontroller.PermissionControllerMetaData,
+			strategybase.StrategyBaseMetaData,
+		} {
+			if parsed, err := metadata.GetAbi(); err == nil {
+				eigenLayerABIs = append(eigenLayerABIs, parsed)
+			}
+		}
+	})
+	return eigenLayerABIs
+}
+
+// EigenLayerRevertDecoder returns a decoder for the custom errors of the EigenLayer core contracts
+func EigenLayerRevertDecoder() *RevertDecoder {
+	return NewRevertDecoder(EigenLayerABIs()...)
+}
+
+// LoadContractOutputABIs parses the abi of every contract output in dir, as written to
+// contracts/outputs/<context>/<name>.json after a deploy. A missing dir yields no ABIs.
+func LoadContractOutputABIs(dir string) ([]*abi.ABI, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	abis := []*abi.ABI{}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read contract output %s: %w", path, err)
+		}
+		var output struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(raw, &output); err != nil {
+			return nil, fmt.Errorf("parse contract output %s: %w", path, err)
+		}
+		if len(output.ABI) == 0 {
+			continue
+		}
+		parsed, err := abi.JSON(strings.NewReader(string(output.ABI)))
+		if err != nil {
+			return nil, fmt.Errorf("parse ABI in %s: %w", path, err)
+		}
+		abis = append(abis, &parsed)
+	}
+	return abis, nil
+}
@@ -0,0 +1,196 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTaskMailboxABI = `[{"type":"error","name":"TaskNotFound","inputs":[{"name":"taskId","type":"uint32"}]}]`
+
+// testDataError mimics the JSON-RPC error a node returns for a reverted call
+type testDataError struct {
+	data string
+}
+
+func (e testDataError) Error() string          { return "execution reverted" }
+func (e testDataError) ErrorData() interface{} { return e.data }
+
+func packCustomError(t *testing.T, parsed *abi.ABI, name string, args ...interface{}) []byte {
+	t.Helper()
+	abiErr, ok := parsed.Errors[name]
+	require.True(t, ok, "error %s not in ABI", name)
+	packed, err := abiErr.Inputs.Pack(args...)
+	require.NoError(t, err)
+	return append(append([]byte{}, abiErr.ID[:4]...), packed...)
+}
+
+func packRevertString(t *testing.T, reason string) []byte {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return append(revertStringSelector[:], packed...)
+}
+
+func writeContractOutput(t *testing.T, dir, name, abiJSON string) {
+	t.Helper()
+	content := `{"name":"` + name + `","address":"0x0000000000000000000000000000000000000001","abi":` + abiJSON + `}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0644))
+}
+
+func TestRevertDecoderDecode(t *testing.T) {
+	amABI, err := allocationmanager.AllocationManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	decoder := EigenLayerRevertDecoder()
+
+	panicData := append(revertPanicSelector[:], common.LeftPadBytes([]byte{0x11}, 32)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"custom error without arguments", packCustomError(t, amABI, "InvalidOperatorSet"), "InvalidOperatorSet()"},
+		{"custom error with arguments", packCustomError(t, amABI, "StringTooLong", "avs metadata"), `StringTooLong(str: "avs metadata")`},
+		{"revert string", packRevertString(t, "not allowed"), `Error("not allowed")`},
+		{"panic", panicData, "Panic(arithmetic underflow or overflow)"},
+		{"unknown selector", []byte{0xde, 0xad, 0xbe, 0xef}, "unknown custom error 0xdeadbeef (data 0xdeadbeef)"},
+		{"no data", nil, "no revert data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, decoder.Decode(tt.data))
+		})
+	}
+}
+
+func TestRevertData(t *testing.T) {
+	data, ok := RevertData(testDataError{data: "0xdeadbeef"})
+	require.True(t, ok)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, data)
+
+	_, ok = RevertData(errors.New("connection refused"))
+	assert.False(t, ok)
+
+	_, ok = RevertData(testDataError{data: "not hex"})
+	assert.False(t, ok)
+}
+
+func TestLoadContractOutputABIs(t *testing.T) {
+	dir := t.TempDir()
+	writeContractOutput(t, dir, "TaskMailbox", testTaskMailboxABI)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte(`{"name":"notes"}`), 0644))
+
+	abis, err := LoadContractOutputABIs(dir)
+	require.NoError(t, err)
+	require.Len(t, abis, 1)
+	assert.Contains(t, abis[0].Errors, "TaskNotFound")
+
+	abis, err = LoadContractOutputABIs(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, abis)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"abi": [{"type": 1}]}`), 0644))
+	_, err = LoadContractOutputABIs(dir)
+	assert.ErrorContains(t, err, "broken.json")
+}
+
+func newTestContractCaller(t *testing.T, rpcURL string, signer Signer) *ContractCaller {
+	t.Helper()
+	client, err := ethclient.Dial(rpcURL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	caller, err := NewContractCaller(signer, big.NewInt(31337), client, common.Address{}, common.Address{}, logger.NewNoopLogger())
+	require.NoError(t, err)
+	return caller
+}
+
+func TestContractCallerDecodesEstimationRevert(t *testing.T) {
+	caller := newTestContractCaller(t, "http://127.0.0.1:0", nil)
+	outputsDir := t.TempDir()
+	writeContractOutput(t, outputsDir, "TaskMailbox", testTaskMailboxABI)
+	caller.UseContractOutputs(outputsDir)
+
+	outputsABI, err := abi.JSON(strings.NewReader(testTaskMailboxABI))
+	require.NoError(t, err)
+	data := packCustomError(t, &outputsABI, "TaskNotFound", uint32(7))
+
+	err = caller.SendAndWaitForTransaction(context.Background(), "CreateTask", func() (*types.Transaction, error) {
+		return nil, testDataError{data: hexutil.Encode(data)}
+	})
+	require.Error(t, err)
+	assert.Equal(t, "CreateTask execution: execution reverted: TaskNotFound(taskId: 7)", err.Error())
+
+	var revertErr *RevertError
+	require.True(t, errors.As(err, &revertErr))
+	assert.Equal(t, data, revertErr.Data)
+}
+
+func TestContractCallerReplaysFailedTransaction(t *testing.T) {
+	amABI, err := allocationmanager.AllocationManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	revertData := packCustomError(t, amABI, "AlreadyMemberOfSet")
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(31337)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(31337),
+		Gas:       100000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		To:        &to,
+		Data:      []byte{0x01, 0x02},
+	})
+	require.NoError(t, err)
+
+	var callParams []json.RawMessage
+	server := rpctest.NewServer(t, rpctest.Handlers{
+		"eth_getTransactionReceipt": rpctest.Result(json.RawMessage(`{` +
+			`"status":"0x0","cumulativeGasUsed":"0x5208","gasUsed":"0x5208","logs":[],` +
+			`"logsBloom":"0x` + strings.Repeat("0", 512) + `",` +
+			`"transactionHash":"` + tx.Hash().Hex() + `","blockNumber":"0x2a","blockHash":"0x` + strings.Repeat("1", 64) + `"}`)),
+		"eth_call": func(params []json.RawMessage) (any, error) {
+			callParams = params
+			return nil, &rpctest.Error{Code: 3, Message: "execution reverted", Data: hexutil.Encode(revertData)}
+		},
+	})
+
+	caller := newTestContractCaller(t, server.URL, nil)
+	err = caller.SendAndWaitForTransaction(context.Background(), "AddOperatorToSet", func() (*types.Transaction, error) {
+		return tx, nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AddOperatorToSet transaction (hash: "+tx.Hash().Hex()+") failed: execution reverted: AlreadyMemberOfSet()")
+
+	// The replay runs as the original sender at the block the transaction was mined in
+	require.Len(t, callParams, 2)
+	assert.Equal(t, `"0x2a"`, string(callParams[1]))
+	var call struct {
+		From common.Address `json:"from"`
+		To   common.Address `json:"to"`
+	}
+	require.NoError(t, json.Unmarshal(callParams[0], &call))
+	assert.Equal(t, sender, call.From)
+	assert.Equal(t, to, call.To)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"syscall"
@@ -20,7 +21,21 @@ const (
 	ExpectJSONResponse
 )
 
+// ScriptOptions adjusts how CallTemplateScript runs a script
+type ScriptOptions struct {
+	// Output, when set, additionally receives everything the script writes to stdout and stderr
+	Output io.Writer
+	// Stderr, when set, replaces the terminal as the destination of the script's stderr
+	Stderr io.Writer
+	// Env is appended to the inherited environment
+	Env []string
+}
+
 func CallTemplateScript(cmdCtx context.Context, logger iface.Logger, dir string, scriptPath string, expect ResponseExpectation, params ...[]byte) (map[string]interface{}, error) {
+	return CallTemplateScriptWithOptions(cmdCtx, logger, dir, scriptPath, expect, ScriptOptions{}, params...)
+}
+
+func CallTemplateScriptWithOptions(cmdCtx context.Context, logger iface.Logger, dir string, scriptPath string, expect ResponseExpectation, opts ScriptOptions, params ...[]byte) (map[string]interface{}, error) {
 	// Get logger
 
 	// Convert byte params to strings
@@ -34,7 +49,18 @@ func CallTemplateScript(cmdCtx context.Context, logger iface.Logger, dir string,
 	cmd := exec.CommandContext(cmdCtx, scriptPath, stringParams...)
 	cmd.Dir = dir
 	cmd.Stdout = &stdout
-	cmd.Stderr = os.Stderr
+	var stderr io.Writer = os.Stderr
+	if opts.Stderr != nil {
+		stderr = opts.Stderr
+	}
+	cmd.Stderr = stderr
+	if opts.Output != nil {
+		cmd.Stdout = io.MultiWriter(&stdout, opts.Output)
+		cmd.Stderr = io.MultiWriter(stderr, opts.Output)
+	}
+	if len(opts.Env) > 0 {
+		cmd.Env = append(os.Environ(), opts.Env...)
+	}
 
 	// Run the command in its own group
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -0,0 +1,292 @@
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Signer types a context signer reference can name
+const (
+	SignerTypeKeystore   = "keystore"
+	SignerTypeWeb3Signer = "web3signer"
+)
+
+// Signer signs transactions for a single account, wherever its key is held
+type Signer interface {
+	// Address is the account the signer signs for
+	Address() common.Address
+	// SignTx returns tx signed for chainID
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// PrivateKeySigner signs with a private key held in memory
+type PrivateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner returns a signer for a hex-encoded private key, with or without 0x
+func NewPrivateKeySigner(privateKeyHex string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return newPrivateKeySigner(key), nil
+}
+
+func newPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *PrivateKeySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// NewKeystoreSigner decrypts a Web3 Secret Storage (V3) keystore file. The key stays decrypted in memory
+// for the life of the signer.
+func NewKeystoreSigner(path, password string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", path, err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return newPrivateKeySigner(key.PrivateKey), nil
+}
+
+// Web3Signer signs through the eth_signTransaction JSON-RPC method of a Web3Signer-compatible remote signer,
+// so the key never leaves it
+type Web3Signer struct {
+	url     string
+	address common.Address
+}
+
+// NewWeb3Signer returns a signer for address held by the remote signer at url
+func NewWeb3Signer(url string, address common.Address) *Web3Signer {
+	return &Web3Signer{url: url, address: address}
+}
+
+func (s *Web3Signer) Address() common.Address {
+	return s.address
+}
+
+// web3SignerTx is the transaction object eth_signTransaction takes
+type web3SignerTx struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+func (s *Web3Signer) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := web3SignerTx{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("remote signer: unsupported transaction type %d", tx.Type())
+	}
+
+	client, err := rpc.DialContext(ctx, s.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer %s: %w", s.url, err)
+	}
+	defer client.Close()
+
+	var raw hexutil.Bytes
+	if err := client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer %s failed to sign for %s: %w", s.url, s.address.Hex(), err)
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer %s returned an invalid transaction: %w", s.url, err)
+	}
+
+	// The signer must have signed exactly the transaction it was given, for this chain and account
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer %s signed a different transaction than requested", s.url)
+	}
+	from, err := types.Sender(txSigner, signed)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s returned an invalid signature: %w", s.url, err)
+	}
+	if from != s.address {
+		return nil, fmt.Errorf("remote signer %s signed with %s instead of %s", s.url, from.Hex(), s.address.Hex())
+	}
+	return signed, nil
+}
+
+// NewSignerFromConfig builds the signer a context signer reference describes. A remote signer signs for the
+// reference's address, or defaultAddress without one.
+func NewSignerFromConfig(cfg SignerConfig, defaultAddress string) (Signer, error) {
+	switch cfg.Type {
+	case SignerTypeKeystore:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("keystore signer needs a path")
+		}
+		password := cfg.Password
+		if cfg.PasswordEnv != "" {
+			var ok bool
+			if password, ok = os.LookupEnv(cfg.PasswordEnv); !ok {
+				return nil, fmt.Errorf("environment variable %s with the password of keystore %s is not set", cfg.PasswordEnv, cfg.Path)
+			}
+		}
+		return NewKeystoreSigner(cfg.Path, password)
+	case SignerTypeWeb3Signer:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("web3signer signer needs a url")
+		}
+		address := cfg.Address
+		if address == "" {
+			address = defaultAddress
+		}
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("web3signer signer needs the address it signs for, got %q", address)
+		}
+		return NewWeb3Signer(cfg.URL, common.HexToAddress(address)), nil
+	default:
+		return nil, fmt.Errorf("unknown signer type %q: use %s or %s", cfg.Type, SignerTypeKeystore, SignerTypeWeb3Signer)
+	}
+}
+
+// SignerAddress returns the account a signer reference signs for without building the signer, so no keystore is
+// decrypted: the reference's address, or defaultAddress, or for a keystore the address stored in the file
+func SignerAddress(cfg SignerConfig, defaultAddress string) (common.Address, error) {
+	address := cfg.Address
+	if address == "" {
+		address = defaultAddress
+	}
+	if address == "" && cfg.Type == SignerTypeKeystore && cfg.Path != "" {
+		keyJSON, err := os.ReadFile(cfg.Path)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to read keystore %s: %w", cfg.Path, err)
+		}
+		var header struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(keyJSON, &header); err != nil {
+			return common.Address{}, fmt.Errorf("failed to parse keystore %s: %w", cfg.Path, err)
+		}
+		address = header.Address
+	}
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("signer needs the address it signs for, got %q", address)
+	}
+	return common.HexToAddress(address), nil
+}
+
+// OperatorSigner returns the signer of a context operator: its signer reference, or else its ecdsa_key.
+// A signer reference has to sign for the operator's address.
+func OperatorSigner(op OperatorSpec) (Signer, error) {
+	if op.Signer == nil {
+		if op.ECDSAKey == "" {
+			return nil, fmt.Errorf("operator %s has neither an ecdsa_key nor a signer", op.Address)
+		}
+		signer, err := NewPrivateKeySigner(op.ECDSAKey)
+		if err != nil {
+			return nil, fmt.Errorf("operator %s: %w", op.Address, err)
+		}
+		return signer, nil
+	}
+
+	signer, err := NewSignerFromConfig(*op.Signer, op.Address)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w", op.Address, err)
+	}
+	if !strings.EqualFold(signer.Address().Hex(), op.Address) {
+		return nil, fmt.Errorf("operator %s: signer signs for %s", op.Address, signer.Address().Hex())
+	}
+	return signer, nil
+}
+
+// AVSSigner returns the signer of the AVS: avs.avs_signer, or else avs.avs_private_key. Without either it
+// returns nil, for a read-only ContractCaller.
+func AVSSigner(avs AvsConfig) (Signer, error) {
+	if avs.AVSSigner != nil {
+		signer, err := NewSignerFromConfig(*avs.AVSSigner, avs.Address)
+		if err != nil {
+			return nil, fmt.Errorf("avs_signer: %w", err)
+		}
+		return signer, nil
+	}
+	if avs.AVSPrivateKey == "" {
+		return nil, nil
+	}
+	signer, err := NewPrivateKeySigner(avs.AVSPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("avs_private_key: %w", err)
+	}
+	return signer, nil
+}
+
+// DeployerSigner returns the signer of the context's deployer: deployer_signer, or else deployer_private_key.
+// Without either it returns nil.
+func DeployerSigner(envCtx ChainContextConfig) (Signer, error) {
+	if envCtx.DeployerSigner != nil {
+		signer, err := NewSignerFromConfig(*envCtx.DeployerSigner, "")
+		if err != nil {
+			return nil, fmt.Errorf("deployer_signer: %w", err)
+		}
+		return signer, nil
+	}
+	if envCtx.DeployerPrivateKey == "" {
+		return nil, nil
+	}
+	signer, err := NewPrivateKeySigner(envCtx.DeployerPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("deployer_private_key: %w", err)
+	}
+	return signer, nil
+}
+
+// NewTransactOpts returns bind options whose transactions signer signs for chainID
+func NewTransactOpts(ctx context.Context, signer Signer, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, tx, chainID)
+		},
+		Context: ctx,
+	}
+}
@@ -0,0 +1,287 @@
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/rpctest"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anvil's first dev account
+const testSignerKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var testSignerAddress = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+func newTestUnsignedTx() *types.Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(31337),
+		Nonce:     3,
+		Gas:       100000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		To:        &to,
+		Value:     big.NewInt(5),
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+func writeTestKeystore(t *testing.T, hexKey, password string) string {
+	t.Helper()
+	key := mustHexToECDSA(t, hexKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, password, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0600))
+	return path
+}
+
+// newMockWeb3Signer serves eth_signTransaction like Web3Signer, signing with key after applying tamper
+func newMockWeb3Signer(t *testing.T, key *ecdsa.PrivateKey, tamper func(*types.DynamicFeeTx)) *httptest.Server {
+	t.Helper()
+	return rpctest.NewServer(t, rpctest.Handlers{
+		"eth_signTransaction": func(params []json.RawMessage) (any, error) {
+			require.Len(t, params, 1)
+			var args web3SignerTx
+			require.NoError(t, json.Unmarshal(params[0], &args))
+
+			inner := &types.DynamicFeeTx{
+				ChainID:   args.ChainID.ToInt(),
+				Nonce:     uint64(args.Nonce),
+				Gas:       uint64(args.Gas),
+				GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+				GasFeeCap: args.MaxFeePerGas.ToInt(),
+				To:        args.To,
+				Value:     args.Value.ToInt(),
+				Data:      args.Data,
+			}
+			if tamper != nil {
+				tamper(inner)
+			}
+			signed, err := types.SignNewTx(key, types.LatestSignerForChainID(inner.ChainID), inner)
+			require.NoError(t, err)
+			raw, err := signed.MarshalBinary()
+			require.NoError(t, err)
+			return hexutil.Bytes(raw), nil
+		},
+	})
+}
+
+func TestPrivateKeySigner(t *testing.T) {
+	signer, err := NewPrivateKeySigner(testSignerKey)
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	signed, err := signer.SignTx(context.Background(), newTestUnsignedTx(), big.NewInt(31337))
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(31337)), signed)
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, from)
+
+	_, err = NewPrivateKeySigner("0x1234")
+	assert.ErrorContains(t, err, "invalid private key")
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	path := writeTestKeystore(t, testSignerKey, "secret")
+
+	signer, err := NewKeystoreSigner(path, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	_, err = NewKeystoreSigner(path, "wrong")
+	assert.ErrorContains(t, err, "failed to decrypt keystore")
+
+	_, err = NewKeystoreSigner(filepath.Join(t.TempDir(), "missing.json"), "secret")
+	assert.ErrorContains(t, err, "failed to read keystore")
+}
+
+func TestWeb3Signer(t *testing.T) {
+	key := mustHexToECDSA(t, testSignerKey)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(31337)
+
+	t.Run("signs the requested transaction", func(t *testing.T) {
+		server := newMockWeb3Signer(t, key, nil)
+		tx := newTestUnsignedTx()
+		signed, err := NewWeb3Signer(server.URL, testSignerAddress).SignTx(context.Background(), tx, chainID)
+		require.NoError(t, err)
+		assert.Equal(t, types.LatestSignerForChainID(chainID).Hash(tx), types.LatestSignerForChainID(chainID).Hash(signed))
+		from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		assert.Equal(t, testSignerAddress, from)
+	})
+
+	t.Run("rejects a signature from another account", func(t *testing.T) {
+		server := newMockWeb3Signer(t, otherKey, nil)
+		_, err := NewWeb3Signer(server.URL, testSignerAddress).SignTx(context.Background(), newTestUnsignedTx(), chainID)
+		assert.ErrorContains(t, err, "instead of "+testSignerAddress.Hex())
+	})
+
+	t.Run("rejects a modified transaction", func(t *testing.T) {
+		server := newMockWeb3Signer(t, key, func(tx *types.DynamicFeeTx) { tx.Value = big.NewInt(1e18) })
+		_, err := NewWeb3Signer(server.URL, testSignerAddress).SignTx(context.Background(), newTestUnsignedTx(), chainID)
+		assert.ErrorContains(t, err, "signed a different transaction")
+	})
+}
+
+func TestNewSignerFromConfig(t *testing.T) {
+	path := writeTestKeystore(t, testSignerKey, "secret")
+
+	signer, err := NewSignerFromConfig(SignerConfig{Type: SignerTypeKeystore, Path: path, Password: "secret"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	t.Setenv("DEVKIT_TEST_KEYSTORE_PASSWORD", "secret")
+	signer, err = NewSignerFromConfig(SignerConfig{Type: SignerTypeKeystore, Path: path, PasswordEnv: "DEVKIT_TEST_KEYSTORE_PASSWORD"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	signer, err = NewSignerFromConfig(SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"}, testSignerAddress.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	tests := []struct {
+		name string
+		cfg  SignerConfig
+		want string
+	}{
+		{"keystore without path", SignerConfig{Type: SignerTypeKeystore}, "needs a path"},
+		{"unset password variable", SignerConfig{Type: SignerTypeKeystore, Path: path, PasswordEnv: "DEVKIT_TEST_UNSET_PASSWORD"}, "DEVKIT_TEST_UNSET_PASSWORD"},
+		{"web3signer without url", SignerConfig{Type: SignerTypeWeb3Signer}, "needs a url"},
+		{"web3signer without address", SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"}, "needs the address"},
+		{"unknown type", SignerConfig{Type: "ledger"}, `unknown signer type "ledger"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSignerFromConfig(tt.cfg, "")
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestOperatorSigner(t *testing.T) {
+	signer, err := OperatorSigner(OperatorSpec{Address: testSignerAddress.Hex(), ECDSAKey: testSignerKey})
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	_, err = OperatorSigner(OperatorSpec{Address: testSignerAddress.Hex()})
+	assert.ErrorContains(t, err, "neither an ecdsa_key nor a signer")
+
+	// The keystore holds anvil's first account, so it cannot sign for another operator
+	path := writeTestKeystore(t, testSignerKey, "secret")
+	ref := &SignerConfig{Type: SignerTypeKeystore, Path: path, Password: "secret"}
+	signer, err = OperatorSigner(OperatorSpec{Address: testSignerAddress.Hex(), Signer: ref})
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	_, err = OperatorSigner(OperatorSpec{Address: "0x00000000000000000000000000000000000000b0", Signer: ref})
+	assert.ErrorContains(t, err, "signer signs for "+testSignerAddress.Hex())
+}
+
+func TestAVSSigner(t *testing.T) {
+	signer, err := AVSSigner(AvsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, signer, "no key or signer means a read-only caller")
+
+	signer, err = AVSSigner(AvsConfig{AVSPrivateKey: testSignerKey})
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	// avs_signer takes precedence over avs_private_key
+	remote := common.HexToAddress("0x00000000000000000000000000000000000000a0")
+	signer, err = AVSSigner(AvsConfig{
+		Address:       remote.Hex(),
+		AVSPrivateKey: testSignerKey,
+		AVSSigner:     &SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, remote, signer.Address())
+
+	_, err = AVSSigner(AvsConfig{AVSSigner: &SignerConfig{Type: SignerTypeKeystore}})
+	assert.ErrorContains(t, err, "avs_signer")
+}
+
+func TestDeployerSigner(t *testing.T) {
+	signer, err := DeployerSigner(ChainContextConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, signer)
+
+	signer, err = DeployerSigner(ChainContextConfig{DeployerPrivateKey: testSignerKey})
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, signer.Address())
+
+	// deployer_signer takes precedence over deployer_private_key
+	remote := common.HexToAddress("0x00000000000000000000000000000000000000a0")
+	signer, err = DeployerSigner(ChainContextConfig{
+		DeployerPrivateKey: testSignerKey,
+		DeployerSigner:     &SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000", Address: remote.Hex()},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, remote, signer.Address())
+
+	_, err = DeployerSigner(ChainContextConfig{DeployerSigner: &SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"}})
+	assert.ErrorContains(t, err, "deployer_signer")
+}
+
+func TestSignerAddress(t *testing.T) {
+	// A keystore's address is read from the file without the password
+	path := writeTestKeystore(t, testSignerKey, "secret")
+	addr, err := SignerAddress(SignerConfig{Type: SignerTypeKeystore, Path: path}, "")
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, addr)
+
+	remote := common.HexToAddress("0x00000000000000000000000000000000000000a0")
+	addr, err = SignerAddress(SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"}, remote.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, remote, addr)
+
+	_, err = SignerAddress(SignerConfig{Type: SignerTypeWeb3Signer, URL: "http://localhost:9000"}, "")
+	assert.ErrorContains(t, err, "needs the address")
+}
+
+func TestContractCallerSignsWithSigner(t *testing.T) {
+	server := newMockWeb3Signer(t, mustHexToECDSA(t, testSignerKey), nil)
+	caller := &ContractCaller{signer: NewWeb3Signer(server.URL, testSignerAddress), chainID: big.NewInt(31337)}
+
+	opts, err := caller.buildTxOpts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, opts.From)
+	signed, err := opts.Signer(testSignerAddress, newTestUnsignedTx())
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(31337)), signed)
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, from)
+
+	_, err = opts.Signer(common.HexToAddress("0x00000000000000000000000000000000000000b0"), newTestUnsignedTx())
+	assert.Error(t, err)
+
+	_, err = (&ContractCaller{chainID: big.NewInt(31337)}).buildTxOpts(context.Background())
+	assert.ErrorContains(t, err, "read-only")
+}
+
+func mustHexToECDSA(t *testing.T, hexKey string) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.HexToECDSA(hexKey[2:])
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WAD is the fixed-point scale of EigenLayer slashing amounts, where 1e18 slashes everything allocated
+var WAD = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// ParseWadToSlash converts a fraction ("0.25") or percentage ("25%") of an allocation into a WAD amount
+func ParseWadToSlash(value string) (*big.Int, error) {
+	raw := strings.TrimSpace(value)
+	percent := strings.HasSuffix(raw, "%")
+	raw = strings.TrimSpace(strings.TrimSuffix(raw, "%"))
+
+	fraction, ok := new(big.Rat).SetString(raw)
+	if !ok || raw == "" || strings.ContainsAny(raw, "/eE") {
+		return nil, fmt.Errorf("invalid wad %q: use a fraction such as 0.25 or a percentage such as 25%%", value)
+	}
+	if percent {
+		fraction.Quo(fraction, big.NewRat(100, 1))
+	}
+
+	wad := new(big.Rat).Mul(fraction, new(big.Rat).SetInt(WAD))
+	if !wad.IsInt() {
+		return nil, fmt.Errorf("invalid wad %q: more precise than 18 decimals", value)
+	}
+	if wad.Sign() <= 0 || wad.Num().Cmp(WAD) > 0 {
+		return nil, fmt.Errorf("invalid wad %q: must be greater than 0 and at most 1 (100%%)", value)
+	}
+	return new(big.Int).Set(wad.Num()), nil
+}
+
+// AppendSlashingRecord adds record to the slashing_history of a context mapping node, creating the list if needed
+func AppendSlashingRecord(contextNode *yaml.Node, record SlashingRecord) error {
+	var recordNode yaml.Node
+	if err := recordNode.Encode(record); err != nil {
+		return fmt.Errorf("failed to encode slashing record: %w", err)
+	}
+
+	history := GetChildByKey(contextNode, "slashing_history")
+	if history == nil || history.Kind != yaml.SequenceNode {
+		history = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "slashing_history", HeadComment: "Slashes sent by `devkit avs slash`"}
+		SetMappingValue(contextNode, keyNode, history)
+	}
+	history.Style = 0
+	history.Content = append(history.Content, &recordNode)
+	return nil
+}
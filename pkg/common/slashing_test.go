@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseWadToSlash(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "0.25", expected: "250000000000000000"},
+		{input: "25%", expected: "250000000000000000"},
+		{input: " 1 ", expected: "1000000000000000000"},
+		{input: "100%", expected: "1000000000000000000"},
+		{input: ".5", expected: "500000000000000000"},
+		{input: "0.000000000000000001", expected: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			wad, err := ParseWadToSlash(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, wad.String())
+		})
+	}
+
+	for _, input := range []string{"", "%", "0", "-0.1", "1.5", "101%", "1/4", "1e-1", "half", "0.0000000000000000001"} {
+		_, err := ParseWadToSlash(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestAppendSlashingRecord(t *testing.T) {
+	record := SlashingRecord{
+		Operator:      "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+		OperatorSetID: 0,
+		Description:   "missed task",
+		TxHash:        "0xabc",
+		BlockNumber:   42,
+		Timestamp:     "2025-01-01T00:00:00Z",
+		Strategies: []SlashedStrategy{{
+			Strategy:                 "0x93c4b944D05dfe6df7645A86cd2206016c51564D",
+			WadToSlash:               "250000000000000000",
+			AllocatedMagnitudeBefore: 1000000000000000000,
+			AllocatedMagnitudeAfter:  750000000000000000,
+			MaxMagnitudeBefore:       1000000000000000000,
+			MaxMagnitudeAfter:        750000000000000000,
+		}},
+	}
+
+	for name, input := range map[string]string{
+		"empty list":   "context:\n  name: devnet\n  slashing_history: []\n",
+		"missing list": "context:\n  name: devnet\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var root yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(input), &root))
+			contextNode := GetChildByKey(root.Content[0], "context")
+
+			require.NoError(t, AppendSlashingRecord(contextNode, record))
+			require.NoError(t, AppendSlashingRecord(contextNode, record))
+
+			out, err := yaml.Marshal(&root)
+			require.NoError(t, err)
+			var parsed ContextConfig
+			require.NoError(t, yaml.Unmarshal(out, &parsed))
+			require.Len(t, parsed.Context.SlashingHistory, 2)
+			assert.Equal(t, record, parsed.Context.SlashingHistory[1])
+			assert.NotContains(t, string(out), "slashing_history: [")
+		})
+	}
+}
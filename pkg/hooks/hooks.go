@@ -1,6 +1,7 @@
 package hooks
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -185,6 +186,41 @@ func WithMetricEmission(action cli.ActionFunc) cli.ActionFunc {
 	}
 }
 
+// WithDryRun runs commands given --dry-run or --dry-run-output with a dry-run report in their context, so the
+// ContractCaller simulates their transactions instead of broadcasting them, then prints the report
+func WithDryRun(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		output := ctx.String("dry-run-output")
+		if !ctx.Bool("dry-run") && output == "" {
+			return action(ctx)
+		}
+
+		logger := common.LoggerFromContext(ctx.Context)
+		logger.Info("Dry run: transactions are simulated against the current chain state and not broadcast")
+		report := common.NewDryRunReport()
+		ctx.Context = common.WithDryRun(ctx.Context, report)
+
+		err := action(ctx)
+
+		report.Print(logger)
+		if output != "" {
+			if writeErr := report.WriteJSON(output); writeErr != nil {
+				return errors.Join(err, writeErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if failed := report.Failed(); failed > 0 {
+			return fmt.Errorf("dry run: %d of %d transaction(s) would fail", failed, len(report.Calls))
+		}
+		if unverified := report.Unverified(); unverified > 0 {
+			logger.Warn("Dry run: %d transaction(s) could not be verified because they depend on earlier ones; they are not counted as failures", unverified)
+		}
+		return nil
+	}
+}
+
 func emitTelemetryMetrics(ctx *cli.Context, actionError error) {
 	metrics, err := telemetry.MetricsFromContext(ctx.Context)
 	if err != nil {
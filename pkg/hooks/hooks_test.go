@@ -2,11 +2,17 @@ package hooks
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
 	"github.com/Layr-Labs/devkit-cli/pkg/telemetry"
 
 	"github.com/urfave/cli/v2"
@@ -206,3 +212,51 @@ func TestWithTelemetryError(t *testing.T) {
 		t.Errorf("Expected duration metric, got '%s'", mockClient.metrics[2].Name)
 	}
 }
+
+func TestWithDryRun(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "dry-run.json")
+	var sawDryRun bool
+	app := &cli.App{
+		Name: "testapp",
+		Commands: []*cli.Command{{
+			Name:  "send",
+			Flags: common.DryRunFlags,
+			Action: WithDryRun(func(ctx *cli.Context) error {
+				report := common.DryRunFromContext(ctx.Context)
+				sawDryRun = report != nil
+				if report != nil {
+					report.Add(common.DryRunCall{Description: "reverts", Revert: "InvalidOperatorSet()"})
+				}
+				return nil
+			}),
+		}},
+	}
+	ctx := common.WithLogger(context.Background(), logger.NewNoopLogger())
+
+	if err := app.RunContext(ctx, []string{"testapp", "send"}); err != nil {
+		t.Fatalf("Expected no error without --dry-run, got %v", err)
+	}
+	if sawDryRun {
+		t.Errorf("Expected no dry-run report without --dry-run")
+	}
+
+	err := app.RunContext(ctx, []string{"testapp", "send", "--dry-run-output", outputPath})
+	if !sawDryRun {
+		t.Fatalf("Expected --dry-run-output to imply a dry run")
+	}
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 transaction(s) would fail") {
+		t.Errorf("Expected the reverting transaction to fail the dry run, got %v", err)
+	}
+
+	raw, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Expected the dry-run report to be written: %v", err)
+	}
+	var report common.DryRunReport
+	if err := json.Unmarshal(raw, &report); err != nil {
+		t.Fatalf("Failed to parse dry-run report: %v", err)
+	}
+	if len(report.Calls) != 1 || report.Calls[0].Revert != "InvalidOperatorSet()" {
+		t.Errorf("Unexpected dry-run report: %s", raw)
+	}
+}
@@ -0,0 +1,94 @@
+// Package rpctest serves fake Ethereum JSON-RPC endpoints for tests.
+package rpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// Handler answers one JSON-RPC method. The returned result is JSON encoded unless it is already a
+// json.RawMessage; a returned error is sent as a JSON-RPC error (see Error for custom codes and revert data).
+type Handler func(params []json.RawMessage) (any, error)
+
+// Handlers maps JSON-RPC method names to their handlers
+type Handlers map[string]Handler
+
+// Error is a JSON-RPC error with an explicit code and optional data, e.g. the revert data of eth_call
+type Error struct {
+	Code    int
+	Message string
+	Data    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Result returns a handler that always answers with result
+func Result(result any) Handler {
+	return func([]json.RawMessage) (any, error) {
+		return result, nil
+	}
+}
+
+// NewServer serves handlers until the test ends. Requests are handled one at a time, so handlers may share
+// state without locking. A method without a handler fails the test and is answered with a method-not-found error.
+func NewServer(t testing.TB, handlers Handlers) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("rpctest: decode request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		handler, ok := handlers[req.Method]
+		var (
+			result any
+			err    error
+		)
+		if ok {
+			result, err = handler(req.Params)
+		} else {
+			t.Errorf("rpctest: unexpected RPC method %s", req.Method)
+			err = &Error{Code: -32601, Message: fmt.Sprintf("method %s not supported", req.Method)}
+		}
+		mu.Unlock()
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if err != nil {
+			rpcErr, ok := err.(*Error)
+			if !ok {
+				rpcErr = &Error{Code: -32000, Message: err.Error()}
+			}
+			errObj := map[string]any{"code": rpcErr.Code, "message": rpcErr.Message}
+			if rpcErr.Data != "" {
+				errObj["data"] = rpcErr.Data
+			}
+			resp["error"] = errObj
+		} else {
+			if result == nil {
+				result = json.RawMessage("null")
+			}
+			resp["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("rpctest: encode response: %v", err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -1,6 +1,7 @@
 package migration_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Layr-Labs/devkit-cli/config/contexts"
@@ -432,7 +433,164 @@ func TestAVSContextMigration_0_0_4_to_0_0_5(t *testing.T) {
 	})
 }
 
-// TestAVSContextMigration_FullChain tests migrating through the entire chain from 0.0.1 to 0.0.5
+// TestAVSContextMigration_0_0_5_to_0_0_6 tests the migration from version 0.0.5 to 0.0.6
+// which adds the funding section
+func TestAVSContextMigration_0_0_5_to_0_0_6(t *testing.T) {
+	// Use the embedded v0.0.5 content as our starting point
+	userYAML := string(contexts.ContextYamls["0.0.5"])
+
+	userNode := testNode(t, userYAML)
+
+	// Get the actual migration step
+	var migrationStep migration.MigrationStep
+	for _, step := range contexts.MigrationChain {
+		if step.From == "0.0.5" && step.To == "0.0.6" {
+			migrationStep = step
+			break
+		}
+	}
+	if migrationStep.Apply == nil {
+		t.Fatal("Could not find 0.0.5 -> 0.0.6 migration step")
+	}
+
+	// Execute migration
+	migrationChain := []migration.MigrationStep{migrationStep}
+	migratedNode, err := migration.MigrateNode(userNode, "0.0.5", "0.0.6", migrationChain)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	// Verify results
+	t.Run("version updated", func(t *testing.T) {
+		version := migration.ResolveNode(migratedNode, []string{"version"})
+		if version == nil || version.Value != "0.0.6" {
+			t.Errorf("Expected version to be updated to 0.0.6, got %v", version.Value)
+		}
+	})
+
+	t.Run("funding section added", func(t *testing.T) {
+		targetBalance := migration.ResolveNode(migratedNode, []string{"context", "funding", "target_balance"})
+		if targetBalance == nil || targetBalance.Value != "10ETH" {
+			t.Errorf("Expected funding.target_balance to be 10ETH, got %v", targetBalance)
+		}
+		extraAddresses := migration.ResolveNode(migratedNode, []string{"context", "funding", "extra_addresses"})
+		if extraAddresses == nil || extraAddresses.Kind != yaml.SequenceNode {
+			t.Error("Expected funding.extra_addresses list to be added")
+		}
+	})
+}
+
+// TestAVSContextMigration_0_0_6_to_0_0_7 tests the migration from version 0.0.6 to 0.0.7
+// which adds the devnet backend selection
+func TestAVSContextMigration_0_0_6_to_0_0_7(t *testing.T) {
+	// Use the embedded v0.0.6 content as our starting point
+	userYAML := string(contexts.ContextYamls["0.0.6"])
+
+	userNode := testNode(t, userYAML)
+
+	// Get the actual migration step
+	var migrationStep migration.MigrationStep
+	for _, step := range contexts.MigrationChain {
+		if step.From == "0.0.6" && step.To == "0.0.7" {
+			migrationStep = step
+			break
+		}
+	}
+	if migrationStep.Apply == nil {
+		t.Fatal("Could not find 0.0.6 -> 0.0.7 migration step")
+	}
+
+	// Execute migration
+	migrationChain := []migration.MigrationStep{migrationStep}
+	migratedNode, err := migration.MigrateNode(userNode, "0.0.6", "0.0.7", migrationChain)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	// Verify results
+	t.Run("version updated", func(t *testing.T) {
+		version := migration.ResolveNode(migratedNode, []string{"version"})
+		if version == nil || version.Value != "0.0.7" {
+			t.Errorf("Expected version to be updated to 0.0.7, got %v", version.Value)
+		}
+	})
+
+	t.Run("devnet_backend added", func(t *testing.T) {
+		backend := migration.ResolveNode(migratedNode, []string{"context", "devnet_backend"})
+		if backend == nil || backend.Value != "docker" {
+			t.Errorf("Expected devnet_backend to be docker, got %v", backend)
+		}
+	})
+
+	t.Run("existing devnet_backend preserved", func(t *testing.T) {
+		userNode := testNode(t, strings.Replace(userYAML, "  name: \"devnet\"\n", "  name: \"devnet\"\n  devnet_backend: \"anvil\"\n", 1))
+		migratedNode, err := migration.MigrateNode(userNode, "0.0.6", "0.0.7", migrationChain)
+		if err != nil {
+			t.Fatalf("Migration failed: %v", err)
+		}
+		backend := migration.ResolveNode(migratedNode, []string{"context", "devnet_backend"})
+		if backend == nil || backend.Value != "anvil" {
+			t.Errorf("Expected devnet_backend to stay anvil, got %v", backend)
+		}
+	})
+}
+
+// TestAVSContextMigration_0_0_7_to_0_0_8 tests the migration from version 0.0.7 to 0.0.8
+// which adds the slashing history
+func TestAVSContextMigration_0_0_7_to_0_0_8(t *testing.T) {
+	// Use the embedded v0.0.7 content as our starting point
+	userYAML := string(contexts.ContextYamls["0.0.7"])
+
+	userNode := testNode(t, userYAML)
+
+	// Get the actual migration step
+	var migrationStep migration.MigrationStep
+	for _, step := range contexts.MigrationChain {
+		if step.From == "0.0.7" && step.To == "0.0.8" {
+			migrationStep = step
+			break
+		}
+	}
+	if migrationStep.Apply == nil {
+		t.Fatal("Could not find 0.0.7 -> 0.0.8 migration step")
+	}
+
+	// Execute migration
+	migrationChain := []migration.MigrationStep{migrationStep}
+	migratedNode, err := migration.MigrateNode(userNode, "0.0.7", "0.0.8", migrationChain)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	// Verify results
+	t.Run("version updated", func(t *testing.T) {
+		version := migration.ResolveNode(migratedNode, []string{"version"})
+		if version == nil || version.Value != "0.0.8" {
+			t.Errorf("Expected version to be updated to 0.0.8, got %v", version.Value)
+		}
+	})
+
+	t.Run("slashing_history added", func(t *testing.T) {
+		history := migration.ResolveNode(migratedNode, []string{"context", "slashing_history"})
+		if history == nil || history.Kind != yaml.SequenceNode || len(history.Content) != 0 {
+			t.Errorf("Expected an empty slashing_history list, got %v", history)
+		}
+	})
+
+	t.Run("existing slashing_history preserved", func(t *testing.T) {
+		userNode := testNode(t, userYAML+"  slashing_history:\n    - operator: \"0x90F79bf6EB2c4f870365E785982E1f101E93b906\"\n")
+		migratedNode, err := migration.MigrateNode(userNode, "0.0.7", "0.0.8", migrationChain)
+		if err != nil {
+			t.Fatalf("Migration failed: %v", err)
+		}
+		history := migration.ResolveNode(migratedNode, []string{"context", "slashing_history"})
+		if history == nil || len(history.Content) != 1 {
+			t.Errorf("Expected the existing slashing_history entry to be kept, got %v", history)
+		}
+	})
+}
+
+// TestAVSContextMigration_FullChain tests migrating through the entire chain from 0.0.1 to 0.0.8
 func TestAVSContextMigration_FullChain(t *testing.T) {
 	// Use the embedded v0.0.1 content as our starting point
 	userYAML := string(contexts.ContextYamls["0.0.1"])
@@ -440,16 +598,16 @@ func TestAVSContextMigration_FullChain(t *testing.T) {
 	userNode := testNode(t, userYAML)
 
 	// Execute migration through the entire chain
-	migratedNode, err := migration.MigrateNode(userNode, "0.0.1", "0.0.5", contexts.MigrationChain)
+	migratedNode, err := migration.MigrateNode(userNode, "0.0.1", "0.0.8", contexts.MigrationChain)
 	if err != nil {
 		t.Fatalf("Full chain migration failed: %v", err)
 	}
 
 	// Verify final state
-	t.Run("final version is 0.0.5", func(t *testing.T) {
+	t.Run("final version is 0.0.8", func(t *testing.T) {
 		version := migration.ResolveNode(migratedNode, []string{"version"})
-		if version == nil || version.Value != "0.0.5" {
-			t.Errorf("Expected final version to be 0.0.5, got %v", version.Value)
+		if version == nil || version.Value != "0.0.8" {
+			t.Errorf("Expected final version to be 0.0.8, got %v", version.Value)
 		}
 	})
 
@@ -471,6 +629,24 @@ func TestAVSContextMigration_FullChain(t *testing.T) {
 		if deployedContracts == nil {
 			t.Error("Expected deployed_contracts section to be added")
 		}
+
+		// Check that funding was added (from 0.0.5→0.0.6)
+		funding := migration.ResolveNode(migratedNode, []string{"context", "funding"})
+		if funding == nil {
+			t.Error("Expected funding section to be added")
+		}
+
+		// Check that the devnet backend was added (from 0.0.6→0.0.7)
+		backend := migration.ResolveNode(migratedNode, []string{"context", "devnet_backend"})
+		if backend == nil || backend.Value != "docker" {
+			t.Error("Expected devnet_backend to be added")
+		}
+
+		// Check that the slashing history was added (from 0.0.7→0.0.8)
+		history := migration.ResolveNode(migratedNode, []string{"context", "slashing_history"})
+		if history == nil {
+			t.Error("Expected slashing_history section to be added")
+		}
 	})
 
 	t.Run("user customizations preserved", func(t *testing.T) {